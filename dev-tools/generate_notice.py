@@ -308,6 +308,10 @@ UNIVERSAL_PERMISSIVE_LICENSE_TITLES = [
     "The Universal Permissive License (UPL), Version 1.0"
 ]
 
+ISC_LICENSE_TITLE = [
+    "ISC License"
+]
+
 
 # return SPDX identifiers from https://spdx.org/licenses/
 def detect_license_summary(content):
@@ -334,6 +338,8 @@ def detect_license_summary(content):
         return "LGPL-3.0"
     if any(sentence in content[0:1500] for sentence in UNIVERSAL_PERMISSIVE_LICENSE_TITLES):
         return "UPL-1.0"
+    if any(sentence in content[0:100] for sentence in ISC_LICENSE_TITLE):
+        return "ISC"
 
     return "UNKNOWN"
 
@@ -346,6 +352,7 @@ ACCEPTED_LICENSES = [
     "BSD-2-Clause",
     "MPL-2.0",
     "UPL-1.0",
+    "ISC",
 ]
 SKIP_NOTICE = []
 
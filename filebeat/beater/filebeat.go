@@ -47,6 +47,7 @@ import (
 
 	// Add filebeat level processors
 	_ "github.com/elastic/beats/filebeat/processor/add_kubernetes_metadata"
+	_ "github.com/elastic/beats/filebeat/processor/decode_syslog"
 	_ "github.com/elastic/beats/libbeat/processors/decode_csv_fields"
 )
 
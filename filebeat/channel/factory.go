@@ -47,6 +47,9 @@ type inputOutletConfig struct {
 	common.EventMetadata `config:",inline"`      // Fields and tags to add to events.
 	Processors           processors.PluginConfig `config:"processors"`
 
+	// ID identifies the input in the monitoring metrics
+	ID string `config:"id"`
+
 	// implicit event fields
 	Type        string `config:"type"`         // input.type
 	ServiceType string `config:"service.type"` // service.type
@@ -81,8 +84,10 @@ func NewOutletFactory(
 // Create builds a new Outleter, while applying common input settings.
 // Inputs and all harvesters use the same pipeline client instance.
 // This guarantees ordering between events as required by the registrar for
-// file.State updates
-func (f *OutletFactory) Create(p beat.Pipeline, cfg *common.Config, dynFields *common.MapStrPointer) (Outleter, error) {
+// file.State updates.
+// Client settings like the dynamic fields and ACK handlers passed by the input
+// are forwarded to the pipeline client.
+func (f *OutletFactory) Create(p beat.Pipeline, cfg *common.Config, clientCfg beat.ClientConfig) (Outleter, error) {
 	config := inputOutletConfig{}
 	if err := cfg.Unpack(&config); err != nil {
 		return nil, err
@@ -124,17 +129,23 @@ func (f *OutletFactory) Create(p beat.Pipeline, cfg *common.Config, dynFields *c
 		fields.Put("input.type", config.Type)
 	}
 
-	client, err := p.ConnectWith(beat.ClientConfig{
-		PublishMode: beat.GuaranteedSend,
-		Processing: beat.ProcessingConfig{
-			EventMetadata: config.EventMetadata,
-			DynamicFields: dynFields,
-			Meta:          meta,
-			Fields:        fields,
-			Processor:     processors,
-		},
-		Events: f.eventer,
-	})
+	clientCfg.PublishMode = beat.GuaranteedSend
+	clientCfg.Processing = beat.ProcessingConfig{
+		EventMetadata: config.EventMetadata,
+		DynamicFields: clientCfg.Processing.DynamicFields,
+		Meta:          meta,
+		Fields:        fields,
+		Processor:     processors,
+	}
+	clientCfg.Events = f.eventer
+	if clientCfg.Identity == nil {
+		clientCfg.Identity, err = inputIdentity(cfg, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := p.ConnectWith(clientCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +157,17 @@ func (f *OutletFactory) Create(p beat.Pipeline, cfg *common.Config, dynFields *c
 	return outlet, nil
 }
 
+// inputIdentity identifies the pipeline client of an input in the monitoring
+// metrics. Inputs without an ID are identified by their type and the hash of
+// their configuration, as logged when the input is started.
+func inputIdentity(cfg *common.Config, config inputOutletConfig) (*beat.ClientIdentity, error) {
+	return beat.NewClientIdentity(config.ID, config.Type, cfg, map[string]string{
+		"type":    config.Type,
+		"module":  config.Module,
+		"fileset": config.Fileset,
+	})
+}
+
 func (*clientEventer) Closing()   {}
 func (*clientEventer) Closed()    {}
 func (*clientEventer) Published() {}
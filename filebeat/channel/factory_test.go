@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package channel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestInputIdentity(t *testing.T) {
+	identity := func(t *testing.T, settings common.MapStr) (string, map[string]string) {
+		cfg := common.MustNewConfigFrom(settings)
+		var config inputOutletConfig
+		require.NoError(t, cfg.Unpack(&config))
+
+		identity, err := inputIdentity(cfg, config)
+		require.NoError(t, err)
+		return identity.ID, identity.Fields
+	}
+
+	t.Run("configured ID", func(t *testing.T) {
+		id, fields := identity(t, common.MapStr{"type": "log", "id": "nginx-access"})
+		assert.Equal(t, "nginx-access", id)
+		assert.Equal(t, map[string]string{"type": "log"}, fields)
+	})
+
+	t.Run("module input", func(t *testing.T) {
+		settings := common.MapStr{
+			"type":          "log",
+			"paths":         []string{"/var/log/nginx/access.log"},
+			"_module_name":  "nginx",
+			"_fileset_name": "access",
+		}
+		id, fields := identity(t, settings)
+		assert.True(t, strings.HasPrefix(id, "log-"), id)
+		assert.Equal(t, map[string]string{"type": "log", "module": "nginx", "fileset": "access"}, fields)
+
+		// the ID is stable for the same configuration
+		again, _ := identity(t, settings)
+		assert.Equal(t, id, again)
+
+		settings["paths"] = []string{"/var/log/nginx/other.log"}
+		other, _ := identity(t, settings)
+		assert.NotEqual(t, id, other)
+	})
+}
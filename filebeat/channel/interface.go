@@ -24,10 +24,17 @@ import (
 )
 
 // Factory is used to create a new Outlet instance
-type Factory func(beat.Pipeline, *common.Config, *common.MapStrPointer) (Outleter, error)
+type Factory func(beat.Pipeline, *common.Config, beat.ClientConfig) (Outleter, error)
 
 // Connector creates an Outlet connecting the event publishing with some internal pipeline.
-type Connector func(*common.Config, *common.MapStrPointer) (Outleter, error)
+type Connector interface {
+	// Connect creates an Outlet adding the dynamic fields to every event.
+	Connect(*common.Config, *common.MapStrPointer) (Outleter, error)
+
+	// ConnectWith creates an Outlet passing additional client settings, like
+	// ACK handlers, to the publisher pipeline.
+	ConnectWith(*common.Config, beat.ClientConfig) (Outleter, error)
+}
 
 // Outleter is the outlet for an input
 type Outleter interface {
@@ -33,11 +33,28 @@ type subOutlet struct {
 	closeOnce sync.Once
 }
 
+// ConnectorFunc is an adapter for using ordinary functions as Connector.
+type ConnectorFunc func(*common.Config, beat.ClientConfig) (Outleter, error)
+
 // ConnectTo creates a new Connector, combining a beat.Pipeline with an outlet Factory.
 func ConnectTo(pipeline beat.Pipeline, factory Factory) Connector {
-	return func(cfg *common.Config, m *common.MapStrPointer) (Outleter, error) {
-		return factory(pipeline, cfg, m)
-	}
+	return ConnectorFunc(func(cfg *common.Config, clientCfg beat.ClientConfig) (Outleter, error) {
+		return factory(pipeline, cfg, clientCfg)
+	})
+}
+
+// Connect calls the function passing the dynamic fields as client setting.
+func (fn ConnectorFunc) Connect(cfg *common.Config, m *common.MapStrPointer) (Outleter, error) {
+	return fn(cfg, beat.ClientConfig{
+		Processing: beat.ProcessingConfig{
+			DynamicFields: m,
+		},
+	})
+}
+
+// ConnectWith calls the function.
+func (fn ConnectorFunc) ConnectWith(cfg *common.Config, clientCfg beat.ClientConfig) (Outleter, error) {
+	return fn(cfg, clientCfg)
 }
 
 // SubOutlet create a sub-outlet, which can be closed individually, without closing the
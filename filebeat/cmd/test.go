@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/filebeat/fileset"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/outputs/elasticsearch"
+)
+
+func genTestPipelineCmd(settings instance.Settings) *cobra.Command {
+	testPipelineCmd := &cobra.Command{
+		Use:   "pipeline",
+		Short: "Test a fileset ingest pipeline against a sample log file",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			moduleName, _ := cmd.Flags().GetString("module")
+			filesetName, _ := cmd.Flags().GetString("fileset")
+			file, _ := cmd.Flags().GetString("file")
+			expectedFile, _ := cmd.Flags().GetString("expected")
+
+			if moduleName == "" || filesetName == "" || file == "" {
+				return errors.New("the --module, --fileset and --file flags are required")
+			}
+
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				return errors.Wrap(err, "error initializing beat")
+			}
+
+			if b.Config.Output.Name() != "elasticsearch" {
+				return errors.New("testing pipelines requires the Elasticsearch output to be configured")
+			}
+			esClient, err := elasticsearch.NewConnectedClient(b.Config.Output.Config())
+			if err != nil {
+				return errors.Wrap(err, "error creating Elasticsearch client")
+			}
+
+			moduleConfig, err := common.NewConfigFrom(common.MapStr{"module": moduleName})
+			if err != nil {
+				return err
+			}
+			registry, err := fileset.NewModuleRegistry([]*common.Config{moduleConfig}, b.Info.Version, false)
+			if err != nil {
+				return errors.Wrap(err, "error loading module")
+			}
+			fs, err := registry.Fileset(moduleName, filesetName)
+			if err != nil {
+				return err
+			}
+
+			docs, err := fs.ReadSample(file)
+			if err != nil {
+				return errors.Wrap(err, "error reading sample file")
+			}
+
+			results, err := fs.SimulatePipeline(esClient, docs)
+			if err != nil {
+				return errors.Wrap(err, "error simulating pipeline")
+			}
+			results = fileset.NormalizeEvents(results)
+
+			out, err := json.MarshalIndent(results, "", "    ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+
+			if expectedFile == "" {
+				expectedFile = file + "-expected.json"
+				if _, err := os.Stat(expectedFile); os.IsNotExist(err) {
+					return nil
+				}
+			}
+			expected, err := fileset.ReadExpectedEvents(expectedFile)
+			if err != nil {
+				return err
+			}
+
+			if diff := fileset.DiffEvents(expected, results); diff != "" {
+				fmt.Fprintf(os.Stderr, "Events differ from %s:\n%s", expectedFile, diff)
+				return errors.Errorf("events differ from %s", expectedFile)
+			}
+			fmt.Fprintf(os.Stderr, "Events match %s\n", expectedFile)
+			return nil
+		}),
+	}
+
+	testPipelineCmd.Flags().String("module", "", "Name of the module")
+	testPipelineCmd.Flags().String("fileset", "", "Name of the fileset")
+	testPipelineCmd.Flags().String("file", "", "Path to the sample log file")
+	testPipelineCmd.Flags().String("expected", "", "Path to the expected events, defaults to the sample file path with a -expected.json suffix")
+
+	return testPipelineCmd
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/elastic/beats/libbeat/autodiscover"
 	"github.com/elastic/beats/libbeat/cfgfile"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgtype"
 	"github.com/elastic/beats/libbeat/common/cfgwarn"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/paths"
@@ -51,18 +52,22 @@ type Config struct {
 }
 
 type Registry struct {
-	Path         string        `config:"path"`
-	Permissions  os.FileMode   `config:"file_permissions"`
-	FlushTimeout time.Duration `config:"flush"`
-	MigrateFile  string        `config:"migrate_file"`
+	Path           string           `config:"path"`
+	Permissions    os.FileMode      `config:"file_permissions"`
+	FlushTimeout   time.Duration    `config:"flush"`
+	MigrateFile    string           `config:"migrate_file"`
+	Type           string           `config:"type"`
+	CheckpointSize cfgtype.ByteSize `config:"checkpoint_size" validate:"min=1"`
 }
 
 var (
 	DefaultConfig = Config{
 		Registry: Registry{
-			Path:        "registry",
-			Permissions: 0600,
-			MigrateFile: "",
+			Path:           "registry",
+			Permissions:    0600,
+			MigrateFile:    "",
+			Type:           "json",
+			CheckpointSize: 10 * 1024 * 1024,
 		},
 		ShutdownTimeout:    0,
 		OverwritePipelines: false,
@@ -0,0 +1,426 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fileset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/match"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/reader"
+	"github.com/elastic/beats/libbeat/reader/multiline"
+	"github.com/elastic/beats/libbeat/reader/readfile"
+	"github.com/elastic/beats/libbeat/reader/readfile/encoding"
+	"github.com/elastic/beats/libbeat/reader/readjson"
+)
+
+// sampleConfig is the subset of the log input settings that decide how a
+// sample file is split into events before they reach the ingest pipeline.
+type sampleConfig struct {
+	Type           string                  `config:"type"`
+	ServiceType    string                  `config:"service.type"`
+	Encoding       string                  `config:"encoding"`
+	BufferSize     int                     `config:"harvester_buffer_size"`
+	LineTerminator readfile.LineTerminator `config:"line_terminator"`
+	MaxBytes       int                     `config:"max_bytes" validate:"min=0,nonzero"`
+	ExcludeLines   []match.Matcher         `config:"exclude_lines"`
+	IncludeLines   []match.Matcher         `config:"include_lines"`
+	Multiline      *multiline.Config       `config:"multiline"`
+	JSON           *readjson.Config        `config:"json"`
+	Processors     processors.PluginConfig `config:"processors"`
+}
+
+var defaultSampleConfig = sampleConfig{
+	Type:           "log",
+	BufferSize:     16 * humanize.KiByte,
+	MaxBytes:       10 * humanize.MiByte,
+	LineTerminator: readfile.AutoLineTerminator,
+}
+
+// volatileKeys are the event keys which depend on the host or on the time of
+// the run. They are ignored when comparing events with the expected ones.
+var volatileKeys = []string{
+	"agent.ephemeral_id",
+	"agent.hostname",
+	"agent.id",
+	"agent.type",
+	"agent.version",
+	"ecs.version",
+	"event.created",
+	"host.name",
+	"log.file.path",
+}
+
+// Fileset returns the fileset with the given name from the registry.
+func (reg *ModuleRegistry) Fileset(module, name string) (*Fileset, error) {
+	filesets, found := reg.registry[module]
+	if !found {
+		return nil, fmt.Errorf("Module %s is not loaded", module)
+	}
+	fs, found := filesets[name]
+	if !found {
+		return nil, fmt.Errorf("Fileset %s/%s is not loaded", module, name)
+	}
+	return fs, nil
+}
+
+// ReadSample reads the events contained in the sample file at path, splitting
+// and decoding the file with the settings of the fileset's input.
+func (fs *Fileset) ReadSample(path string) ([]common.MapStr, error) {
+	inputConfig, err := fs.getInputConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config := defaultSampleConfig
+	if err := inputConfig.Unpack(&config); err != nil {
+		return nil, fmt.Errorf("Error reading the input config of fileset %s: %v", fs, err)
+	}
+
+	procs, err := processors.New(config.Processors)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating the input processors of fileset %s: %v", fs, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := newSampleReader(f, config)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating reader for %s: %v", path, err)
+	}
+
+	var events []common.MapStr
+	var offset int64
+	for {
+		message, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error reading %s: %v", path, err)
+		}
+
+		startingOffset := offset
+		offset += int64(message.Bytes)
+
+		text := string(message.Content)
+		if message.IsEmpty() || !config.shouldExportLine(text) {
+			continue
+		}
+
+		fields := common.MapStr{
+			"log": common.MapStr{
+				"offset": startingOffset,
+				"file": common.MapStr{
+					"path": path,
+				},
+			},
+		}
+		fields.DeepUpdate(message.Fields)
+
+		event := &beat.Event{Timestamp: message.Ts}
+		if jsonFields, ok := fields["json"].(common.MapStr); ok && config.JSON != nil && len(jsonFields) > 0 {
+			ts := readjson.MergeJSONFields(fields, jsonFields, &text, *config.JSON)
+			if !ts.IsZero() {
+				event.Timestamp = ts
+			}
+		} else {
+			fields["message"] = text
+		}
+
+		fields.Put("event.module", fs.mcfg.Module)
+		fields.Put("event.dataset", fs.mcfg.Module+"."+fs.name)
+		fields.Put("fileset.name", fs.name)
+		fields.Put("input.type", config.Type)
+		if config.ServiceType != "" {
+			fields.Put("service.type", config.ServiceType)
+		} else {
+			fields.Put("service.type", fs.mcfg.Module)
+		}
+		event.Fields = fields
+
+		event, err = procs.Run(event)
+		if err != nil {
+			return nil, fmt.Errorf("Error processing event at offset %d: %v", startingOffset, err)
+		}
+		if event == nil {
+			continue
+		}
+
+		doc := event.Fields.Clone()
+		doc["@timestamp"] = common.Time(event.Timestamp)
+		events = append(events, doc)
+	}
+
+	return events, nil
+}
+
+// newSampleReader builds the same reader chain as the log harvester, but
+// reading the sample file only once up to its end.
+func newSampleReader(f *os.File, config sampleConfig) (reader.Reader, error) {
+	encodingFactory, ok := encoding.FindEncoding(config.Encoding)
+	if !ok || encodingFactory == nil {
+		return nil, fmt.Errorf("unknown encoding('%v')", config.Encoding)
+	}
+	enc, err := encodingFactory(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var r reader.Reader
+	r, err = readfile.NewEncodeReader(f, readfile.Config{
+		Codec:      enc,
+		BufferSize: config.BufferSize,
+		Terminator: config.LineTerminator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if config.JSON != nil {
+		r = readjson.NewJSONReader(r, config.JSON)
+	}
+
+	r = readfile.NewStripNewline(r, config.LineTerminator)
+
+	if config.Multiline != nil {
+		r, err = multiline.New(r, "\n", config.MaxBytes, config.Multiline)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return readfile.NewLimitReader(r, config.MaxBytes), nil
+}
+
+// shouldExportLine decides if the line is exported or not based on
+// the include_lines and exclude_lines options.
+func (c *sampleConfig) shouldExportLine(line string) bool {
+	if len(c.IncludeLines) > 0 && !harvester.MatchAny(c.IncludeLines, line) {
+		return false
+	}
+	if len(c.ExcludeLines) > 0 && harvester.MatchAny(c.ExcludeLines, line) {
+		return false
+	}
+	return true
+}
+
+// SimulatePipeline runs the given documents through the ingest pipelines of
+// the fileset using the Elasticsearch simulate API. It returns the resulting
+// documents, in the same order. Documents that failed to be processed contain
+// the error reported by Elasticsearch under the `error` key.
+//
+// The root pipeline is passed inline to the simulate API, so that pipelines
+// installed in the cluster are not modified. Pipelines referenced through the
+// pipeline processor must exist in the cluster though, these are loaded if
+// they are missing.
+func (fs *Fileset) SimulatePipeline(esClient PipelineLoader, docs []common.MapStr) ([]common.MapStr, error) {
+	if len(fs.pipelineIDs) == 0 {
+		return nil, fmt.Errorf("Fileset %s has no ingest pipeline", fs)
+	}
+
+	esVersion := esClient.GetVersion()
+	pipelines, err := fs.GetPipelines(esVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting pipeline for fileset %s: %v", fs, err)
+	}
+
+	minESVersionRequired := common.MustNewVersion("6.5.0")
+	if len(pipelines) > 1 && esVersion.LessThan(minESVersionRequired) {
+		return nil, MultiplePipelineUnsupportedError{fs.mcfg.Module, fs.name, esVersion, *minESVersionRequired}
+	}
+
+	for _, pipeline := range pipelines[1:] {
+		err = loadPipeline(esClient, pipeline.id, pipeline.contents, false)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading pipeline for fileset %s: %v", fs, err)
+		}
+	}
+
+	root := pipelines[0]
+	if err := setECSProcessors(esVersion, root.id, root.contents); err != nil {
+		return nil, fmt.Errorf("failed to adapt pipeline for ECS compatibility: %v", err)
+	}
+
+	request := struct {
+		Pipeline map[string]interface{} `json:"pipeline"`
+		Docs     []common.MapStr        `json:"docs"`
+	}{Pipeline: root.contents}
+	for _, doc := range docs {
+		request.Docs = append(request.Docs, common.MapStr{"_source": doc})
+	}
+
+	status, body, err := esClient.Request("POST", "/_ingest/pipeline/_simulate", "", nil, request)
+	if err != nil {
+		return nil, interpretError(err, body)
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("Simulating pipeline %s failed with status %d: %s", root.id, status, body)
+	}
+
+	var response struct {
+		Docs []struct {
+			Doc *struct {
+				Source common.MapStr `json:"_source"`
+			} `json:"doc"`
+			Error common.MapStr `json:"error"`
+		} `json:"docs"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("Error decoding simulate response: %v", err)
+	}
+
+	results := make([]common.MapStr, 0, len(response.Docs))
+	for _, doc := range response.Docs {
+		switch {
+		case doc.Error != nil:
+			results = append(results, common.MapStr{"error": doc.Error})
+		case doc.Doc != nil:
+			results = append(results, doc.Doc.Source)
+		default:
+			results = append(results, common.MapStr{})
+		}
+	}
+	return results, nil
+}
+
+// NormalizeEvents flattens the events and removes the keys depending on the
+// host or the time of the run, making them comparable with the documents
+// stored in the `-expected.json` files of the filesets.
+func NormalizeEvents(events []common.MapStr) []common.MapStr {
+	normalized := make([]common.MapStr, 0, len(events))
+	for _, event := range events {
+		flat := event.Flatten()
+		for _, key := range volatileKeys {
+			delete(flat, key)
+		}
+		normalized = append(normalized, flat)
+	}
+	return normalized
+}
+
+// DiffEvents compares normalized events against the expected ones, and
+// returns a human readable description of the differences. An empty string
+// is returned when the events match. The `@timestamp` field is only compared
+// when the expected event contains it.
+func DiffEvents(expected, actual []common.MapStr) string {
+	var b strings.Builder
+
+	if len(expected) != len(actual) {
+		fmt.Fprintf(&b, "expected %d events but got %d\n", len(expected), len(actual))
+	}
+
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		var exp, act common.MapStr
+		if i < len(expected) {
+			exp = expected[i].Flatten()
+		}
+		if i < len(actual) {
+			act = actual[i].Flatten()
+		}
+		if _, found := exp["@timestamp"]; !found {
+			delete(act, "@timestamp")
+		}
+
+		keys := map[string]struct{}{}
+		for key := range exp {
+			keys[key] = struct{}{}
+		}
+		for key := range act {
+			keys[key] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		var lines []string
+		for _, key := range sorted {
+			expValue, inExp := exp[key]
+			actValue, inAct := act[key]
+			if inExp && inAct && reflect.DeepEqual(normalizeValue(expValue), normalizeValue(actValue)) {
+				continue
+			}
+			if inExp {
+				lines = append(lines, fmt.Sprintf("-   %s: %s", key, formatValue(expValue)))
+			}
+			if inAct {
+				lines = append(lines, fmt.Sprintf("+   %s: %s", key, formatValue(actValue)))
+			}
+		}
+
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "event %d:\n%s\n", i, strings.Join(lines, "\n"))
+		}
+	}
+
+	return b.String()
+}
+
+// normalizeValue round-trips a value through JSON so that values read from
+// files and values built in memory compare equal.
+func normalizeValue(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// ReadExpectedEvents reads the list of expected events from a JSON file.
+func ReadExpectedEvents(path string) ([]common.MapStr, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []common.MapStr
+	if err := json.NewDecoder(f).Decode(&events); err != nil {
+		return nil, fmt.Errorf("Error decoding expected events from %s: %v", path, err)
+	}
+	return events, nil
+}
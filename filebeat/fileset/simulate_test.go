@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package fileset
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs/elasticsearch"
+	_ "github.com/elastic/beats/libbeat/processors/add_locale"
+)
+
+func TestReadSampleNginx(t *testing.T) {
+	fs := getModuleForTesting(t, "nginx", "access")
+	require.NoError(t, fs.Read("7.0.0"))
+
+	events, err := fs.ReadSample("../module/nginx/access/test/test.log")
+	require.NoError(t, err)
+	require.Len(t, events, 10)
+
+	first := events[0]
+	assert.Equal(t, int64(0), first["log"].(common.MapStr)["offset"])
+	assert.True(t, strings.HasPrefix(first["message"].(string), "10.0.0.2, 10.0.0.1, 127.0.0.1 - -"))
+
+	for key, value := range map[string]interface{}{
+		"event.module":  "nginx",
+		"event.dataset": "nginx.access",
+		"fileset.name":  "access",
+		"input.type":    "log",
+		"service.type":  "nginx",
+	} {
+		v, err := first.GetValue(key)
+		assert.NoError(t, err)
+		assert.Equal(t, value, v, key)
+	}
+
+	// Set by the add_locale processor of the input
+	_, err = first.GetValue("event.timezone")
+	assert.NoError(t, err)
+
+	second, err := events[1].GetValue("log.offset")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(first["message"].(string))+1), second)
+}
+
+func TestSimulatePipeline(t *testing.T) {
+	var loaded []string
+	var simulated map[string]interface{}
+	existing := map[string]bool{}
+	testESServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/":
+			w.Write([]byte(`{"version":{"number":"7.0.0"}}`))
+		case r.URL.Path == "/_ingest/pipeline/_simulate":
+			simulated = nil
+			json.NewDecoder(r.Body).Decode(&simulated)
+			w.Write([]byte(`{"docs":[
+				{"doc":{"_index":"_index","_source":{"message":"ok","event":{"module":"mod"}}}},
+				{"error":{"type":"illegal_argument_exception","reason":"bad"}}
+			]}`))
+		case r.Method == "GET" && existing[r.URL.Path]:
+			w.Write([]byte(`{}`))
+		case r.Method == "PUT":
+			loaded = append(loaded, r.URL.Path)
+			w.Write([]byte(`{"acknowledged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testESServer.Close()
+
+	testESClient, err := elasticsearch.NewClient(elasticsearch.ClientSettings{
+		URL: testESServer.URL,
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, testESClient.Connect())
+
+	testFileset := &Fileset{
+		name:       "fls",
+		mcfg:       &ModuleConfig{Module: "mod"},
+		modulePath: "./test/mod",
+		manifest: &manifest{
+			IngestPipeline: []string{"pipeline-plain.json", "pipeline-json.json"},
+		},
+		vars: map[string]interface{}{
+			"builtin": map[string]interface{}{},
+		},
+		pipelineIDs: []string{"filebeat-7.0.0-mod-fls-pipeline-plain", "filebeat-7.0.0-mod-fls-pipeline-json"},
+	}
+
+	results, err := testFileset.SimulatePipeline(testESClient, []common.MapStr{
+		{"message": "ok"},
+		{"message": "bad"},
+	})
+	require.NoError(t, err)
+
+	// the root pipeline is simulated inline, only the missing pipeline
+	// referenced by it is loaded
+	subPipeline := "/_ingest/pipeline/filebeat-7.0.0-mod-fls-pipeline-json"
+	assert.Equal(t, []string{subPipeline}, loaded)
+	assert.Contains(t, simulated, "pipeline")
+	assert.Len(t, simulated["docs"], 2)
+	require.Len(t, results, 2)
+	assert.Equal(t, "ok", results[0]["message"])
+	assert.Contains(t, results[1], "error")
+
+	// pipelines existing in the cluster are not overwritten
+	loaded = nil
+	existing[subPipeline] = true
+	_, err = testFileset.SimulatePipeline(testESClient, []common.MapStr{{"message": "ok"}})
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
+
+func TestDiffEvents(t *testing.T) {
+	expected := []common.MapStr{
+		{"event.module": "mod", "log.offset": float64(0), "message": "a"},
+		{"event.module": "mod", "message": "b"},
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		actual := NormalizeEvents([]common.MapStr{
+			{
+				"@timestamp": "2019-01-01T00:00:00.000Z",
+				"event":      common.MapStr{"module": "mod", "created": "now"},
+				"log":        common.MapStr{"offset": int64(0), "file": common.MapStr{"path": "/tmp/test.log"}},
+				"message":    "a",
+			},
+			{"event": common.MapStr{"module": "mod"}, "message": "b"},
+		})
+		assert.Equal(t, "", DiffEvents(expected, actual))
+	})
+
+	t.Run("different", func(t *testing.T) {
+		actual := []common.MapStr{
+			{"event.module": "mod", "log.offset": float64(0), "message": "a"},
+			{"event.module": "other", "message": "b", "error.message": "failed"},
+			{"message": "c"},
+		}
+		diff := DiffEvents(expected, actual)
+		assert.Equal(t, strings.Join([]string{
+			"expected 2 events but got 3",
+			"event 1:",
+			"+   error.message: \"failed\"",
+			"-   event.module: \"mod\"",
+			"+   event.module: \"other\"",
+			"event 2:",
+			"+   message: \"c\"",
+			"",
+		}, "\n"), diff)
+	})
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetFieldsYml returns asset data.
 // This is the base64 encoded gzipped contents of fields.yml.
 func AssetFieldsYml() string {
-	return "eJzsfWtzGzmS4Pf+FTh3xMnepaiHZbdbG7N7HtvdrR0/tJa9vbM7GyJYBZJoo4BqAEWafXH//QKZeNWDlGSLHjtWMxFtkaxCJhKJRCKf35Nfn759ffb65/9FnisilSWs5JbYBTdkxgUjJdessGI9ItySFTVkziTT1LKSTNfELhh58eyC1Fr9xgo7+u57MqWGlURJ+H7JtOFKkqPx4fho/N335FwwahhZcsMtWVhbm9ODgzm3i2Y6LlR1wAQ1lhcHrDDEKmKa+ZwZS4oFlXMGX7lhZ5yJ0oy/+26ffGDrU8IK8x0hllvBTt0D3xFSMlNoXluuJHxFfvLvEP/26XeE7BNJK3ZK9v6P5RUzllb13neEECLYkolTUijN4LNmvzdcs/KUWN3gV3Zds1NSUosfW/D2nlPLDtyYZLVgEsjElkxaojSfc+nIN/4O3iPknaM1N/BQGd9jH62mhSPzTKsqjTBygHlBhVgTzWrNDJOWyzkA8iMmcIMLZlSjCxbhn82yF/A3sqCGSBWwFSSSZ4SssaSiYYB0RKZWdSMcGD+sBzbj2lh4v4OWZgXjy4RVzWsmuEx4vfU0x/UiM6UJFQJHMGNcJ/aRVrVb9L3jw6PH+4eP9o8fvjt8cnr46PThyfjJo4f/uZcts6BTJszgAuNqqqnjYvgC/7zE7z+w9UrpcmChnzXGqso9cIA0qSnXJs7hGZVkykjjtoRVhJYlqZilhMuZ0hV1g7jv/ZzIxUI1ooRtWChpKZdEMuOWDtEB9nX/eyoEroEhVDNirHKEoiZgGhF4EQg0KVXxgekJobIkkw9PzMSTo0NJ/x6ta8ELirOcKbU/pdr/xOTy1G34sinczxl9K2YMnbMtBLbsox2g4k9KE6Hmng7ADn4sv/ieGviTe9L/PCKqtrzif0S2c2yy5GzltgSXhMLT7gumI1EcOGN1U9jGkU2ouSErbheqsYTKxPUtHEZE2QXTXnqQAle2ULKglsmM8a1ySFSEkkVTUbmvGS3pVDBimqqiek1UtuHyXVg1wvJaxLkbwj5y43b8gq0TwGrKJSsJl1YRJePT3R3xCxNCkV+VFmW2RJbOt22AnNH5XCrNLulULdkpOTo8Pumv3EturJuPf89ETrd0ThgtFmGW7c36X/cS/9wbkXtMLo/v/Xe+VemcSeQUL9Wfxi/mWjX1KTke4KN3C4ZvxlXyu8jLVkro1C0ySsGZXbnN4+SndefbLPC+XDuaU7cJhXDbbkRKZvEPpYmaGqaXbnmQXZVjs4VyK6U0sfQDM6Ri1DSaVe4BP2x8rLs5DeGyEE3JyJ8ZdWIA5mpIRdeECqOIbqR728PVZgwHGkx0/A9+qn5Is3AycsqSOAbOdvhTLkzgPSSSbqR0+0QhgRxu2fzCfl8tmM6F94LWNXMc6CYLOzVOFQS7I4D03DhTykpl3ZqHyZ6SMwRXOEVAzXDSsG/dRhwl/MaOFYhXRKaM2nG2f5+evwKVxB+c7Qn5Fad1feCmwgs2Jok3cuFbKhZIB1IX9AzCZ8gt3BB3vBK70KqZL8jvDWvc+GZtLKsMEfwDI3+hsw90RN6ykiN/1FoVzBgu52FR/OOmKRZOSL9Uc2OpWRCcB7kAcnuS4UYEJkcSRm0l7Q5WL1jFNBWXPEgdv5/ZR8tkmWRRb1dv3NfdvfQiwCC8dFtkxplG9uHGE/I+n4EEAjFlHkS+DjqNO8l0BdpBUOBooZVxh7+xVLv9NG0smeBy83IC6+FWwhMjExpP6Mns0eHhrEWI7vSjOPusqb+X/Hen3tx83vG4dSyKjA3vreBcnzICbMzLjdMrW9Nz/93FBL3WAvsrlwi9FTSE4lMoDvEImvMlA7WFSv8aPu1/XjBRzxrhNpHb1H6GcWC7UuQnv6EJl8ZSWXg1piOPjAMMQskxiT9OSTpOWU019SqIn74hkrES7x+rBS8WfVBxZxeqcsCcep3N+2zmFN8geWCqKJLCV2pmmSSCzSxhVW3X/aWcKdVaRbdQu1jFd+t6y/IFaecAEGPp2hAqVu6fSFunCppFYE1cVq+N47vuNB8n0sgosyNV07PI4h7ElKVH4Ajjs9bCpxXrMkBr8StaLNyVoE/ifJxAZ3/Z3AGp/91fY9vE7uD0eHw4PtzXxXGmxhSCd/SYZ+mbLYrMU/+mY7iSzUDho7hyXHLLqVUglCiRzK6U/uA0HclAoXK7LuCGCopmc6pLOLjcuaSkGWXP46E15XjT58ppvjOhVu6G5nS6ltr87tm5HxV3RUKzh5v7wj2eYQZSxDAZ1RX3zMVfX5OaFh+YvW8ejAEKatq1VlYVSvRA4Y3WHSstoEHP0nBdZ+5SFDSBQCWrqTQUkBmTC1WxeDY3BnUcy3RF7oVrutL3klav2YzpFiqyM0GDaob/2euguLJTFnUw0EEzAiAKxKEl52GZE4gcf9SmPRMFAG7nNKZxBPGjJuWPS4feb43EBQBdELW7YEQZGCzRVyrbG9IJdVyvfdhj4fYa77w43kGAE60UIKvxmHAXYcMqKi0vQElnH60/UdhH1BVGKMC/i5I9nCtWkSV30+V/sKTYu4kyDcq+4bahfjnOZmStGh1hzKgQgfm4DMeaZXOl1yP3aBCIxnIhCJNOtfV8i6YRJzRLZqxjD0dSR7AZFyLqXLSutao1p5aJ9Q2UOlqWmhmzK30OuB01eM9bHqCXvVHMVFM+b1RjxBq5Gd6JAnvlyGJUxcAkRIS7AFJJzs5HhJJSVW4BlCaUNJJ/JEY5PhkT8tdEWX9EgM0iaQULRjRdBZwC30/G/osJkqx9wkl3AUgHWNmgzQJvoJMxrycOlckY0Zq4W1zNZOlVDNQPlExIwHXCr1hYlenaMnPFkSJUVPXxZtF+rbUOf3Y/4K0iGvb8erhrsxMHeBvoHi9HT05aiOGkdnDY+f2L449bMOdMjQtu15c7UkyfcbsGUL3Zv1LSakZFHx0lLZdM2l3h9DpTkiOwHn6vlbYL8rRimhd0AMlGWr2+5EZdFqrcCekQBDm7eEMciB6Gz55uRGtXq+lRGlzQZ1TSsk8poYpcpd+Ezpypy1rxKJfaRikl59w2JcpqQS186GGw93/JPaHkvVOy/8PD8eOjkycPD0fknqD23ik5eTR+dPjox6Mn5P/t9ZDs0+v2xPR7w/R+kMXZT6jtBfKMiNe98QRWMzLXVDaCam7XuVBdk8IJd1A5MuH5LMjMeLNBDucaT9OCScu0V7xmQilNZFNNmR6BJr/gSa0xcVBET5B6sTbc/REsa0XY1iZD4bWymfcA7IZcEtpYVYEInzMVZtvX/6fKWCX3y6K3NprNuZK73GlvAcK2jbb/b8824bWjreZxGtxp/9awKWsTitdX4BAfaDPn2Xk8oINEhMMi5yw0AijJ3NkbTdpn58sT98XZ+fJxUjw6Z21Fix3Q5tXTZ5uwzoGjSnuDo74F5Bzf/qSD/biNh9L25vqGsZpvwExpu23ejWF6zCrKxY5EmpNoBACEZRhAYNYIMbA5bhWJPUMcGAALcowuKRd0Kvp75qmYMm3JCy6NZV7LauELqvx4Z9bXvgVy5q3tADgaSeDmeFALah0jDNAV8dwhYXP1CIH1kVhQs9jZeYmUcnCIg+M2W6G0Zu6y2jL1z/Ba4h50B41Ucp07DnEvZZLsvWHejDmBWfASrxPwwc1uEt1LhZIzXCsqWjCdAlJQma7RJLiDO6LPQ9iB+HvTkcRNl7WiVAQc+ljt6Mi6WDjBhLoHuH647COSbUkKW7JlW1MNgoymtfDFZssaRoEQZI8ySGYYioC5aKZpdA0npxdekdFiHCQv2I3JRifXjLxiVvMCjc8mN25TSV48O0bTtuOQGbPFghlQvbLRCbfG+xUTko672u7wll+Tm2g0baPgx9WN9A5LzSplo4mVqMYaXrIMUhczxIkS71ELEwqLLtOrXm1se+5x0DQQuA498HA6umG5Sah6gt3EiFLApWZ3knnvXSIQwgKXqZ5Tyf/ATc/L6Ab3u2xNSj6bMZ0bUkA55uD8JRS3575lkkpLmFxyrWTV1qwSbz399SIC5+WI/KzUXDDkf/Lm7c/krERHNZhRexu+r04/fvz4hx9+ePLkyY8//tgmJ56QXLhL/x/JVnLbVH2awSEOjqMKGmiAp2GrpE3UEw6N2WfU2P2jjp7rvQu7Y4ez4FU6ex6kF+AaNmEXUb5/dPzw5NHjH578eEinRclmh8MY7/DIjjjn/r8+1plWDl/23Vi3htGrIAcyj9ZWMtrjccVK3lRt1VmrJS9j4MIuVR2UAAHgOGzOPCiLrsyI0D8azUZkXtSjuJGVJiWfc0uFKhiV/ZNuZVrTwqvjjiblb46fuN3y4xgFvad+OJJbX25xeMUH204N727oxcxlYTw1K/iMh4tjxAJt9t4v5U33apYPkgVgMsMC3AUTdaZAwnmFIa1xaONPQrl2BLK8Yjc4oHai43klOE2el+09zCs636lMyfcGAIv2UkRoRQ2ZNlxYd5wPoGbpfEeYJc7yeNF5G4EsKnQ79Cw6dEt8aFfYAlAfatmCu8PVSHNOFqEoTZBldyVOcHRSUUnnTnsDeRL5oCdJMCo1EyOZay0XJM87X28RJdmj212wqD1nT4OJFe1AB+3ozIExM6/rVf5WlD7e3/o1OgRb/sxreQWTGosB3bfkFYzDgnfwf7ZXMF+UYEH0kfudTfTFXIP5NrjzD975B28HpTv/4PVpducfvPMPfkv+wewQ+9achC3UyY49hTc47L+cu3AjBe58hnc+wzufIbnzGX5rPkNMFO+kim+zJrxilu7nqxPsjT4VHUFe5zZ/VXbCQIr55+VvZen3oJD52F8FkzHEqjGZsMKM/UMTzPYJaCQOBzeeY8qqMRZznmAziF7kNyG/uuv37w3Tawhlx2SvyEZclrxghuzv+2t2RdcBIcj2F3y+sGLIW5bNBt73BQocasKdplxaNtc+wpyWvzlUwzlaLFhFO/QnrSxc09cgj8aH48Occ7RWLdP2i/jF9oTUZFouIHvJB8PjgLCPqFyTD1wmM8Z7zEWoMH8KnwNzNqZeOuIJhr5ZR+aQhgoyqqCGmZSzGaYFa8+tYWKWXLJU4ug3sEntSGcGYsLg4d6AtkPmEWxrpzs0oQ+cngMY5Inum9GIye6Dkw1p2zmPLTvJQi+W10x6xvUdcp2ExIdh74lQQQlEL4vmRYtXIks+hTz6djaSY58gUxxDuSXL8ozBHLjAdaQpbTgI6Zcp3x8ES8iBhiQcXjF3gw0uKfetGyiOkVKn1SybhB8vDEVDKi6BbNMQfeFjKlLuFCr0ZMowRcrr5X5MGuy3VhGaq8QjtGgOJGBNmV0x5iCFTAtZ+sCJ6JxEYD53CZOpC6HcIU+ehpW4mtx4g/JDVkozdw0HG5OAETGzBT7mGemA0DChs8f8sCmnu0X1nFsSyStWKb0mTshB5owfrswInxhu2QjJNLr9eUqa9w8bpwSxElPmbxIBcg370CdHfuDopKA11o7w6ZJtb4HPno0WEJ+mljYgz0rCjMkZ+Clh9ZJ2saCSTPCBkJ80SamYcSHcXp8AQfZpWU5GZOJZfh9YnsFXMy7YfqGZY7QJJvWEAi5xxJipHTjOz4w7OBWYe/qHpFO69mtqjCPmPuZttY8Lj/ouluMFbgYPoUv8eMgt+HzhE9WGZSBISDhAZ71ViWPC6kBeXGdxkCEmo7CmhknjE8aS9YpGNCNeaeSgHdGQQvgr1W5zQ6GEWQOBaFH1UTOnCo3IipFaULAV+CAEQuOQwlfloEXBagvJ0j4uAc+0oDqNSI3lmBrD0FVV0GbYoAYrDU69JBriIiNnXbHGsVJSdx09k+MgvdC24TJKTiZBZaE4Z80o8GzIScek1jVm//VqC3kmQQXSbVXuxHrhDTKpGlTMEcy+SsvqcY1jRok6ULwpFpXpioozSSplbJa1CFZVx0QrlQovGfSxTdmAloxbOnwskuuqaJcfKqgowE/prTuCruNZBXTyJ52vGAUqvD90UvRK6+iAZYFXQ9kVbWw4dVlJeKc2QMCkUpKnjF2SDbG3B5psWDH3McSFWUU+MFaTpkZmhZfyslVtqkKuOmDapqMTmajmFVSM8pVNTsOB23ZJLTXsKlvbJ0my3B7iwXRS+Qsl3VZGI//EPzMh951kN8ySA38cG2YfOH4O5nIsQeGUB2KaaUIfrj+VKhvBDIi61rbL5SRqBm4FG+14TaxDtSkuE9D8wo8skn5CMG5RPbbwcF/EGEttO/CpbPR1nD0D9s3Om1zWjb0MP0oqlWGFSmnoqrH5A9S84kLwwWdqzQpuYN2OBhfzuQfdOk4csTKw7XoTKBHgvAbS4WfmdEbNyAepVjKvupa41A7v+rClAbrEuzuOnsUqxTuHvI49cpPwTqj25HZXZMOgjgvi9+7AW+b+KCfVBXVnF1Yg6gQx7dAk+As1C3K/ZnpBawN1iKA+z4zLOdO15tI+cOup6cqfGVa5BYCj1ao4gZJVShqr3fThvgRWCW7XA1b8EAU69NfTPz97/sWuvGfP3WxiiEymznZwHixR84Ffi4E+WeF24w9XTPNn+JwvIYi6q9qtvArWDfvLWDLwbDrcQhU4fxXMbH1bNMWONg7fTtKYEyfYmNPDqaC6mnydCh4g2TZygNze9XnnTwd0GW+tzIMVifJbVOvJbLTu+ad0LLnVn3i1Nr+3w0aCqraLqb+lK7ALxdqCagZucB256b1XkbbIkg1KrFTunCnZR4Yyv1TFZRaPXHLjOKXE8x4cDKBOMqqLBSsTw04bS3is9qTdQc6WQZedXKKuNelT8oLV5OhHcvjk9Pjx6dEhRhE/e/HT6eH//v7o+OSfLljRuAngJ2IXTuXHO4XG747G/tGjQ/9H2plKV8Q0hVMsZ41ANaSuWRlewH+NLv50dDh2/z8ipbF/Oh4fjY/Hx6a2fzo6ftj2narGFmp3oRpOfHkQmyRYq/Zqshe4S0yBNqa0mU37jG2NnFVUCtVtkq0GH/TSyZPQ1wGdUS4azQZlUhzxWrLp+jIpjnt92YQ4t9ZOc/Ph0mSbctM2nQlFB82wb7n5QGAELNrHlWPOttp2n43nY2I84xKjBKBoHiRTzHvD/OUJHKtwffFXPdTXFkx3Q3Aj7pdS6eoa/LdxEnuvwW7D/2AlDHvFhEbRtOY08lmcxKFby6PDw4ECcBXlEgNwvGdzrRpYswojNKkEK6QvYgSXZWoMn0uTIWTa90c3xIpiZrRhjntkmgZSzfuOqBChRFNHcTVsybJoplsJfrjwY3ZMd3FBA8yOAvDrAqOtkh4YbubpDb8XKkYlSNYl09kNPursjrDgwnFSei9ZiZo6KCGZQQ5u0vQDI2Bq9aA4C8mK0nBjwfyMtAzeus7u2vuhQ1h3VfjsOwFeOK68FXgrZX4vaEkydz9I1p4NFwN3rdlhctpedsymy1dWYLU1pb09k6wNeX1R4g9o7+bwOLc1V6EZLdde7JRsRhthycXaOAUgmTAy6XOGBhPAlArM+Ftxk5tCniaBHIEiSGCUU7BOSiXBS3D23AO/96LRqmYHTytjmS5pde9BtoenU82W6LgIj1+8u/cAPCKS/PLLaVUl5uZUhKf2Dx+dHh7ee9DZy7uqkPiWIbvAEeQ17Qa9bnEuviI9XSrI24w5C6nqOIR/ON10nFconnGvHHtf3U/h89ayflBTv+PXIYbZ/iUFXGaGTJ1UaFtYvevJ/Qre+OAwAfMKyMpUss+B87XDg0JHjVEFT6WBQU0LNf1ahebMyEnrA2+5CXIDHT6woE49UYb5auDoNACQZ0FZJa/Q0ufI+l8/nb3671A53CS/lc/8heJ/4NhGbSeoFv2cDTqbMbSuusc78wlck5Xc98aom7i5r5kis0kGvqSh6D2gWDFLMW4WXCQd8VUyN/0dCa/nMPiGbDhM0xYd9QRg92NVbk+ewipHKF2dIyaECLUijJq1Q9EyYKHpGgkaXx6I3Kj92R6ja3cWcXeuORR0x/g6Jzp/Pnv+YDNhE8/tGpc8s7ePB5e9KI5bTC5WJWt3pghIBBdZLqdI2+CwswRjh1RGD4eKKiwVneqUPeXo5OhxG8fbFQzeogQaTqVKPuNd4aBWcmcJzXg6OAB7YDLR/WzBmtpd2VzPqV0EpbbPo4b/cR06b4qyhqm5MdxKQ9oVuR8NJcpdaGhZBt1t4saC+DdwlU8edNRLqufMXu6QFO8AAhAbNA6zrgSXHzpBzztMwAdygbEUXEojUnINSobHpEORZmci9Z0P5QRp+h6kqU737yw66/5FR9QiI+fhVHOmcgXtZ/9xi372M1N5sF5BtbukpfoqNJmEQ+5JXkqGylxHajf4ydJVWoqeV8pKpnm0sVlWLMA2n1oGOMzOzrPYGXRS6n3T1LXg0Vt5LeXm68nQ++qz877CzLyvLCvvq8/Iu8vG+zqz8b7GTLyvIAuvf1kI51f8YvMJ9i5m+2SxwBXzptYUfA7P+KByaLzABFvSuDm9Vpa5gT+ltMlXldn0pdOZYtCCMq2Q7l/C561molCAp2Um8mX5SaGqurEYPuyrRcWOUs8uMF42tIUaNljmHaGSWQX7P6VCQO3kgRB7DWohqCmDQcN5uLCbK9A1xgf7ERdUlyuq2YgsubYNFaHQkxmR51ARJKu2A0Yo8pdmyrRkFtoDlexGdTR0seCWFZlT61aTpeoQLBcaOWTwevv845PHl4/b5RruqibcVU24OUp3VROuT7M7Pe2uasLuqya483NHmOz94sfOqyPmcSQ2a7UXfK4r75Ymk4DZxOkOldu/mtlGYynYXrHFva1a3a222EM9Jy/g9NREOoaYJt8wBpOQR+Ai9970qL86FZfLOUQo+ID0rUVUUVP2Ic3oEnSUnUB7PqBUlwqfVhEDNCBeDxcx2E0li1/8Ug7D3BV/vt7Km2BM83nvwJUZR2ac+B6Kg2G0hxeSEOn1e0MFmMbjmL6kGFZlwDQ8h4C3zqXsJcgKh7U27iTRpGQFLyFB1umuwEZJsCv3fGfhlRnPaMXFekdH05sLguOT+8HWp1m5oHZESjblVI7ITDM2NeWIrLgs1Sq5/1MVPXiyh3cjdlWfo6fz+voYoOUHn0/IPg+ZvcMqKC0cDV6p3+iSdWfwwan8X2wOCC2iDXcuTVc+XqjvGhqfjA/3j46O931eWBf7HSo0G+gfwpcz6m8i+H90sQ3X5i+FcYDn+d7pRsqMSDNtpG228TrVK97j9cHqCrtD/ro8cnQ4PjoZH7Ww3VWwS2gH2hG/PyntK4OHasW+J633PLTqsLshoKnxJFZYnkAh+WU1yhRgiLzOdN14WR/lLV+zGuS5xyOd1XHEoTN7oNbJXcWhNnfdVRy6qzh0V3Ho6644tLC2ZcX/5d27c/h8kx4l7qUYDjsO9WHIpNFiEgJTGUZTZ101AUktAr6+Ke717fnhhakq1+OBirdXBWRcWfX2ohWf0UaTANQueZ88+WEzij6YZkd7+J2/juBibMXyFyaEIiulRTmM7Q5o+U5ZKjoRLx2K3nfIwmZfMOr0gL5ydXTycJjAFbMLtbNEvxZJEVQnARqZHFMDoFzMlOU5A1YRoVZMQ863E6GhBtWYXDCfKKuKpgpxXnFs40u23DsLYfVOy3vx7OJe3zw2Z3ZEaqgdUzd2kEzQIlrvLGDrrR8+pdTklOutppM95vTgYCrUfOy/HReqOujgbmolDfvi+xzBXnej50h+2Z2+Dc/NWz3g+6X3usf20za7R9pYahszYOq9LuqbU2zaNEVAwxbfk8O2m2y3VzzAa9Od+WicdzoJ9ab8if7Sf7zyQEebE22V+VGQ25ln5lznZIbJ7+IO+SZkOjmsohfEVwrrZS9iB4FW8vOKajkZkQkUTXN/8IFEUaZ1azq7TLgNaWytPC43mZCAS7vFC2DrZ09kOvEMazQJbtH9bkkDJWKi2lpT3aqHeIZ2T01TOcKJHzYobsgVuYUUmuCHAjJuxDxTL6yFHyVPEO3kh/rJjnoTCgnAccwFXbKYe2TcomIschHqKWKIIVoGmCwUNkvQRLIVEVwyA93kltktxd1vBKMSEtfaKH9u/jIxyqcn7+2BHuDO+tw4PA0WMNAWPjuNGdxv4Kh4tfZ7P1rTMVsmlwavs6+uKNoXcm3acR5oT6mqRnr6Y1iwWjIdJEgKKiG4ClnOjo/TMHl3o/DEJ0WFhNE71Tq6WUShUNBN4jJq7Myxw0yTp3h1m/Mlkxihm0P1Eq7WyqpCiXapIqqn3Gqqk+mf+MRWn08GJQkNboqKF1qFPKYRcCAVRgGwNe789LD5sK5ZMqfx4vcRmdGCTZX6MCJ2xa1FrwU3ZJVXJHKiJpWJSkU+yZLJMqumBCHT2E0xhhe7I7aM4cSxYALugoPSKd5n5xhDbUZQVdyMSDbmiuuQNvgVquaUtzvB3XZ/lj1UuVDVsppKA4o4rMhUuX3DNfP121rZ/RNfmQre9En3eVn18H0o9DMik7BZ/U94dvG0Eqap+gR4+PhJiwBegtj15e46YT5FUxaU+oSMMhDaWSH7s3OsNOm5iRqyYkJ4IRfnE7ZfilZoy79xTEWnxCol9ulcKmN54bRHWVLd6rQZh50JtcoX4yWjWmLSOrXxajTndtFM4VLkGARKqx1E4u3zct/pagPlgU8Xb/7RvD755R9f/fzo1V8PnizO9H+c/16c/Oe//XH4p9ZSRNbYgXpz73kYPOhpQVxbTWczXoz/Jt8yNx8sv5SO09O/SfK3SJy/kX8gXE5VI8u/SUL+gajGZp+4tExLKvCT46D0qZHAuH+Tf5O/LpjMx6xoXWcFin3/WHd47WNLvSolh/o6taN4IGWKTT5mlFxumD1DIF7JTX7J2WqMOGwAHEijNKmZ5hWzTCMiLaSvh1NCpIWB+xdcGR5YPnIEOr7XZSdP+xbfzJReUV2y8vJzgg+ylhwxT91v1+wnryDXWn0cqFX14/H4aHw0bhdP4VTSSwxf2pGAOXv6+ik5D9LhNYAi98POXa1WY4fDWOn5AR7MUNv2IMiTfUSu/8X448JWIkuiv/ByBM6rUMckvGW8/KECalqABAON5zWzPwm1wvJq8Je32MZxhZqHW1/jTbZDc+oRvJ1yuGu3CCpH0zVR4OWEYuMqnL4mhbCFc6mL7c9gtfuVz3gL7c/rkuIPXD/IJx25/t2BQzf9MnDshh+TfuYP4OGD97htpAhcs4ur7Msfwu0inZkQU0HYxzGcaCMigKN+o4XTJB3R3NmbNNyvT3OL/pHoHg9Y74KEF47hqYm8nAkx1NrBlUpTIQhG/oJw8m0YmwckCgu6dsKpKesRsUU9IrxePt7nRVWPCLPF+MHXR3lbdAi/o7iEMzx03lycQRq2wEN0lccPBLZ+6ag4drQ7QQpmt6TasGJEal4BQb8+cjqkM9OAr1TTahnxJv9uW/6HjK/3a4XUrOBUBA4exeRYjIPrXamxuEQsvFsyywo7CuPDS1hd5OoR99vnm1eusmKv7YzXGCFCSdEYq6qY9oGDQgty8Hb7qXZqnig54/MmtSKxiuhGXp8AxKiZdeCyWmjtNJQZ12xFhTAjp+HqBkJ6kEJcyYNawxRhqBCUGHTITEs0TBqlY4WrFZu2sMiAQBC4UMaQoaEdIZ+ev/LUMHmb1cANuQGHYjXoDfYbL6BwcAwjketRXikO52kiK5hQ6wXZwSSFeQuJQ4UVP6avs0Jeedvq7w1rcGDy4t1LSFxSErgm3PV8qeh2GxPPTsHSpBmYBqGgVcmgP4CnB3SEffHs4gZGp7tkm7tkm5ujdJdsc32a3SXb3CXbfNPJNt1cm3j6tu0fn2aU6bdIHR7+i7U5bSmqd1kPd1kPd1kPd1kPt5/1YJjmVOzWYBzu1x6YP++vKqJ1e83BQreBXKzGpi7bCtsz7ZMd3cUwaE7BEJ1GWtfMjIeiboKrQOdtB8LFE6JwSgP/1Ma3CPu4hj+UEAzCdPAS6/5KV9CB2IgwZoukLe/zbRI1zhwh5DHr4w4G23ur3gJLZYIlhS3NqeR/JGU/mHm6318RB5KPE+73TGpeLJBx4GK/qXdZVVMZTmmlvb7aYrpOpEYeGJJ6ky6YqKEsN9Waynlo12N95dus5w+VGKQDHoN21H5EI83nJnU6/g55KjmqX6xeTM4fUT1IUr3FSlEEX4AIvoKd3oGdtdMuYAPrqI50v3704TepGX7jauE3rBN+QwrhN6wNfvWqYOYhjc08vJQ7z766djPtjcItdv0dPukKKtNpl3LwvM253fsOAhtjE2FeHmS87INKWnG1IIBDB9ZxDbl4M8skMZauTah/HLr7YjduGvtngYJYc3TUQKaiUFMqskr0Ad1kULpe/av5dTIQPi0GTGu69uESQCSq5+BIy+1kr6DPpNcncHq1VpYVFpwn3PJlKwmyp3f6j/vExBTNfbIv4p+NiXeKfRLa/7SjKNhHVjTQBWFHpHg6he4wDMN1/QoGqiTovR1y0Bh9MOXyIMztS9St9DvOn0JxodzVAtpMkIIKwSBlfK5pFRMgDa+4oAOdgLvI11dmid4oa+Q8bsH+4XN80g5MqnuwPz9r5ZxCoRi/nHtuekOIdK68n9lI5V3osppzkm+Y0ncFHB8ePd4/fLR//PDd4ZPTw0enD0/GTx49/M9Op42FZrS8Xkr4jSj0DgYmZ8+vXiCQ+rvmbADSiXdxNITvR5jlgKwOflIfF1Ln+4I8oxLDuKepz6Y9jUNmpQ4IJVOtVgZsDyE5xCMRZMGKTUlN5yzrpKqwm317iVZKf+ByfonxTb3m2bea5uZhkQgrmC/iEdqVVgtVsQMqsGFFShxLgQH+TH+bfbX1TE+tdRj2QQ/VSme04IJbdzjXfKmwHbFWDfTSrzkrsg5W0J0lLDYYSOAB022r4sPhDWPQhL2icu2UsAJCA9zV9sWzi9DV6V2Ogh8am+WBDQdvkNUIr8aQWRDOQmha5UCEMlXKO6bg/Da1kmXaRT79RZKJp+J4EmfyFBr/amajwcdRKLkQmBll+UNTRhoocgRt9qP1ZOTjPUeJCUIk3IgUgkNbsPAolWUMjsoDUKEICNgH6hp6ygpBzs6DWmFVwp7XkxHqVhTUHemJ5isbYLTh2Tmxmi85FWI9IlKRiloLCS4sHhPcAjCqWTki03UM2slBndLxdFyMy8lNzAzXacEx7Lx5KmI+3Nm5wTVWMmtEnd/k+/E/F9eL/vHPDeQFeebxtSFiMEqhpPSRSrNoiPPhFJrNqS4xTsUYbC+enjfYJp3HWEqnbmIoa6F01qj4J6XJu2fnsS8QCM2IJuJWMO4+ewJxyaHQxMVfX/swzvsmFOwPevmz8wyXMQDBejEx+LYLydfAFesePcLytWPgpQn9EEEq+GAbQgvbBKctRvIxXZF7cbx7WC55FtXKHAvZQdyECmPws79mBN9yP6MqiBJfLLZAwWY6IPJ5eIF00QJAoZcVzMKPmEKBsNjHb40s0j0Gd7p/e2iwRNpUCCQN6XYvLuM+OuxDzqp/8hkOfxCm0O6rgtcuWjopX1FpeRGC631WFvuIrZG8PEs3IndVmzXCPbbkbrr8D5aZNyUpmIaLYEqMCrJKRxgzKkSQVaGjf0Etmyu9RmHlE+KM5UIQJqGhHjy2IbXFEWzGnY7sh6V1rVWtObVMrG9yOUNJvit1CJ0F2GoPFyYeHZhUGQRMNeXzRjVGrJGb4Z2o6qwcWUy8HYBrgjoxPiI0FOPDwjVQwk85PhkT8tdEWV/EMa9PgrtK01VKQ0C+n4z9Fz5Htq3GSXcypATGssFwNLxXTtz5AwVwxojWZERK5o4sSFkNxa1Ts0A4Z3i3ueRt54/9GRLHoPR6Sr3zXh3fWxr2T99+8qQdX46TugKzTyp0g9jg+J22VXchc3chc3chc3chc3chc990yNwnRqzt9UPWQsBa4iy8fnb8weTsfHnivjg7Xz5OikfnrP1ikW5DYXafl6V27tPTPuVg7xgtr054upnBUkHZkI3zvquneVdP866eJrmrp/mt1dP0hU3gucysFr66ItQqlEXpGmls/pvSAy2OnILkkVtRQwolBPSgviKcasZl6UtMBe6ErHBky1gHLMB2T4aIhevbEFi9YBXTVOyw2MeLACMXT8prhQH9+3wGOgC0JTcPupWeeJl1qQBzjyG00MoYohk4tnztnIkfEHZfqaDnk+3rg0/oyezR4eGsreXsYjvt9UVzKLjXSInWVcS4P2VvqsAdKGIT03WLdL7IQEU/MEO4JbUyhk/ReRRZJw4NLJQlXiLPStZjqKHOF8GQr9061UxzJgtwWBnTMIPGQjeWZqWbgG8xlmz66MaP44Zm9bzEsgEplALuYYHZ0ZjG5RyaL/u2Zb0VLR/+wB6x6YwdUva4OPnxh+Nyyn6cHR79cEKPHj/8YTp9cnzyw+yqAgm339MicHiK5PX7fyCYN79axRchvNfzPpxG4AiJtSWEWhm4ZK1UJE+6Y4WxoMdFEBU6MV9QDNzvsZY7XgNly3nJW/UpfJOMuNvgeMt7sQgstebRc8tYcqdzThs381DvCtdWN+ALiSfOQhlrhtkXTffBVO0nS7AkjJ9KJzDB55BDAreakReCGssL71jKyAxT8JnH4ZhGJbwxlunWVQmdGn9m1Jr+ENw46pRsRhthoSJRHX2jkV4W2kaDRI5j8hmRioQxYkOSgSKI+Rz285TXLH7A7sRC49vewPgdPv37BMvfaHfBi8Hf6dPaUT8eOGdbQtKd6CAlM4UhzGSDpIRBUkoy7Lo2dm1mHHW4Iw4a6x1MWgs/VB0z/721HLsLc9/79xCe2l6Q6Ghp6Tz9VUkyDGotqA+Eul2DoePMYsf1js6zTCBpZL9+YbPx8Tivq4D+mJb6l77Zov3hU1d754LDB7BC68BBu+5pe6TMDXeFAy53H3kv3FfpJvIOrzs30VfiJsL18NakvIxRz6T0xXxFiNKdr+jOV3Q7KN35iq5Psztf0Z2v6JvyFWE1vm/NV+SxJrv2FV3/dP+CDqOByd85jO4cRncOI3LnMPrWHEaNRonlrQXv376Ej5tNBe/fvgyXe98xk5imhiqfmIPnAFlAp6Ya1vL925e+gJ9/MgbGLxiZakYxyUKtJOHSKmKKBXPCBW9QI0gZ8+8rEmT/dcwCQ1e829s0z/2N3ZNbi1FsIHBvtVqNvaVqXKh7bVstZNcUFKwHQM+KrjGc2of7OjUBqw0CXTH8XKxT6i5tT434jBywA0OPBsNGPg4/1bcGlXWuYqcVf7X31oGeitieQouuM03n1e46TO250zYztzVaEDqzvlrI5PtJRmir6nsdC+jk+0nol+Lbw6AW7pHuyIwdZr6fzfCodPwPdiJeufX0CTwQgt0YllZrnRlksKJEnBeX0M4QTvjJiKwWDBIBbKtDjGaFksbqBqyQjnswxjxYhNrWqFyNGeiK1l7+05OThwdoc/2X3//UssF+b1W7Uu5wv6LbPKyw/w7M0bcsAhYxMXMpzravX79W1seuczlQr3SUl6cp4+6EOq1hMUeYiENNvjy0gNQ4oeb+1ude5cZnOP/WGJuC/kO1WifYNvb7iZle8bU4LAUn6IqaiOioJXgH3cGftLButA0/d5R/Y7KVvO01P/fDDzbrTDjYXSlI59BjqAU7k0GeQPfGV1xBbiHRNruG9PA4OXnYzy49edhCCrLEdrUxnfAFAJ6Jo4UD8MVfcG6Dc4j7wNG0w2w9Gf8vIOPZRyhYnLWbyKFApguesLH3l1TuXdihmQkdq0tluMOrNlSeogBv2tj41CgDhpPFoI44Yuz6VNU24QOo45MT/3bHVdfyRZMpsyvG0jEPuVgrhcpD5yBDrWlXa3sBo2/eAyBd7nXkLGbRTk4Hz2PEd4Oc6inQO77V5jEJmXDJMWipyebqRMV3XgfvOdWGCw7Bo3guQXNjtqTxsPYaW9vR9lNWsIMu0WLEwF6cX1TcN5wZvxXCBQ8b/dgFlfAaL0P2a1DpY76uPylhm4EX01OpukkA1t/RLvINmUS+AWvI39sQcmcDudIG8tWZP75ay4dh+pLOw5Uok+wkfXsN+Y5jBCmfIjjdJd9XQQrFL+LJ4pF75+58vgTSQq18u9QVm8YIEwiwyepiYvUJqp220ERUg35xfZGMfS++1E720LpLws8XIYTgS3VzyjgESddD6oLOqOZf8kL7XvoFXbajjBJzDXjz/+BC0INH40NyH8n4T+TZ+XtPUvLmghwdXx5hQ81Qy+0BeVrXgv3Kpn/h9uDx4aPx0fjoURQn9//yy7tXL0f4zs+s+KAeEB/3dHB0PD4kr9SUC3Zw9OjF0ckTT6eDx4fdUrZ3xbEHsb4rjn1XHPvzMP4fWxx7t6j+e1/qbjganBT8bt8BOSVTBq2CqCwWSuPH/UJVFaDpdYk/4zMtaP8Mgz4L5gh8BV6PIZPh8gDKpfClRHx56+82xD8Cvp2mD0Mk2drJwc+6NbLDbGx5xf5I0X44MBU8WkBrahen/n7aebjic00RntUNa4+Oc2kNq6a/sSK274YPl1fO5J/jKRYpC+sYumQBOX1UaRsD6MTfQiApThuBvHAvdUptQpmasuS+TJDT3SHO1cfkA5xYMCxfQzIcUb5pBbeglVDLQrZbC9njjv4iOibKn9u6fjDoINv1Bx7k0a2jQ5gsA/NFyIO4Lmu/45gLwlnK0XFXI797C6GaMm3UZ+5jsH1ANDv1CW0DlH7lf0V9vGi9ahwLsDKkjtCyvIQHLsOQoXKc0vlWbs0aXhjXWjnWT+aAKIX8L/sft/Noru76Vxw//qzUXDCcMXLjAHBe0TkbAE0rvk+nRXl0/HBQlCboZ24EcvY82hiQTjG1Caf8PXnq2ATzs0SZi4MY0sQsHUeSAJGv4LPBh7fyWQYjIJhSBbeDiROKz98Y0jW2TgfWdfdPBs2nPV1mAmY7MP/COHvhurD8AcYFt+vLaxwb29+6LlTP49dduN7+ui4cjEO8FozWo4PjB3lUquID8KoXSM/D54Hthb9BelI36cT/5va1WShtL/H8OyUzKgzL1BWEtx+F0Qa1IqJFBk/HTaeYPxHzWJxhYmUEG35lkGgbQDmJc3NoIOlk3rz2RlA7b14P6KeDE3TKhHGC892b52+cBrciVpGK1k7IGvYvPVxa6hTZrlKR7aoFynREYRw4153niW9/wU8Dg5w5fSjjVn8suNdDTuY4Y1Dogj/Env7cePHsIk8x4jFniBVmvK7E2D+HaedU+0BtJffTmx3TMqK+ndM3L03L/huGmColGJXXJO8sUQR8jmnZ+3CVGU8bLvog+ysaT+97R0+eHx3+eO966Ly5IACh3VZmCJFClWxwH2zDxVjNbLG4PjIBSujWGjnwQzNlWjLMGfJ8+Jf8u4Fx0+9R2WtrbmlQknPhdqmaXrpSsraQ3s5zXYrXqhwWOzfazBkFauXbdw+CagZk+KdCOlcleX/2vA8IchtqWtzepNKIfWCq7In8zwQWjHV9YCgurxbL1wPk5X9F6z4kcA5hic/bApcNOQxTM0gbNMzeLkHTuBvIWrJaqDWE890q4DTuBsCQFT5rxK1PORt4A+grtI5PBRyHvRLssIr1+XBxXC/OUwOUXvuTgXFDPfsoxeMVckjq5s1VbiJy2cfrKnmhMHyvnwYZUPT8jH9TQn3gdJ82VpXcFGqZXwX+FX8lz/0va5I/R7J77pW2ioGh8jPP4xGH3GRs9M+N0aDTNs7ewFIX7K6YF0fULCKQWV+HYfJtVt9NNjtaLLy3dAFG6OjDbteIZzyU2HZEKEnZYBt7S7Vt6papFNROpStMLYy2RvDX11TTilkGxZKmDKyDbt2grTzD+DL8wn3EcDJeAmqGLaGSUE21NRhCdXY+InmbC16OIEYBvEQtlKgssbcCWACHSOjr3dValU1hb07Idz6PF/euH8YpZXFu28B+Mru0wO6Z6FC4n0F+cAXorBHjDSH7FotZGjNOP+MFE+vNdLO+Ax4h1+LG0N+/fUkW7qoHlSQAnOdWwGQb0YtGd3wk7UvJBqi/xgDzMD8scYEs7i9wtLELJi3HJNMQeBzEmlDzJMVeqjmZcYEIY3DFNreICI8LLttej9Y0hZqP3WPjLPR3iLSa/d5wzcqksl9BcIDdKXHmUAEqQIuYPFg7BpcCtYYaHQGSEcJPqTPKKZkcLKk+EGp+4BsNCjWfjPvz9IHs7UoXnz3Zi1Y5i96U1dx7n8K8oYK9z9AcQFLNZoa1ZUoW3/xpy4Bj+sjMWmkLzV8lQ5FsCO26rNzVkla3RSHHuTgiWS2YBCq4TZ5kAIb8+w25Z2ypGrvndoP7m2m910aPy7qxuV01oQNawZVUgQGwzk9nvdJaYbcCOGjaWQVASmRKaL+SajBFGME2M8ECTgrT531WBQI3vp+Gl4c/ccHAhYkCwrN7a9boGzTs94Z13TifwyFhwEyLACGZh8pGvlgbEBjYtWZ9e1zqByTso9U0GWPxwOZKc7seRiX8emuohAFjjDXA2UYNUDa4XV/C1fI2ZeiiqShuF/C+BkDbF2XnaARAHTRiU0Ls6HabCMi2I84NPyA5Z4LOB4ve5IMNnzjwaoAwtNQLa+sxNm8xbOxP4EvB5Lxzag54g1uvTlW5HuflebY6TToBlldfuJJNMc65/8rmW1o31Lu/gi30nJDq3OJvqJZFseeHwmD5KHo3S6IAulJlI64XVdF6dCvZHatfguvc0qq+1uCFZlnlw62jo0toTK3Vtxq3kY+b2Nvbu9ylhskl10qCaWZJNXe72ZCV5tYyCdUIYYQ9Q/714s1rWBu3seaQWqz5MoUEd4rRUs2wAFsKvIGDHhIysaBd1Hr9Idge15+QZvzd/w8AAP//xBd2Mg=="
+	return "eJzsfWtzHDeS4Hf/ChwdcS3vNosPUQ/zYmKPI8k2YySZI1LrnVlvqNFVqG6YVUAZQLHVvrj/fpGJBArV1XyIw9ZIex0TMRarqxKJRCIzkS98y345eff29O2P/4O91Expx0QhHXNzaVkpK8EKaUTuquWYSccW3LKZUMJwJwo2XTI3F+zVi3PWGP2byN34m2/ZlFtRMK3w+ZUwVmrFDrL97CD75lt2VgluBbuSVjo2d66xx3t7M+nm7TTLdb0nKm6dzPdEbpnTzLazmbCO5XOuZgIfAdhSiqqw2Tff7LJLsTxmIrffMOakq8QxjPsNY4WwuZGNk1rhI/YDfcPo6+NvGNtlitfimI3+t5O1sI7XzegbxhirxJWojlmujcC/jfi9lUYUx8yZ1j9yy0Ycs4I7/2dvvNFL7sQewGSLuVBIJnEllGPayJlUQL7sG/yOsQugtbT4UhG/Ex+d4TmQuTS67iCMmVs2MudVtWRGNEZYoZxUMxyIIHbDrV0wq1uTizj+aZng539jc26Z0gHbikXyjD1rXPGqFUzaBJlGN20FEyOwNFgpjXX4fTIKoGVELuRVh1UjG1FJ1eH1jmju14uV2jBeVR6Czfw6iY+8bmDRR4f7B09395/sHj6+2H9+vP/k+PFR9vzJ47+PkmWu+FRUdu0C+9XUU+BifMH/84N/fimWC22KNQv9orVO18CFe54mDZfGxjm84IpNBWthSzjNeFGwWjjOpCq1qTkAAZ6mObHzuW6rArdhrpXjUjElLCydRwfZF+CeVBXD8SzjRjDrNBCK24BpROBVINCk0PmlMBPGVcEml8/thMixQkn6jjdNJXNE8JiVWu9OuaGfhLo6hg1ftDn8nNC3FtbymbiBwE58dGuo+IM2rNIzogMyCsGixSdq+E0Cb9LPY6YbJ2v5R2Q7YJMrKRawJaRiHOHCA2EiUWA460ybuxbIVumZZQvp5rp1jKuO63s4jJl2c2FIerDcr2yuVc6dUAnjOw28WjPO5m3N1a4RvODTSjDb1jU3S6aTDRdxOi1Z3VZONlWcu2Xio7QOtpxYdgPWU6lEwaRymmkV317dET+JqtLsF22qIlkix2c3bYCU0eVMaSM+8Km+EsfsYP/waLhyr6V1MB/6zkZOd3zGBM/nYZY91Eb/udPxz86Y7Qh1dbjzX+lW5TOhPKeQVD+JD2ZGt80xO1zDRxdz4b+Mq0S7iGQrZ3wKiwx/Wl26BWwekJ8O9FtJS8HVEmjOHct1VYnc2TErhPP/0IbpqRXmStjArhrYbK5hpbRhjl8Ky2rBbWtEDfuawMbXVjenZVLlVVsI9mfBQQzgXC2r+ZLxympmWgUKlcY1NkOFhhPN/oWmSiDtHGTkVHTiGDkb8OeysoH38FuAq2CfgBCaC8QtmV/Y74u5MKnwnvOmEcCBMNm5SKeKBgIQQBE3llo7pR2seZjsMTv1w+VgCOjSTxq2DGxVO+7wy4AVGBkiU8GJjfz+PTl7gyaJtGsmRCvOm2YPpiJzkbGON1LhW2gR1gelLtoZTJag2DmMDeqVubnR7WzOfm9FCwSzS+tEbVklLwX7Cy8v+Zi9E4W0yAGN0bmwVqoZQQ6v2zafM27Zaz2zjts5vHxy9oadAzsZIpnfiMjk+HdnrXS7QzRzUQvDqw8ySB3az+KjE6roZNFgV1+7r1f30qswBpMFbJFSCuPZR1oi5CNZogRCMWW/i3wdbBrQZKZG6yAYcDw32oLyt44b2E/T1rEJgstkMcH1AP1HxEiExnN+VD7Z3y97hFidfhRn/9DU3yv5eyvuM29i8mNkUc/YSK8F6vWpYMjGsrh2ekVvevD/m5ggWS0AvicRBitoGUfdTuLQq6CZvAKbVoOu9Cvn3yYNNRdVU7YVbCLY1DTDCNgtNPuBNjSTyjqucjJjVuSRhYFRKAGTkDplnToVDTecTBCavmVKiAJkk2KLucznw6Hizs51DYOBeZ3M+7QEwzdIHpyqF0nhkS6dUKwSpWOibtxyuJSl1r1VBE7cxCpeLJsblo+e4QDMOr60jFcL+E+kLZiCdh5YE+carHGEh9o8CF0GcjvI7EjV7l3P4jTEVHSvoAqTZW/hI8wBA/QWv+b5HI4EQxKncAKd6bC5AVL/Ox1j+8Rewelptp/t75r8MDFj8kqu2DEvKnkHQ+aEvgSGK0SJBh+o1rlgUkknuQM9XcLuFG6hzSXLtVICDXJQpQE3UNggbWfcFMDsFvSSVnacvO+V1lT6k77UilesrPSCGZGDTRe5CmTaxYszgup3RYfmADd4AK8nmKEUsUJFcwXeOf/bW9bw/FK4R/a7DCWnt7Qbo53OdTUYyp9oQa30BiWY2uBxXcChKFgCgUrOcGU5zjJj57oWUZW31ts4Tpia7dARwGmzEzDVzIhSmB4qamWC1psZ9DPZoJ6TpiLaYGiDBrDzgAIDtNSMcbsyRIo/kj5jL3oDwM5pbQt6lqB2xp9UgN5vrUL8vC0IJlE8yKwD1tFXaTcACULdr9cuGh3ED5FNCN5eGCd6KVBWezUBB2Eraq6czAFBOBcCibli4qO3FcZegBNQaaNecRrcRy2v5B8iOE3gRM1yYdDYt9K1nJbjtGRL3Zo4Rskr8gAw+ITUmhMzbZZjeDUIROskOBuUbdH45dE1AkKzENYBewBJgWClrKpoc/GmMboxkjtRLT/BqONFYYS1ffH1cPYccjsuVeAtGpBkbxQz9VTOWt3aaum5Gb8hkIwtgCxW1wJcOmABWzwzn56NGWeFrmEBwFPDWiU/MgtOB5cx9reOsqQirOskM8N1NHwRcAp8P8nowcTzZ2QyMDGFggMAQYX91Xqfhfe1TDLZTECyTTKP1gROcY1QBZkYyF5gv0aQeJzIRr1VmS6dWFmTgUqpdDT1/cmi/1lvHf4M8PypIjr2aD3g2AziALfNQL0cPD/qIeYndQtm9+EU2r8eftYbcyZ0lku3/LAhw/SFdEuk+2D2b7RyRvBqiI4G96dQblM4vU2M5DjYAL+32rg5O6mFkTlfg2SrnFl+kFZ/yHWxCTRf+CHY6fnPDIYYYPji5Fq0NrWahNLaBX3BFS+GlKp0npr016EzE/pDo6Vy68Z9rdVMOvCngKyuuMM/BhiM/g/bqbTaOWa7zx5nTw+Onj/eH7OdirudY3b0JHuy/+T7g+fs//blASA5pNfDien3VpjdIIuTn7y1F8gzZmR7I4Hgt5nhqq24kS4YASz4DY3wbq9EeL4IMjOebDyHS+OPR7lQThgyvMpKa8NUW0+FATeZPwoHsyZIOUboVayZLy0EBaJnLQ/burMlGXurXRI9gJMGCH3eOl2jCJ8JHWabjVbXbqqt02q3yAdrY8RMarXJnfYOR7hpo+3+9cV1eG1oqxFOa3faX1sxFX1CyeYWHGSzbpTR6VlU0EEiorJIOcs7AcA9ok3n0j49uzoCZXx6dvU0wBAhihPQqnl+C173oc2bkxfXYZ0O7k1aewsCiarvDXLmv76XYj/s46GNuzsSwd6wzshrMNPG3TTv1gqTiZrLqj/qg4k0kGgMBwjLsAaBsq2qDxuUq4DEyDIYBueNcoxfcVmBL2mwJifVVBjHXoF7Qkg1xBdN+Wxj3tehB7IkbzsOHJ0keHLcayrugBHW0BVf36TCSs0jP9gQiTm38w0NPyJKwWQhaj0H0z/Xxgg4rPZc/UBBjgiholFaLdPAIQO/SeoJfG8FuTEn8BG6p+E4gX8ARScxvJRrVXovOa96Y4IBknPVHaNZCAeviD4aoU+lwca/D4V+XpHE7SprRamIOAyxGjLPg+B1PgfBBMABvUrPpBoikmxJjluy51vTbdF3rYUH13vWfBYI8+xRBMmcV7rFeJZUpeExNNwFvfwR2XuMCTEQ8tkNQa6SvRHOyBzcneAfS5zbHJJjDn28DTikFC6fC4umVwKdSWcprtghCRwd+M4O45oSwobeadpHgeCaVlHA0ohau+hiZbp1VhYiIccqZh4nziiiFiZEgOnAjp+S2diP3OMvCSA37wYP2lHmkFTSoUoE+xQnSp7DqWNzknl00RHIjwV8o82MK/kHHl0g7hXC4LTLlqyQZSlM6kiBH5zE4C/j3lDadUJx5ZhQV9JoVfctq463Tn45j4PLYsx+1HpWCc//7Od3P7LTAn263o062PDZaHVvPX369NmzZ8+fP//+++/75PQaUlZw6P+j85U8NFVPknEYjANU8Q4aPGzALkg20UA4tHZXcOt2D1bsXIoubI4dTmkEdvoySC/ElTh7gKjcPTh8fPTk6bPn3+/zaV6Icn89xhtU2RHnNP43xDqgFB4Ow1gPhtGbIAeWzQ0IJWR0h1ktCtnWPUwbo69kIcyGsOx5gnCvhQGzEPhNk7L4wo4Z/6M1YsxmeTMmkAx2ZiFn0vFK54KrweT4wvam5Y+0G5oUnRzvud1SdewFvTA9ldx7eEPAK77YD2pQuGGQM5ek8TQil6UMB8eIhffZU1yKXPe6TIFE0XoxF5bUlY8yJAYk6iuf0hpBW9KEagk6Cvzgn6CgZLEBW4qM4G7ysujvYVnz2UZlSro3cLDoL/UIQWLQtJWVA3W+BjXHZxvCrOMswovP+ggkWaE3j55kh96QH7oy/CkOSqmWvXE3uBrdnDuPUBiWWHZDI7/z0FnNFZ+B9YbqO/LBQJIUECAyiRhJQmupIHm58vgGUZK8enMIFlk0DeWhi9X7gfb62ZlrYCZR19virV76ULz1SwwIpkS4W1SQIFKKwYNFBSNYjA7+/x0VTBfF6V7m/j8rNJhug218cBsf3MYHt/HBbXxwGx+8Pj6YKLGvLUjYQ33TkcJPUPafL1x4LQW2McNtzHAbM9zGDL+6mKEvFI8RQ18qfpM34Y1wfDddneBvpFL07M6n+duqE9aUmP8jpBql5ffohPEndWA7bWoopc/YROQ2o5cm4PDlEQ2CSXNBpqxb63zNE1piXS12x/+/wPH791aYJfh+qNgrspFUhYRSj91dOmZDhSMhBPS0lZzNXbUuWpbMBr+nBgWAWgXaVConZgaXyDJe/AaoBj2az0XNw9cRIvENTWFgQULHgv2Uc4zRpsc78cENvqieaxkS32MyPBPwPe4jrpbsUqrOjfHe1yLUKH7oPXRn+9JLIF4lfGwWyEwRagxfY4WO7Wo2w7TgFYgni6oMEsiChwahZ6M7c/GGbOZXgAaeS+n5FCYGAsYj2MNhIyLveu25BgMqub4FjVjsvnayoWw75bGYaR94LD64mcdofdeFTkLhw/roSaWDEYgYQbJAj1ciS55Ace5KNRJXnUwBhoIlCw5WXXp34BweJrzb1ZO97ur9UbCEGmhAC7yIcIINISl4CoAijBBvw4G6SRC8AIqHUlyofzMuZF9QTkVXO+UNejYV8Ea0ywkmJ0McBBRPTWIMsa8twJoKtxACRqIEQJCenPL/CKwfjGqXoGDRQEILKHl2ElbidnL7ExSBrMFlqlqfg14hRF/ZgofttCIdxfl6QievEdiuprtH9ZRbOpLXotZmyUDIYeUMgSsSwhNYbdhVW0GhEYb9pbArL1tInBIFfvQJEoqHrhQPLSFGF1D6h9BZzhvXmq55ST9aAMUpqQcEBHFvA5Ifm6q5TjFOiavXWRdzrtjEvxDqkybZIBcE9/oEhcMuL4rJmE2I5XeR5QU+gvr53dwIiFBMfFFPaOASIcZK7cBxNDMJCw6ZKOvyRsDW2224tSBud33dVm8xAuqbWI5XQJxYvLVKfNokls3lbE6FautlILyJm0KXg1WJMHF1sC5uZXE8u03GITZhhbJUMNZ5r3hEM+LVQQ7WkYdkM/YLN5D4BMklrGyBzzrTR5fQ+2HMFoI1FUdfASUhMB5BVtSVg+e5aByfdnkJoBE602nMGt+OCYofMVSV83a9Qw1XGoN6nWiIi+w565Y1jp2SVteRmNwDGaS2rW+jBDIJOwsRRDCfOfJsqElH6TxdQkmfGfYWIiZBhQmbr5Dg6cjJIdN1g4o1gsmjblkJ1wgzStQ1zZtiU5lVUXGqWA2pLl3VInpVgYkWumu8BB1qfGePoZXst3T4Mw9kZqEiP2Sj5bzKMU5J3p2KL6OuQjqRpqOOUaBggtLpsld6qmMxD5+GtivQ7YlEEHhsV3oDBExqrWRXscsSEKORZbpbMfgz5IU5zS6FaFjb+DpW/ChtW9WnKljCONEVOoLI9AfvnFfjdGW7oOGa0zb4va1wt3D5vSRZ6g+hYZKpwNrmWsFWhpc4m9A7E/YIJLsVju2RyWCF+w74ObjLfQsKsNWYbacd+gwg1bpoK2FR1PW2XSonvWUAMfzWAK9Vy9BtSqpu0PTA71mk+8kPA4tK2OLLQxFjHXe2T/K219/hE/ybK19K1bTuQ/hRcaWtyHVXhq5bl77A7RtZVXLtO40RuQRZfMwO1i7mSxo6LCiZ0yodNmXUkhQO6msknf9bgM1oBLtUekEneK+0Oy5163d92NLwM0KBNg8IPclVCjQWqriDm+064d2h2mMgeH1VZCNQ4IL4HBTeVRqPAqkO/f9CB6Ii66G6QZfgT+AFfNQIM+eNhaOO789TSjUTpjFSue9gPaFC2esMp2EBULU6TRABZq2VddBtD4CQV0K6ZbbK7F0W6Lp/nfz5xcvPduQ9fQkSORir3Ypld2pRA46LPm4PtihocAP83lbqSUZq0WLXHG8XZIKtpv11kCLPdsotdIGjo2Di67vBUlyxxvHppIM5AcEmJmM24RU39eTLNPAQyd7KerndX9sH4bueviPtgPLt5s48aLGlC9l/M4G2qv+0iS23hhOvl/b3ftpIMNU2MfV3fIF+odC1D8gApoiJ3PSeTKQbZEmfJNGIhQZmUhXiI/gLoEuFzj8QWwBjFtKCvCq8vscAA8gwK7jJ56LoGBa6LcnY7cmAIhdXwZadfPBG4mRIyXPRsIPv2f7z48Onxwf7eHBnL179cLz/P789ODz6X+cibyH/wP8FTdUEd/5MYfyzg4xePdinf0SkFuAjtm0O7hwI/KEZ0jSiCB/4/1qT/+lgH3y32QErrPvTYXaQHWaHtnF/Ojh83I+d6tbluhabFF80xHUSrNd7tfMXwCEGT4PUbpUcez0d24Mc63sYfZj6avyLJJ2IhNQHtOSyao1YK5MixDvJprvLpAj37rLJ49xbOyPt5QebbMrrtmlZae7Wrc87aS8ZQgCrpDFSA3P2Voo9EtksY5YYl1ldIYrQ6y7MApz1eBLxgdWR7Y56OH8GrvjsGtw/gNulP4G1/HftJEZvQa9BK5yCmdsnNI6uNbDIQ8NLxvZhLQ/291dlC/iluFS+QJ8im9AkB/QJukTQFQJeSD97ZEXGrZUzZROEbLfqwHcAYgGVThD0EcA9qpuGpxrFjqCbJbVoykY9IlpxJUxnPX7C4eD65IdzgrniuosLGsbs0TRjv8Cku2ALi0a5676gvVALDidTBQo/OcHHYzgQFg6ueCobhbMHgzOv06sOOVi0ml9CZ1nwHfqhJO30XCsrrQPgRMsQrVvZXaNnK4SFo0Kfqvc4E/jjzK2nAvJSpucCguklGZwPOm/PNQcDONZssDhtlKjZ7vDV5Xz3pwQei86lkPQX9R1Gg6M84Ny3XCtwYy1J7BSi5G3l2PnSggEQgabS5xTH0w31bcOKv4W0qSvkpBPIcVBvRCGjHEOYgiutMEpw+pIG33nVGt2IvZPaOmEKXu98l+zh6dSIKx+4CK+fX+x8B8vIFfvpp+O67phb8iq8tbv/5Hh/f+e7bPRZOiS+Exhz8ZEwsrRbMLsS8lBHen6lsW4z1ix0XcfB9wnMy7O0QzE4M9JY3Q/h7xtCdSfYuHA1rsPAgzM4pGDIzLIpnOTJd084U+gJerRjND4ETAC2l5VxeoAUlapEHxy3Vueyaw2MZlro6ReiWeFvroo98tz0Y2y4oGCeaCuoG7gPh+CQp8FYZW+8pw/I+p8/nL75r9A53HZxK6r8xeZ/sorh8mBaDGs2eFkK3xpfVoP5ENBOxMTI5icEkfI7lshcJwNf89D0HhYFxufAQNRfeEV8FQLqqe8w2n32wEsEfk01HBAJEOzjg2MPc1UeDKURskgcJdmLKGhJxPIKelAKbpewzE6Azgb+Knsfr8ncaNSsN52ZLDY0kTMjsaE77nioAH704+nL764nbMdzm8ZF8fqGBZZqkMXxYHicAuwuzSWkcQASIUSWyqkUrXpzWL3RRY8egIrOHa86TJM014SZjg6e9nF8WMFAHiW0cGpdQOLJinDQi1A9+/BUwX2IA4zQZWK6/PAwfMPdfEOjn3E3D0btkEet/OMudE7M++HUAAasNFZrsUfRUaLhQMOLIthuE4CF+W8TQGTyXR8Vx81MuA8bJMUFjsBgBDRV7LKupLq02S1W0oMhgJwAVAAqVWIM1/6MWYfJCkXajYnUC0rlRGn6HqWp6c7fSXbWo/MVUesZOU2nmgmdGmg/Cn2bffaj0MH6AGMp58Ys0/4qvHMJh9qTtJUMDxqz7+ZBrZaUq/QMPTLKCmFk9LE5kc8xHa27MgAwOz0LFjiEtj2ddm0LN7WI4lOMmy+nQu+Lr877AivzAkpfSFVe4OpbUPnnVeQN6bStxvsSqvG+xEq8L6AKb3hYCPorPrheg13Eah9SY8BO4HNEV2u0db2CoKRyeMWISlzxuDmdTqMVd9UrGzMKHqKyaYNSYG05UxgXvCvpKv4U/r7BDDmJDXh6biJqyw9Bz6bFbObYLSpsVCiTgG/jtVDrHZbpjVCdWwU+7FogdJ7Y/rVPaBZiKHBt0nByBRTOFeka84MJ4pybAu7eGrMraVwL2cm+A5Qds5fQHMIEzzGKNQgZ/KWdCqMEGPJwwgzn/rvwJcQ3JVzf1ZoVFniQXf1zE5LlKAaSjjfY5x+fP/3w9GjbNWHbNWHbNWHbNWHbNeG/UdcE0J8bwmT0E8EOMrN3jySEASmC3mWlW8qAmws2CZhB9XFdw/41wrVG2d7Vj6HZ4uhGq+5h5kMmHY4r0wZOJzbSMeQ00YUxvgh5DIePkFkS7VcwcaWaYYYCJaTf2ETVW8qU0uxDgkDZCXTKRUE0WaVCcwsV1nfEgGVjslnfxGAznSx+oqVcP+am+PPtjbwJTi5iS8+VCUcmnPgerwxCIyoIScz0+h0uewLXeIRJLcVgNqEMj9exfKqrXgIXGVQgQHaEKiCMK3JZCEs2LrJRBOo08NbKwmublbyW1bJPtQdTTT+fMw+fPQq+PiOKOXdwW9FUcjVmpRFiaosxW0hV6IX9biCM/JsDvNtqU/05BjYv9cfA5IYQ86G8MRYqe9fK0Tc8Zz+fszf6N37Vrx3TNrsEk/+zzcGPFtHGMxdkfPt8oQHmR9lRtr97cHC4S3Vhq9gP99qm6R/SlxPqX0fw/1jFNhybPxfGYTzie/BhaTtm7bRVrr2J17lZrHRX0bGJwedCnoa7lUcO9rODo+zgljDOw14HuiJ+4T7FF71uxXQnLUUeen3YIQKElxpPYoflCV6id1V32T/U0jOxdUm2gyGbXPma9CBPIx6dro4Q1+ns0bbj0Lbj0Lbj0Lbj0NfdcWjuXM+L/9PFxdkn31ECH8V02Cz0h2GT1lTUAhdzCJ3u3aoJr7SmCvjSpbh39+eHD6a6WGZp69qbtseafOv00z5xe/kZfTQZjrpK3ufPn12PIiXT3AHJ+3DCBR1H/GLciOVPoqo0W2hTFeux3QAtLzRkM9mbKPoIkMXNPhe8EGaNcXVw9Hg9gaGViy7ugPN9SDvqkdQPlYi4i3iZDJ7XfLuYqUhrBpxmlV4IA6nzwHexB1XGzgUVyuq8rUOeV4RtqWXLzmlIq4cDwasX5zvZaJU4M+HGrIEWJqxp3Voy4RXRZmMJW+8IPOlZaXvMOFhNkD32eG9vWulZRk+zXNd7K7jbRisrPvs+98PedaOnSH7enX4Tntdv9YDv597rhO39NjshDcWgrV3j6r0r6teX2PRp6gda7/E92u+HyTZ7xEO8aIghpfAIFxAJ/aZIo7/Ws7spdO9z4r02PyC3Qh+su2tmnHyfEA9i7Yx+DpVOgFWMglCnsJAPQF8yf4G+6BU/L7hRkzGbYNM0+IdcUygqjOlNJ9RXbWJGoYytV8cFkwkFuHy1eQFu/eQNAgs2cQklb7appENngHRQlyVVZ7Y23PT6IZ6i1e3gTrmQ6johsMFw81yReki5ShrIAMS0Ui+sBUFJC0T70wiTHQ8mFAqAI8w5vxKx9gjatkEdNfBn6KfoUwy9Z0CoXPvLEgxTYsGgSwsYq7W+ituQwWTzCmrd2mYV5YQ896pfZlZTefJoBJX6qOtT5/A0eMDQWviHy5gx/AZOFfZmSXs/MC5Vy6TS4G3y6HqJgK0NQq1NP88DMIcoTauI/j4tWF8JEyRIl1SCqaABTpqn0TFhMtK9skIC9HD8ILCrVUShUVA2urMUQ7ZaF5h+MKE+OsGhsEcEtlLgKh2VJFxjtNO5rvqtiriZSme46Vz/jApbqbMitiSEu04uBasllFhSHdMYOZBXVuNg2LgofdleLhvRudNk/vuYlTwXU60vx8wtpHM+aiEtW4R1CqHcrk1U1+STXQlVJN2UtIm3KdJkCgEqtojpxLFhgt8Fe9DWkJ2e+RxqC1augeqvBOZCmlA2+AWa5lz2b4JbY3UN1MmnWFwjf7xCsMwZriwa4pgEOdWwb6QR1L+to85pySbUmQq/pKL7tK16eB4a/YzZJGxW+skXbcluJWxbDwnw+OnzHgFIgrjlh415/0Yn3pWFrT5hkji7ZHLs9AxaGRSBm7hlC1FVJOQIJIvbr8tW6Ms/2gnYndhpXe3ymdLWyRyaGqmCm95NmxFsWelFuhivBTfQag3yNlw8Gs2km7dTPBQBg2Brtb1IvF1Z7IKtNqT3wfH853+1b49++tc3Pz5587e95/NT8x9nv+dHf//rH/t/6i1FZI3+OjyIebPzMgAPdloQ187wspR59qt6J2A+aCWHuDmU/f6q2K8EkrFf2b8wqaa6VcWvirF/gaYRyV/Qe8QoXvnfxMf0r1Zhh6pf1a8Kuj+nMGveNEmDYro/FpTXrr9Sj9rAwTvUp3YcFVJi2KQwo+QCMCPLMKccJn8lxSLzOFwzcCANdHsQRtbCCeMR6SF9N5w6RHoYACYYyqDBUshx0GxnlZ2I9j2+KbVZcFOI4oNsbmGdG5IPkis5Yp06bdfkJzKQG6M/Ds+4B99DE5WD7LCHnuSKf/DpS33sHkzAnJ68PWFnQTq8xaHYo7BzF4tFBjhk2sz2vGIG943dC/Jk1yM3fJB9nLu6iudhxs5JjqC+Cn1MwleW5A+vsKcFSjA0ld4K9wOUqIKEs/gv8thGuNBHjGy2lly26+Y0IHi/5HDTYRFvHE2XTGOUE5qSgyOZ1BnJFRk5eoDtj+D5Yr/IUj7gLSmkcAnIvVQufbtG6Xa/rFG74ccIMijg9Yr38Kg/a1raW6Z9n8UavX4WThdxGBw1Y+JjxmBfjFmFLP4bz8GSBKKB7o2vf4GWW4yPBApGrDdBwnNgeG4jLydCzFvtkFEveNcIQrC/+HHSbRgvD+goXPElFCW2RTNmLm/GTDZXT3dlXjdjJlyeffflUd7lzWfJSzj1UeCfz0+xDLtirnewgd8CW78GKmZAuyNPweSU1FiRj1kjayTol0dOQDpxDVCnGpP6Bn5On93gHDhRodGNGRSBgDkqeRU4eByLY+G0lh5uCT/fXCK2AC4EFEKMA3z8yHcXuR3ibl+/kXGVNHuN4oU6cNMKc5a31uk6ln14oFC4AsOHxvirPU+0KuWs7a4igQKmVt2dAMzq0sFwSS+0fhlKKY1Y8KqykLnmTItpX55CUqu9xuAU4SG1oOr6oySWK3T81iZ2uFqIaQ+LZBBMAq+0tWwdaCDkydkbogaaHQHRwA2pAwc6Y13vvyEB5fH2aSRqCc7CpI0YzNNGVrCh14tnB8v4HUgcOqwQTOqzwt54Tx8oDDxuqIK9ungNx7pGQ1VJ16CRWkUnxnps1xJMB3CYg2sQG1oVAloPB3pAXRHolU9wOm2LbbbFNttim22xzbbYZltsc02xzWqtTdA2/ayzezplEqfLjeA/2zWnYfht1cO26mFb9bCtethQ1YMVRvJqsw7jcL6GIxQeERP36qa9HOCGCLcNpGI1dL69sbG9MFTsCAfDYDkFR3QHCTopZOuybkKowKTXDoSDJ2bhFBb/01i6IuzjEv+hq0oY+Jc/xMK/uiPomtyIALNH0l70+SGJGmfuR0hz1vuLunYfPAgKkaVoiLS1hzYzruQfnbEf3Dyrz2/JA0nhhPO9UAZSPdCSBfnWz9ePyRlwouYqaGltyF7tMd1KpkZkvN7dpHNRNVCDw7gxcIsbHPR9200Pp7vzhyufpAMllrqftR/R6ObzKX06/gl1KimqfZbapBpL1zuYB2Fc3buguBPB56g/bmEnEEI/n8cWdpRPtp519Ip0v3v24VdpGX7lZuFXbBN+RQbhV2wN0jw/F+Z3ZY3OFIw09h7fVMqdJY/ufJn2tcKNhyHWazrIiIvarqvBI59zDx7wUXKJsCz2El6mpJJeXi2MFG9gzRqsxSudUJCptLSh/3G43RdvWAavO0FEA7GRPmAFvDur9JRXFN2CuFVAt3Mo3UVeczOzG+KL0YkxfEnpEkgkbmYYEU79ZG/wnkmyJ/z0ICItcrgfQ1np5FWvCDIbrbAR/bnLbCzR3GW7QRzuQjQh2J+7cPqA/600bhYfRd7iLQgbIsXJFG+HgRzwrq9xoEo3+mCH7LXW7E2l2gtz+wxyc0Q7jrQQveIT+qE5NeySClKtG6NnhtexANLKWlZ8zU3Aq8g3srjFGv+kqpGAryyy1ZGPDo/6iUnNYOy1dPu08TlcZBGWc8Sa9YgcPOhFKhfhltWUk+jClMHoo8P9g6e7+092Dx9f7D8/3n9y/Pgoe/7k8d/7vn+8dKvIHp5CFwiYnb68fYHQtr3D+P8QZ+MgCWtfEA3x+Rgvp/eX66JItpQXEqgMiGYQ4PFp3NPunk13HO/ZTFodMM6mRi+gatuKUBxCSARZAIHhhs9i26EKM67UoMgagvBSzT74vNHB5dkPRiqgCI1FuVRwP7UuU3YbLObeXNdij1f+woqAcpoYQDr9XfLoRp0eEyqhghnumwrdSkuew72/oJwbeaWRqNxAUiroZCny5AYrOKPGxQYp5l+wq9eqUDq8hUtVoG6HqyVrKg5vQuIxBpOpjoFdpCgQaH9ZHmBCJ8h67Euu4VsedCGE5nEISrf1DCBJf0ONXdHtIip/UWxCVMwmcSYnkAWRG+GiwwfcRF0IQdgx5RfSxFpscgSxkRjXN2NKzw6uoSQTbszySuK1YOFVCDdS2D9LE1CxCQj6B6C6pMApnp4Fs8LpDnvZTMbetoIb4iDl1BONOhv4bMPTM+aMvJLQTXjMFFxjBUUPvqSBgEoHeRSCG+g5Ol3GpJ10qGOeTbM8KyafYA7J5g4ban3w5qSK9XCQ245rrEPvktAsN4yThFVoT5x3T27YEifsfF3qT1fdWVBviLBQwCSKMpVKbfrpOUbMILMVLHfIs8Drxbv3If3DsKmMuZRgbvpU1lyb5KJi6CJz8eKMoPqgKnnGKHfYiFxIyFgiAkklsdHE+d/eUi7oIxsa9hNQANjhkrEfYr+YkLA4GIl64FbLpNrV04NgruTAKxvuQ0SpQMk20EuhDUFbhOSEqdlOhLcDAgiLuROwAQu1grgNHcbwZzpmhNjysKKKINJBCtADwWZXhkjnQQLpvDcApMW0FmdBELtUIKmAJ35rVd6dY/xOp6/XAetI2zUC6UDC7vXLuIuKiDghMsgLD34vTKF/rwoYlAqkFrOi5gqKNyi5HggNtWMf/dVIJM8IqLR4VIMGJ06zKwnTharnzr2pWC6M473CqCCrTByjhCSvAJMu18q5EzNtll5YUUGcdbKqmFC2xcoq7q4rbQGClbKqotjgTWN0Y+BerWr5CdKIJPkdRNK9zCHkerpqzy9MVB0YOogCpp7KWatbWy09N+M3BBLuRQaVFk8HGJrgIMbHjIdmfCjeW2zhBy2c4WLkv3WUpSaOaX8ShiwPfiXCKfD9JKMHVCMbmQyzPRVUfxJU2F+tT0fz58pJJpsJyLRJ5tGagN8QVBZojtjcursskAE0GdzUm6of+zP8AIdd19WN0EYh69Bvz4G9dfC8n1/uJ3ULZvfhFJIHHn62TZnbpsxtU+a2KXPblLn/RilzsrkFh/WHntEwZS0krNHrDPY18NVKPJidnl0dgTI+Pbt6GmCIVV372TLd1qXZUbXXLQgkqr43yBmVp91Hsfd9YncoePokd9wZFCXdMO9tP81tP81tP81tP82vrp8mNTZZdauFRzf41YKPBq5DXnXSBDGJv2mz5oojMJAIObjhKNdVhXdQrw8yxwBzKcFdrYqEO7EqHNwpyW2SYWwwXSm0/gk+BNHMRS0MrzbY7ONVGCMVT5qswoD+I1miDYDXkkPHOQLFqIVHQVWZcEsFunss49A4B9yUGNiyvgp3QgBx9xUa73wKnYUS5njOj8on+/tljxgb2U6j96v7J3CtaZUC10LAeDhlclX4HVjFS0yXPdJRk4GaX0IowkGbSSvxyv5EsEXQyEJJ4SVKOa2SxnJDdOLNF8GRb2CdoCeFUDnMQFoLxXroLARYRhQwAQVdVnLR+fR9GD/CDZfVS/S/WNGlUgCCkdnR52almlWiu7ZssKLF42fiiZiWYp+Lp/nR988Oi6n4vtw/eHbED54+fjadPj88elbe1iDhYdY8VXJET/I6Jvt/TTIvU2s+lLbjfRCwvicVLTvedW6hStMtdCRPd8YKsHjdAeSmY75gGPA66eU+F8vQyyoGL2WMycH/6JKMuNsA7y74xNgJxD4hDOPRAyYrJOSATVuYOX1G17CYVoGBEjUOBKHsevYFglIMp5ssm3KoUaaprCQmUA05diLQJXtVcWgARIGlhMyotqjyOKhp+DmvWgvxpfSoxDDe8GfBnR2CkBbyWwtR8raCe39z3cTYaKQXiFNyUUaYsoRwVoBB+1EUQ1YX6Rx2ac/0+NomgceHZOwXdO0Nwo+8RXP6pyTLf9LugnEDY4eydlT76/RsT0jCAU2rOFyEChCvkZQov7qSZJSafez6zDjudBdA7bqIxH4Hk97CT25hjN5ykGWwiRX5d8rnW1mQGGhZ8BtXpZNh2DREX4KnilPquHD+xvUVm4dmAwzIw4BDajzODrO0r4KPx/TMv+7JDdaff2tg+A2icyHgg1h578AehXYJtT6kJAx3SwAuDR9RFO6LDBNRwGsbJvpCwkR+PciblDDRPzFW5FHaxoq2saJtrGgbK9rGiraxohtiRagsvrpYEWG98VjR3bX7ZwwYrZn8NmC0DRhtA0bbgNFXFzBqTZV6C96/e32Lq+D9u9d0BA83ZjLbNiBvkTeg5L6CjHxMiDW4lu/fvaYGfvRmUBJAr6kR/BK8tIVeQNUBeMlzCKaM6QQ1xpIx+l6zIPvv4hZYd8R7uE3zkk7sRG5TjeMFAjvQfpk8VVmud5INcarQBYDOWss40rPmS59OTem+YCb4boNIV59+Xi270t3gLohQYb6Z9wNDnSS3Ykx5+FF1exfbTAdtOqGjPXkHBiZifwo9upaGz+rOs/HglAVtK3VKWsZLR91CJt9OEkI73aTUvQAH9LeTcF8KXQ+DUALS2ehzVb6flggdlth7wmQN60kFPFgWAY2v42otE4cMZgKH4RhY9nCdYQbwJpAFLvDi2KThuoQoITSBdKaFyCvIA8oxDx6hvjeK+kCvLnvaQLxb/uOjo8d73uf6b7//iZ77v791ut8pN1ytsyGqjt4rf/+OKLori+AiR04lJ+ls4ywJEh6bKHddqlhC0PUrHaftaYq4O7FPa1hMpL8RPIgxXB6eQ0UYutU9DPhUWqpw/g36R8ek/9CtFgRbj3nT1YyVXvGzCJZjEBSczgHRcU/wrg0H32thgYuu+bm35g23NlnJh17zMwIf9nLvSr8OB7cpA+kM7xjqjZ3IICLQTnbLEWQtOvc+hgzwODp6PND3R0ePe0hhldgdsLoPkTBuhQMQE0cPB+Lrf/FHrLVzIJhwjxDbWWG2gYz/N5Tx4iM0ERHJdRPpKFjp4jUs2ZigFdjk3ya4Q6P5xai7VII7forvwG8cvsHUi/DWOBkMP6Ckjggx3vpUN67DB1H3b07o65VQXS8WzabCLYTo1DwMCjHwnA8P/95q2tTaniP0a3lvB6VLukogZ32R9uR4rT72+F4jp3ozAwN6g4ev9wR+ZXLZN+z/sXd9vW3cSPzdn2LRPjgB6pWdi4teD+ihiNKem+YSXBz0UaG0tMRmJQrLlV330x9+5AzJ3eWu1rbcP4CBooi15MxwOBwOh8OZBgUNM5n/HrhSuyQbnK1lXg3No42/WLNN3b6EnbyU18Jv1mSxNS/aqGwi5MdWqIPHSMId3Tio4BeFvGh2KfABzxX6qVcCQQk4IfPrVzbp/Xtd2intMrO3mMQlrvUwxgz/M/0ifyOXyN/AG/JnO0KefCB7fSB/OffHX9bzYWQ1E0s+EkWaPQu/jtDvDgZr+RDBiUM+ZUHi5Bd+ZyHiLlfyllMgrfQNlUtFJgyOMMFxIs6LaXm8FRWshZ0nle2L8Sq5kD6Yqzk3j7KSCVt7StT7FYcQ9AvLoxAUWNeRkw/iSlTqjzzQftzQhEYhP0zkLE3kW/27KksxOc9Ps2eOjf/KXr3/SCxFlryzF7MzV1CTc7k9z77fbkv5i5y/UfXk69NzlC07J9BZ9uzNfy7f/vyV6/OjXHzWzzOKe5qcvchPs7d6rko5OTt/ffbyG+LT5OvTdirbp+TYT8mxn5JjPyXHPlxy7McltRXbObA1QAsenYAf32ZzaUsFic1ipSv358lCr9eWo2RLIHj6qIXtO0vCK3ZHuC62O1sQ/vBgjUvk27i2vj+b3voovZ27XaJV9CHFksFKDjTqBmRQliMT2O8h2s8BFqXyHlC43r51hLYbr9USkgBO19VONqG7sVBLB1bPf5ULNnLdH7O9I/mOfow4a+eRq2ThLEbIWuOzlfipd9tw6kXyGp0IHpvuWKiiKBSlCYLtjgnkmHyLh86mzTmMqYkiyvtmcICsQFoUss2g7UR2pKM7iRCiWBEPzp8FmhS7LuCkjA5ChyQhV700Ob+DOOpnTIMpl8q9BVEyvNHJVMGrd1HqXREW6iv8yb4PG80u6EFbgtNv6avzOS4aXQ2cELLgpyOiKGa2wYxBcuY4XcVLuTFq2yHfVhqiH9wBXgvRl5PfjgaFITZ3qQvk8Uetl6V0IwYJWZZArtbI19pFLdbqRMwXxdmLf7wcxn4BCNnF1PsY7Kj8VJBsfpl9DzGxjXRZxOqACQLjcs8SOz975CzZeFDOIhxMYHgqOIzGD0gV98U0Yum0cI1dPxE2evY0ixTMMDLqkEcdxuKiDUyVqr6djdg2hnuNxUoyPnbiOutrLJ7KRgqOwtFomoTP+qhASqgqKKQp/51YXu4bcibX7Ucn1A/r2sA9MnP7H3LSl0ZG5orDd+KVUY9Z4clK745xl7gb7YhxLE6aWRHD0l2STOtBBY1zd2zoFW93d8Ta6jkO6f3RlWIuS5NlX2aX76bvUMP8Bm7KtdhCyRr57whswpzaY1IN6POg0x0JOUsu9vMgt6gNlpbaC9hDkbTStoDu/CYzjwQUvyfFk/YNJCJlexnVYfybIbkw+e26zKmdq+KB2Avsgxu9OQk9W65lR/qwpPdPTcP/yyDmWpdSbEay9ypwxN45hmnv4tUmn+9U2UXZnVG/e39x9s307PSfX4wj592HzGKIvdBpQuCqSK6DIVpMXcl6sRpPDGPhaq1eAj/v5nBDuKdGJIdv4t8ScMN3b+w1LbcANFhse7Vq6LRXs4ame2WuzfGtLvKR7B7gaMSBraby3UlUO1UcDNN7XWQfL6ZdKcL/zVYs5MFQBYhdZHjdc1AObthZ10Xm1OV+tTwOEen/tdh2MdkAWbttHQxdBDKNs5I2u6SRjSPjwxka4PawtZDbUt/acL6DIg5wexDD7MJt3sGHHAHuQR32g4Mi9mD3ok2bWA/H6+CSOifNGXQ5paxPK3LOZ++1uD9CprRugH03lSt/G2vkEYa8U08jZejRiH/Vpf6sxAmebhXKLPR1fBT4yX3NpvTlNovbec/DGF9FAlS85xEdHmTew0VqlzuHTtM5mxKJBF34j/2urgwhXBVMAPkh+3Gq4u7oXgtE6wGyDYAR4Q6bXjNTIJZUnGIbTCiyYofQLRy3qnq3bbhKrdkJh7x9Wuh9jcCMSCexlohZ1lU2lwBh582Wz5eFi8exPyxqhG8CqCosaUZe20xCCF42LoQKmYSjugMI2EHTFYyiJkmIE7C1FawHMMVCyne3rXSxW9R3Z+QlveN1a5fAwCjzYxtCe29xaaA9Nv5C4VmE+fke1FEhxjtiphKLxOow/EgWjM83ozZpOvitxZ2xI4JxpW9ccL9DR9JqKRli+mIXwshTh5IerL/4AHMeH6KNWcTpACd29QoBIO7FMQces1or9TJosZ/1EgWO3BS64Iqha5GSm5dq07z1aAyz1MsczfIo9DfFWsRdqEoWwWTfw3AAbac4AymWC6jkQpmJLFUsli5PjkgVOgI89v27xDr+JPRpci2qSamXEyo0WOrlp7w7Tgpkb2a6ePBgKVsHQe0MWS/p9onHnU3CC80Ekfrqysi6L775ftPgYFLYKYKqZeHmwqpkk4n2lRWOlmJ9KA5Bch3E7AZpM8AFLPKgAxCj8xUvyGNTF3pXH2M14N+yqo6b5KnNdlfHftVAjnX/7OWKBWBVSHu+wlzZC3bkWmkIqk2oVrNQ2vIrIQeTx8Eu+09A8SnTlgh+VeGQG6qnQfrwB1VKXAWRgiBxb4zakpYbBEC1r3EeIiEMMLIirJKMQ2WZBHNrsJCoas3twWhggLiSrkRwxoJd20pppBRIk8JfD0YKA2SbwA15iBvW2ICj3h4tD7VowJbVbi3ccoEZnDGi4Ul5dDIYUZqMlHXykPlgI4Wm438/vDp/+eIlYfNlXNK0wNZTxSFZwdbjxRTyITZteqyY2MdNG7wvoph9QWsrTeXaLA9LJCc+6yEvTYV79AbDZEYXtz2+jwHv950pDUhdJq8hngoTTgQ2d96H6cnFtDUYmp+cygoekqtxmAeBbyEHG69KsTT7gKXNHtuVMaRmCs86cldByMiczMBZKTfLlumWCElodJ3r4jaPc0Ql708YbQiiHHnqb1WaTndpd+p/b9CdwQZ52ClbrqTUfA/MrN97CZR72+j3//7tkFGvdbEr5Z4pcAAaTQfZDn07s/EbtVhvRwFfVDJKvzkI3d1L5qKuK3Ovtd7DzxhuEG9yuuJkLTfXqtIb6x+8FpXClmKym0rViOFGSkwL4dhkP3149187N7CLlzAYi0r5PPOdjMi4obJZAOkavJRODdtXwfgYHb3IEmvCzfS2Vnpj8qP/DwDbVzkX"
 }
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/libbeat/common/cfgtype"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+)
+
+type config struct {
+	harvester.ForwarderConfig `config:",inline"`
+
+	Host         string                  `config:"host"`
+	URL          string                  `config:"url"`
+	Prefix       string                  `config:"prefix"`
+	MaxBodySize  cfgtype.ByteSize        `config:"max_body_size" validate:"nonzero,positive"`
+	ReadTimeout  time.Duration           `config:"read_timeout" validate:"nonzero,positive"`
+	ResponseCode int                     `config:"response_code"`
+	ResponseBody string                  `config:"response_body"`
+	BasicAuth    bool                    `config:"basic_auth"`
+	Username     string                  `config:"username"`
+	Password     string                  `config:"password"`
+	HMAC         *hmacConfig             `config:"hmac"`
+	TLS          *tlscommon.ServerConfig `config:"ssl"`
+}
+
+type hmacConfig struct {
+	Header string `config:"header" validate:"required"`
+	Key    string `config:"key" validate:"required"`
+	Type   string `config:"type"`
+	Prefix string `config:"prefix"`
+}
+
+var hmacHashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+var defaultConfig = config{
+	ForwarderConfig: harvester.ForwarderConfig{
+		Type: "http_endpoint",
+	},
+	Host:         "localhost:8080",
+	URL:          "/",
+	Prefix:       "json",
+	MaxBodySize:  10 * 1024 * 1024,
+	ReadTimeout:  30 * time.Second,
+	ResponseCode: 200,
+	ResponseBody: `{"message": "success"}`,
+}
+
+func (c *config) Validate() error {
+	if len(c.Host) == 0 {
+		return fmt.Errorf("need to specify the host using the `host:port` syntax")
+	}
+	if !strings.HasPrefix(c.URL, "/") {
+		return fmt.Errorf("url '%v' must start with /", c.URL)
+	}
+	if c.Prefix == "" {
+		return errors.New("prefix must not be empty")
+	}
+	if c.ResponseCode < 200 || c.ResponseCode > 299 {
+		return fmt.Errorf("response_code %v is no success status code", c.ResponseCode)
+	}
+	if c.BasicAuth && (c.Username == "" || c.Password == "") {
+		return errors.New("basic_auth requires username and password to be set")
+	}
+	return nil
+}
+
+func (c *hmacConfig) Validate() error {
+	if c.Type != "" {
+		if _, ok := hmacHashes[strings.ToLower(c.Type)]; !ok {
+			return fmt.Errorf("unsupported hmac type '%v'", c.Type)
+		}
+	}
+	return nil
+}
+
+// hash returns the hash function used for computing the signature. Defaults
+// to sha256.
+func (c *hmacConfig) hash() func() hash.Hash {
+	if c.Type == "" {
+		return sha256.New
+	}
+	return hmacHashes[strings.ToLower(c.Type)]
+}
@@ -0,0 +1,193 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// errorResponse is returned as JSON document to the client if a request
+// is rejected.
+type errorResponse struct {
+	status  int
+	message string
+}
+
+func (e *errorResponse) Error() string { return e.message }
+
+func newError(status int, format string, args ...interface{}) *errorResponse {
+	return &errorResponse{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+// ServeHTTP validates and decodes a request, publishes the decoded objects
+// and waits for the events to be ACKed before responding.
+func (in *Input) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	objs, err := in.readRequest(r)
+	if err != nil {
+		in.log.Debugw("Rejected request", "remote_address", r.RemoteAddr, "error", err)
+		in.sendError(w, err)
+		return
+	}
+
+	batch := newBatchACK(len(objs))
+	for _, obj := range objs {
+		fields := common.MapStr{}
+		fields.Put(in.config.Prefix, common.MapStr(obj))
+
+		data := util.NewData()
+		data.Event = beat.Event{
+			Timestamp: time.Now(),
+			Fields:    fields,
+			Private:   batch,
+		}
+		if err := in.forwarder.Send(data); err != nil {
+			in.sendError(w, newError(http.StatusServiceUnavailable, "input is shutting down"))
+			return
+		}
+	}
+
+	select {
+	case <-batch.done:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(in.config.ResponseCode)
+		io.WriteString(w, in.config.ResponseBody)
+	case <-in.done:
+		in.sendError(w, newError(http.StatusServiceUnavailable, "input is shutting down"))
+	case <-r.Context().Done():
+		in.log.Debugw("Client closed connection before events have been ACKed", "remote_address", r.RemoteAddr)
+	}
+}
+
+func (in *Input) readRequest(r *http.Request) ([]map[string]interface{}, error) {
+	if r.Method != http.MethodPost {
+		return nil, newError(http.StatusMethodNotAllowed, "only POST requests are allowed")
+	}
+
+	if in.config.BasicAuth {
+		username, password, ok := r.BasicAuth()
+		if !ok || !secureEqual(username, in.config.Username) || !secureEqual(password, in.config.Password) {
+			return nil, newError(http.StatusUnauthorized, "invalid username or password")
+		}
+	}
+
+	limit := int64(in.config.MaxBodySize)
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		return nil, newError(http.StatusBadRequest, "failed to read body: %v", err)
+	}
+	if int64(len(body)) > limit {
+		return nil, newError(http.StatusRequestEntityTooLarge, "body exceeds the maximum size of %v bytes", limit)
+	}
+
+	if in.config.HMAC != nil {
+		if err := in.validateHMAC(r.Header, body); err != nil {
+			return nil, err
+		}
+	}
+
+	return decodeBody(body)
+}
+
+// validateHMAC checks the hex encoded signature of the body send in the
+// configured header.
+func (in *Input) validateHMAC(header http.Header, body []byte) error {
+	cfg := in.config.HMAC
+	signature := strings.TrimPrefix(header.Get(cfg.Header), cfg.Prefix)
+	if signature == "" {
+		return newError(http.StatusUnauthorized, "missing %v header", cfg.Header)
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return newError(http.StatusUnauthorized, "invalid %v header", cfg.Header)
+	}
+
+	mac := hmac.New(cfg.hash(), []byte(cfg.Key))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return newError(http.StatusUnauthorized, "invalid HMAC signature")
+	}
+	return nil
+}
+
+// decodeBody decodes a sequence of JSON objects or arrays of JSON objects.
+// This accepts a single JSON document as well as NDJSON.
+func decodeBody(body []byte) ([]map[string]interface{}, error) {
+	var objs []map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	for {
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, newError(http.StatusBadRequest, "malformed JSON body: %v", err)
+		}
+
+		switch doc := v.(type) {
+		case map[string]interface{}:
+			objs = append(objs, doc)
+		case []interface{}:
+			for _, elem := range doc {
+				obj, ok := elem.(map[string]interface{})
+				if !ok {
+					return nil, newError(http.StatusBadRequest, "array must only contain JSON objects")
+				}
+				objs = append(objs, obj)
+			}
+		default:
+			return nil, newError(http.StatusBadRequest, "body must contain JSON objects")
+		}
+	}
+
+	if len(objs) == 0 {
+		return nil, newError(http.StatusBadRequest, "body is empty")
+	}
+	return objs, nil
+}
+
+func (in *Input) sendError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if e, ok := err.(*errorResponse); ok {
+		status = e.status
+	}
+
+	msg, _ := json.Marshal(common.MapStr{"message": err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(msg)
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+const inputName = "http_endpoint"
+
+func init() {
+	err := input.Register(inputName, NewInput)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input accepts JSON documents pushed via HTTP POST requests. Requests are
+// answered once all events of the request have been ACKed by the pipeline.
+type Input struct {
+	sync.Mutex
+	config    config
+	tlsConfig *tlscommon.TLSConfig
+	server    *http.Server
+	outlet    channel.Outleter
+	forwarder *harvester.Forwarder
+	started   bool
+	done      chan struct{}
+	wg        sync.WaitGroup
+	log       *logp.Logger
+}
+
+// batchACK tracks the events published for a single request.
+type batchACK struct {
+	pending atomic.Int
+	done    chan struct{}
+}
+
+// NewInput creates a new http_endpoint input
+func NewInput(
+	cfg *common.Config,
+	connector channel.Connector,
+	context input.Context,
+) (input.Input, error) {
+	cfgwarn.Experimental("The http_endpoint input is experimental")
+
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSServerConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connector.ConnectWith(cfg, beat.ClientConfig{
+		Processing: beat.ProcessingConfig{
+			DynamicFields: context.DynamicFields,
+		},
+		ACKEvents: onACK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Input{
+		config:    config,
+		tlsConfig: tlsConfig,
+		outlet:    out,
+		forwarder: harvester.NewForwarder(out),
+		done:      make(chan struct{}),
+		log:       logp.NewLogger(inputName).With("address", config.Host),
+	}, nil
+}
+
+// Run starts the HTTP server. Consecutive calls to Run have no effect once
+// the server has been started.
+func (in *Input) Run() {
+	in.Lock()
+	defer in.Unlock()
+
+	if in.started {
+		return
+	}
+
+	l, err := in.listen()
+	if err != nil {
+		in.log.Errorw("Error starting the HTTP server", "error", err)
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(in.config.URL, in)
+	in.server = &http.Server{
+		Handler:     mux,
+		ReadTimeout: in.config.ReadTimeout,
+	}
+
+	in.log.Infow("Starting http_endpoint input", "url", in.config.URL)
+	in.started = true
+	in.wg.Add(1)
+	go func() {
+		defer in.wg.Done()
+		if err := in.server.Serve(l); err != nil && err != http.ErrServerClosed {
+			in.log.Errorw("HTTP server failed", "error", err)
+		}
+	}()
+}
+
+// Stop stops the HTTP server. Pending requests are answered with status 503.
+func (in *Input) Stop() {
+	defer in.outlet.Close()
+	in.Lock()
+	defer in.Unlock()
+
+	if in.started {
+		in.log.Info("Stopping http_endpoint input")
+		close(in.done)
+		in.server.Close()
+		in.wg.Wait()
+		in.started = false
+	}
+}
+
+// Wait stops the input.
+func (in *Input) Wait() {
+	in.Stop()
+}
+
+func (in *Input) listen() (net.Listener, error) {
+	if in.tlsConfig != nil {
+		in.log.Info("Listening over TLS")
+		return tls.Listen("tcp", in.config.Host, in.tlsConfig.BuildModuleConfig(in.config.Host))
+	}
+	return net.Listen("tcp", in.config.Host)
+}
+
+func newBatchACK(n int) *batchACK {
+	b := &batchACK{done: make(chan struct{})}
+	b.pending.Store(n)
+	if n == 0 {
+		close(b.done)
+	}
+	return b
+}
+
+func (b *batchACK) ack() {
+	if b.pending.Dec() == 0 {
+		close(b.done)
+	}
+}
+
+func onACK(privates []interface{}) {
+	for _, private := range privates {
+		if b, ok := private.(*batchACK); ok {
+			b.ack()
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http_endpoint
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// testOutlet collects events and ACKs them once release is called.
+type testOutlet struct {
+	sync.Mutex
+	events []beat.Event
+	ack    func([]interface{})
+	done   chan struct{}
+}
+
+func (o *testOutlet) Close() error          { return nil }
+func (o *testOutlet) Done() <-chan struct{} { return o.done }
+func (o *testOutlet) OnEvent(d *util.Data) bool {
+	o.Lock()
+	defer o.Unlock()
+	o.events = append(o.events, d.GetEvent())
+	return true
+}
+
+func (o *testOutlet) count() int {
+	o.Lock()
+	defer o.Unlock()
+	return len(o.events)
+}
+
+func (o *testOutlet) release() {
+	o.Lock()
+	defer o.Unlock()
+	var privates []interface{}
+	for _, e := range o.events {
+		privates = append(privates, e.Private)
+	}
+	o.ack(privates)
+}
+
+func newTestInput(t *testing.T, settings common.MapStr) (*Input, *testOutlet) {
+	out := &testOutlet{done: make(chan struct{})}
+	connector := channel.ConnectorFunc(func(_ *common.Config, clientCfg beat.ClientConfig) (channel.Outleter, error) {
+		out.ack = clientCfg.ACKEvents
+		return out, nil
+	})
+
+	in, err := NewInput(common.MustNewConfigFrom(settings), connector, input.Context{})
+	require.NoError(t, err)
+	return in.(*Input), out
+}
+
+func post(in *Input, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	in.ServeHTTP(w, req)
+	return w
+}
+
+func TestRespondAfterACK(t *testing.T) {
+	in, out := newTestInput(t, common.MapStr{})
+
+	resp := make(chan *httptest.ResponseRecorder)
+	go func() {
+		resp <- post(in, "{\"a\":1}\n[{\"a\":2},{\"a\":3}]\n", nil)
+	}()
+
+	for out.count() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-resp:
+		t.Fatal("request answered before events have been ACKed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	out.release()
+	w := <-resp
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"message": "success"}`, w.Body.String())
+
+	for i, e := range out.events {
+		v, err := e.Fields.GetValue("json.a")
+		require.NoError(t, err)
+		assert.Equal(t, float64(i+1), v)
+	}
+}
+
+func TestRejectRequests(t *testing.T) {
+	in, out := newTestInput(t, common.MapStr{"max_body_size": 16})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	in.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	assert.Equal(t, http.StatusBadRequest, post(in, `{"a":`, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, post(in, `[1, 2]`, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, post(in, ``, nil).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(in, `{"message":"too large"}`, nil).Code)
+	assert.Equal(t, 0, out.count())
+}
+
+func TestBasicAuth(t *testing.T) {
+	in, out := newTestInput(t, common.MapStr{
+		"basic_auth": true,
+		"username":   "beat",
+		"password":   "secret",
+	})
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+	req.SetBasicAuth("beat", "wrong")
+	w := httptest.NewRecorder()
+	in.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, 0, out.count())
+
+	go func() {
+		for out.count() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		out.release()
+	}()
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`{}`))
+	req.SetBasicAuth("beat", "secret")
+	w = httptest.NewRecorder()
+	in.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHMAC(t *testing.T) {
+	in, out := newTestInput(t, common.MapStr{
+		"hmac.header": "X-Hub-Signature",
+		"hmac.key":    "secret",
+		"hmac.prefix": "sha256=",
+	})
+
+	body := `{"action":"opened"}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, http.StatusUnauthorized, post(in, body, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, post(in, body, map[string]string{"X-Hub-Signature": "sha256=00"}).Code)
+	assert.Equal(t, 0, out.count())
+
+	go func() {
+		for out.count() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		out.release()
+	}()
+	assert.Equal(t, http.StatusOK, post(in, body, map[string]string{"X-Hub-Signature": signature}).Code)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := map[string]common.MapStr{
+		"relative url":           {"url": "events"},
+		"basic auth no password": {"basic_auth": true, "username": "beat"},
+		"hmac without key":       {"hmac.header": "X-Signature"},
+		"unknown hmac type":      {"hmac.header": "X-Signature", "hmac.key": "k", "hmac.type": "md5"},
+		"error response code":    {"response_code": 500},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig
+			assert.Error(t, common.MustNewConfigFrom(settings).Unpack(&c))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpjson
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+)
+
+type config struct {
+	harvester.ForwarderConfig `config:",inline"`
+
+	URL             string            `config:"url" validate:"required"`
+	Interval        time.Duration     `config:"interval" validate:"nonzero,positive"`
+	HTTPMethod      string            `config:"http_method"`
+	HTTPHeaders     map[string]string `config:"http_headers"`
+	HTTPRequestBody common.MapStr     `config:"http_request_body"`
+	APIKey          string            `config:"api_key"`
+	JSONObjects     string            `config:"json_objects_array"`
+	Pagination      paginationConfig  `config:"pagination"`
+	Timeout         time.Duration     `config:"timeout" validate:"nonzero,positive"`
+	TLS             *tlscommon.Config `config:"ssl"`
+}
+
+type paginationConfig struct {
+	Type        paginationType `config:"type"`
+	CursorField string         `config:"cursor_field"`
+	Param       string         `config:"param"`
+}
+
+// paginationType configures how the next page of a response is requested.
+type paginationType uint8
+
+const (
+	paginationNone paginationType = iota
+	paginationLinkHeader
+	paginationCursor
+	paginationOffset
+)
+
+var paginationTypes = map[string]paginationType{
+	"none":        paginationNone,
+	"link_header": paginationLinkHeader,
+	"cursor":      paginationCursor,
+	"offset":      paginationOffset,
+}
+
+var defaultConfig = config{
+	ForwarderConfig: harvester.ForwarderConfig{
+		Type: "httpjson",
+	},
+	Interval:   60 * time.Second,
+	HTTPMethod: "GET",
+	Timeout:    30 * time.Second,
+}
+
+func (c *config) Validate() error {
+	switch strings.ToUpper(c.HTTPMethod) {
+	case "GET":
+		if len(c.HTTPRequestBody) > 0 {
+			return fmt.Errorf("http_request_body is not supported with http_method GET")
+		}
+	case "POST":
+	default:
+		return fmt.Errorf("http_method %v is not supported, use GET or POST", c.HTTPMethod)
+	}
+
+	if _, err := url.Parse(c.URL); err != nil {
+		return fmt.Errorf("invalid url '%v': %v", c.URL, err)
+	}
+
+	return nil
+}
+
+func (c *paginationConfig) Validate() error {
+	switch c.Type {
+	case paginationCursor:
+		if c.CursorField == "" || c.Param == "" {
+			return fmt.Errorf("cursor pagination requires cursor_field and param to be set")
+		}
+	case paginationOffset:
+		if c.Param == "" {
+			return fmt.Errorf("offset pagination requires param to be set")
+		}
+	}
+	return nil
+}
+
+func (t *paginationType) Unpack(s string) error {
+	typ, exists := paginationTypes[s]
+	if !exists {
+		return fmt.Errorf("unsupported pagination type '%v'", s)
+	}
+	*t = typ
+	return nil
+}
@@ -0,0 +1,435 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+const (
+	inputName = "httpjson"
+
+	// stateURLKey identifies the registry state of an input by its configured url.
+	stateURLKey = "httpjson_url"
+)
+
+func init() {
+	err := input.Register(inputName, NewInput)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input polls a REST API returning JSON documents and publishes every
+// object of the response as an event.
+type Input struct {
+	sync.Mutex
+	config    config
+	client    *http.Client
+	outlet    channel.Outleter
+	forwarder *harvester.Forwarder
+	state     file.State
+	started   bool
+	done      chan struct{}
+	wg        sync.WaitGroup
+	log       *logp.Logger
+}
+
+// page is a single decoded response of the API.
+type page struct {
+	records []interface{}
+	next    string
+}
+
+// NewInput creates a new httpjson input
+func NewInput(
+	cfg *common.Config,
+	outlet channel.Connector,
+	context input.Context,
+) (input.Input, error) {
+	cfgwarn.Experimental("The httpjson input is experimental")
+
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := outlet.Connect(cfg, context.DynamicFields)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+	if tlsConfig != nil {
+		u, _ := url.Parse(config.URL)
+		transport.TLSClientConfig = tlsConfig.BuildModuleConfig(u.Host)
+	}
+
+	in := &Input{
+		config: config,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+		},
+		outlet:    out,
+		forwarder: harvester.NewForwarder(out),
+		state:     initialState(config.URL, context.States),
+		done:      make(chan struct{}),
+		log:       logp.NewLogger(inputName).With("url", config.URL),
+	}
+	return in, nil
+}
+
+// initialState returns the state to resume polling from. If no state has
+// been persisted for the configured url, polling starts at the url itself.
+func initialState(rawURL string, states []file.State) file.State {
+	for _, state := range states {
+		if state.Type == inputName && state.Meta[stateURLKey] == rawURL {
+			state.TTL = -1
+			return state
+		}
+	}
+
+	return file.State{
+		Source:    rawURL,
+		Type:      inputName,
+		TTL:       -1,
+		Timestamp: time.Now(),
+		Meta:      map[string]string{stateURLKey: rawURL},
+	}
+}
+
+// Run starts polling the API. The input keeps polling in the background
+// until it is stopped, consecutive calls to Run have no effect.
+func (in *Input) Run() {
+	in.Lock()
+	defer in.Unlock()
+
+	if in.started {
+		return
+	}
+
+	in.log.Infow("Starting httpjson input", "resume_url", in.state.Source)
+	in.started = true
+	in.wg.Add(1)
+	go in.run()
+}
+
+// Stop stops polling and closes the outlet.
+func (in *Input) Stop() {
+	defer in.outlet.Close()
+	in.Lock()
+	defer in.Unlock()
+
+	if in.started {
+		in.log.Info("Stopping httpjson input")
+		close(in.done)
+		// closing the outlet unblocks a publish waiting on the pipeline
+		in.outlet.Close()
+		in.wg.Wait()
+		in.started = false
+	}
+}
+
+// Wait stops the input.
+func (in *Input) Wait() {
+	in.Stop()
+}
+
+func (in *Input) run() {
+	defer in.wg.Done()
+
+	for {
+		if err := in.poll(); err != nil {
+			in.log.Errorw("Failed to poll API", "error", err)
+		}
+
+		select {
+		case <-in.done:
+			return
+		case <-time.After(in.config.Interval):
+		}
+	}
+}
+
+// poll requests pages starting at the last known position until no further
+// page is available. Events carry the position to resume from, such that
+// the registry only advances once the events have been ACKed. The position
+// is the url of a page and the number of its records already published,
+// records of a page are not published again if the page is requested
+// another time.
+func (in *Input) poll() error {
+	reqURL, skip := in.state.Source, int(in.state.Offset)
+	for {
+		select {
+		case <-in.done:
+			return nil
+		default:
+		}
+
+		p, err := in.fetch(reqURL)
+		if err != nil {
+			return err
+		}
+
+		hasNext := p.next != "" && len(p.records) > 0
+		resume, resumeOffset := reqURL, int64(len(p.records))
+		if hasNext {
+			resume, resumeOffset = p.next, 0
+		} else if in.config.Pagination.Type == paginationNone {
+			resume, resumeOffset = in.config.URL, 0
+		}
+
+		if skip > len(p.records) {
+			skip = len(p.records)
+		}
+		for i := skip; i < len(p.records); i++ {
+			state := in.state
+			state.Source = reqURL
+			state.Offset = int64(i + 1)
+			if i == len(p.records)-1 {
+				state.Source = resume
+				state.Offset = resumeOffset
+			}
+			state.Timestamp = time.Now()
+
+			if err := in.publish(p.records[i], state); err != nil {
+				return err
+			}
+		}
+
+		in.state.Source = resume
+		in.state.Offset = resumeOffset
+		if !hasNext {
+			return nil
+		}
+		reqURL, skip = p.next, 0
+	}
+}
+
+func (in *Input) publish(record interface{}, state file.State) error {
+	msg, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %v", err)
+	}
+
+	data := util.NewData()
+	data.Event = beat.Event{
+		Timestamp: time.Now(),
+		Fields: common.MapStr{
+			"message": string(msg),
+		},
+	}
+	data.SetState(state)
+	return in.forwarder.Send(data)
+}
+
+// fetch requests a single page and computes the url of the next page
+// according to the pagination settings.
+func (in *Input) fetch(reqURL string) (*page, error) {
+	req, err := in.newRequest(reqURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := in.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %v failed with status %v", reqURL, resp.Status)
+	}
+
+	var body interface{}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	records, err := in.splitRecords(body)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &page{records: records}
+	pagination := in.config.Pagination
+	switch pagination.Type {
+	case paginationLinkHeader:
+		p.next, err = nextLink(req.URL, resp.Header)
+	case paginationCursor:
+		obj, ok := body.(map[string]interface{})
+		if !ok {
+			break
+		}
+		cursor, _ := common.MapStr(obj).GetValue(pagination.CursorField)
+		if cursor != nil && fmt.Sprint(cursor) != "" {
+			p.next, err = setParam(reqURL, pagination.Param, fmt.Sprint(cursor))
+		}
+	case paginationOffset:
+		var offset int64
+		offset, err = getOffset(req.URL, pagination.Param)
+		if err == nil {
+			offset += int64(len(records))
+			p.next, err = setParam(reqURL, pagination.Param, strconv.FormatInt(offset, 10))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (in *Input) newRequest(reqURL string) (*http.Request, error) {
+	var body io.Reader
+	method := strings.ToUpper(in.config.HTTPMethod)
+	if method == "POST" && len(in.config.HTTPRequestBody) > 0 {
+		raw, err := json.Marshal(in.config.HTTPRequestBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %v", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, reqURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if in.config.APIKey != "" {
+		req.Header.Set("Authorization", in.config.APIKey)
+	}
+	for k, v := range in.config.HTTPHeaders {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+// splitRecords returns the list of objects to publish as separate events.
+func (in *Input) splitRecords(body interface{}) ([]interface{}, error) {
+	if in.config.JSONObjects == "" {
+		if arr, ok := body.([]interface{}); ok {
+			return arr, nil
+		}
+		return []interface{}{body}, nil
+	}
+
+	obj, ok := body.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("response is no JSON object, can not read '%v'", in.config.JSONObjects)
+	}
+
+	v, err := common.MapStr(obj).GetValue(in.config.JSONObjects)
+	if err != nil {
+		return nil, nil
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("field '%v' is no JSON array", in.config.JSONObjects)
+	}
+	return arr, nil
+}
+
+// nextLink returns the url of the `rel="next"` entry in the Link headers.
+func nextLink(base *url.URL, header http.Header) (string, error) {
+	for _, h := range header["Link"] {
+		for _, link := range strings.Split(h, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				param = strings.Replace(strings.TrimSpace(param), " ", "", -1)
+				if param != `rel="next"` && param != "rel=next" {
+					continue
+				}
+
+				u, err := base.Parse(target[1 : len(target)-1])
+				if err != nil {
+					return "", fmt.Errorf("invalid next link '%v': %v", target, err)
+				}
+				return u.String(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func setParam(rawURL, param, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set(param, value)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func getOffset(u *url.URL, param string) (int64, error) {
+	v := u.Query().Get(param)
+	if v == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset '%v' in url: %v", v, err)
+	}
+	return offset, nil
+}
@@ -0,0 +1,281 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+type testOutlet struct {
+	sync.Mutex
+	data []*util.Data
+	done chan struct{}
+}
+
+func newTestOutlet() *testOutlet { return &testOutlet{done: make(chan struct{})} }
+
+func (o *testOutlet) Close() error          { return nil }
+func (o *testOutlet) Done() <-chan struct{} { return o.done }
+func (o *testOutlet) OnEvent(d *util.Data) bool {
+	o.Lock()
+	defer o.Unlock()
+	o.data = append(o.data, d)
+	return true
+}
+
+func (o *testOutlet) messages(t *testing.T) []string {
+	o.Lock()
+	defer o.Unlock()
+
+	var msgs []string
+	for _, d := range o.data {
+		msg, err := d.Event.Fields.GetValue("message")
+		require.NoError(t, err)
+		msgs = append(msgs, msg.(string))
+	}
+	return msgs
+}
+
+func (o *testOutlet) lastState() file.State {
+	o.Lock()
+	defer o.Unlock()
+	return o.data[len(o.data)-1].GetState()
+}
+
+func newTestInput(t *testing.T, settings common.MapStr, states []file.State) (*Input, *testOutlet) {
+	cfg := common.MustNewConfigFrom(settings)
+	out := newTestOutlet()
+	connector := channel.ConnectorFunc(func(*common.Config, beat.ClientConfig) (channel.Outleter, error) {
+		return out, nil
+	})
+
+	in, err := NewInput(cfg, connector, input.Context{States: states})
+	require.NoError(t, err)
+	return in.(*Input), out
+}
+
+func record(i int) string {
+	return fmt.Sprintf(`{"id":%d}`, i)
+}
+
+func TestPollLinkHeader(t *testing.T) {
+	var mu sync.Mutex
+	lastPageSize := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		pageNum, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size := 2
+		if pageNum < 2 {
+			w.Header().Add("Link", fmt.Sprintf(`</items?page=%d>; rel="next", </items?page=0>; rel="first"`, pageNum+1))
+		} else {
+			size = lastPageSize
+		}
+		var items []json.RawMessage
+		for i := 0; i < size; i++ {
+			items = append(items, json.RawMessage(record(2*pageNum+i)))
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+	defer server.Close()
+
+	in, out := newTestInput(t, common.MapStr{
+		"url":             server.URL + "/items",
+		"pagination.type": "link_header",
+	}, nil)
+	require.NoError(t, in.poll())
+
+	assert.Equal(t, []string{
+		record(0), record(1), record(2), record(3), record(4), record(5),
+	}, out.messages(t))
+
+	// no next link on the last page, polling resumes after its last record
+	last := server.URL + "/items?page=2"
+	assert.Equal(t, last, out.lastState().Source)
+	assert.Equal(t, int64(2), out.lastState().Offset)
+	assert.Equal(t, last, in.state.Source)
+	assert.Equal(t, server.URL+"/items", out.lastState().Meta[stateURLKey])
+
+	// records of the last page are not published again
+	require.NoError(t, in.poll())
+	assert.Len(t, out.messages(t), 6)
+
+	// only records appended to the last page are published
+	mu.Lock()
+	lastPageSize = 3
+	mu.Unlock()
+	require.NoError(t, in.poll())
+	assert.Equal(t, record(6), out.messages(t)[6])
+	assert.Len(t, out.messages(t), 7)
+	assert.Equal(t, int64(3), out.lastState().Offset)
+}
+
+func TestPollResumeWithinPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"events":[%v,%v,%v]}`, record(0), record(1), record(2))
+	}))
+	defer server.Close()
+
+	// the first record has been published before the restart
+	in, out := newTestInput(t, common.MapStr{
+		"url":                     server.URL,
+		"json_objects_array":      "events",
+		"pagination.type":         "cursor",
+		"pagination.cursor_field": "next",
+		"pagination.param":        "cursor",
+	}, []file.State{{
+		Type:   "httpjson",
+		Source: server.URL,
+		Offset: 1,
+		Meta:   map[string]string{stateURLKey: server.URL},
+		TTL:    -2,
+	}})
+	require.NoError(t, in.poll())
+
+	assert.Equal(t, []string{record(1), record(2)}, out.messages(t))
+	assert.Equal(t, int64(3), out.lastState().Offset)
+}
+
+func TestPollOffsetResume(t *testing.T) {
+	total := 5
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("from"))
+		var items []json.RawMessage
+		for i := offset; i < total && i < offset+2; i++ {
+			items = append(items, json.RawMessage(record(i)))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"items": items},
+		})
+	}))
+	defer server.Close()
+
+	base := server.URL + "/items"
+	in, out := newTestInput(t, common.MapStr{
+		"url":                base,
+		"json_objects_array": "data.items",
+		"pagination.type":    "offset",
+		"pagination.param":   "from",
+	}, []file.State{{
+		Type:   "httpjson",
+		Source: base + "?from=2",
+		Meta:   map[string]string{stateURLKey: base},
+		TTL:    -2,
+	}})
+	require.NoError(t, in.poll())
+
+	assert.Equal(t, []string{"from=2", "from=4", "from=5"}, requests)
+	assert.Equal(t, []string{record(2), record(3), record(4)}, out.messages(t))
+	assert.Equal(t, base+"?from=5", out.lastState().Source)
+	assert.Equal(t, time.Duration(-1), out.lastState().TTL)
+
+	// empty page does not advance the position
+	require.NoError(t, in.poll())
+	assert.Equal(t, base+"?from=5", in.state.Source)
+	assert.Len(t, out.messages(t), 3)
+}
+
+func TestPollCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "value", r.Header.Get("X-Custom"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "logs", body["query"])
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"events":[{"a":1},{"a":2}],"meta":{"next":"abc"}}`)
+		case "abc":
+			fmt.Fprint(w, `{"events":[{"a":3}],"meta":{"next":null}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	in, out := newTestInput(t, common.MapStr{
+		"url":                     server.URL,
+		"http_method":             "POST",
+		"http_request_body.query": "logs",
+		"http_headers.X-Custom":   "value",
+		"api_key":                 "secret",
+		"json_objects_array":      "events",
+		"pagination.type":         "cursor",
+		"pagination.cursor_field": "meta.next",
+		"pagination.param":        "cursor",
+	}, nil)
+	require.NoError(t, in.poll())
+
+	assert.Equal(t, []string{`{"a":1}`, `{"a":2}`, `{"a":3}`}, out.messages(t))
+	assert.Equal(t, server.URL+"?cursor=abc", out.lastState().Source)
+}
+
+func TestPollErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	in, out := newTestInput(t, common.MapStr{"url": server.URL}, nil)
+	assert.Error(t, in.poll())
+	assert.Empty(t, out.messages(t))
+	assert.Equal(t, server.URL, in.state.Source)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := map[string]common.MapStr{
+		"missing url":          {},
+		"unsupported method":   {"url": "http://localhost", "http_method": "PUT"},
+		"body with GET":        {"url": "http://localhost", "http_request_body.a": 1},
+		"unknown pagination":   {"url": "http://localhost", "pagination.type": "pages"},
+		"cursor without field": {"url": "http://localhost", "pagination.type": "cursor", "pagination.param": "c"},
+		"offset without param": {"url": "http://localhost", "pagination.type": "offset"},
+		"zero interval":        {"url": "http://localhost", "interval": 0},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig
+			err := common.MustNewConfigFrom(settings).Unpack(&c)
+			assert.Error(t, err)
+		})
+	}
+}
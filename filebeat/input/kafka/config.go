@@ -0,0 +1,230 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/libbeat/common/kafka"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/monitoring/adapter"
+	"github.com/elastic/beats/libbeat/reader/readjson"
+)
+
+type kafkaInputConfig struct {
+	harvester.ForwarderConfig `config:",inline"`
+
+	Hosts          []string          `config:"hosts" validate:"required"`
+	Topics         []string          `config:"topics" validate:"required"`
+	GroupID        string            `config:"group_id" validate:"required"`
+	ClientID       string            `config:"client_id"`
+	Version        kafka.Version     `config:"version"`
+	InitialOffset  initialOffset     `config:"initial_offset"`
+	ConnectBackoff time.Duration     `config:"connect_backoff" validate:"min=0"`
+	ConsumeBackoff time.Duration     `config:"consume_backoff" validate:"min=0"`
+	WaitClose      time.Duration     `config:"wait_close" validate:"min=0"`
+	MaxWaitTime    time.Duration     `config:"max_wait_time" validate:"min=1"`
+	Fetch          fetchConfig       `config:"fetch"`
+	Rebalance      rebalanceConfig   `config:"rebalance"`
+	TLS            *tlscommon.Config `config:"ssl"`
+	Username       string            `config:"username"`
+	Password       string            `config:"password"`
+	JSON           *readjson.Config  `config:"json"`
+}
+
+type fetchConfig struct {
+	Min     int32 `config:"min" validate:"min=1"`
+	Default int32 `config:"default" validate:"min=1"`
+	Max     int32 `config:"max" validate:"min=0"`
+}
+
+type rebalanceConfig struct {
+	Strategy     rebalanceStrategy `config:"strategy"`
+	Timeout      time.Duration     `config:"timeout" validate:"min=1"`
+	MaxRetries   int               `config:"max_retries" validate:"min=0"`
+	RetryBackoff time.Duration     `config:"retry_backoff" validate:"min=0"`
+}
+
+type initialOffset int
+
+const (
+	initialOffsetOldest initialOffset = iota
+	initialOffsetNewest
+)
+
+var initialOffsets = map[string]initialOffset{
+	"oldest": initialOffsetOldest,
+	"newest": initialOffsetNewest,
+}
+
+type rebalanceStrategy int
+
+const (
+	rebalanceStrategyRange rebalanceStrategy = iota
+	rebalanceStrategyRoundRobin
+)
+
+var rebalanceStrategies = map[string]rebalanceStrategy{
+	"range":      rebalanceStrategyRange,
+	"roundrobin": rebalanceStrategyRoundRobin,
+}
+
+func defaultConfig() kafkaInputConfig {
+	return kafkaInputConfig{
+		ForwarderConfig: harvester.ForwarderConfig{
+			Type: "kafka",
+		},
+		ClientID:       "filebeat",
+		Version:        kafka.Version("1.0.0"),
+		InitialOffset:  initialOffsetOldest,
+		ConnectBackoff: 30 * time.Second,
+		ConsumeBackoff: 2 * time.Second,
+		WaitClose:      2 * time.Second,
+		MaxWaitTime:    250 * time.Millisecond,
+		Fetch: fetchConfig{
+			Min:     1,
+			Default: 1024 * 1024,
+			Max:     0,
+		},
+		Rebalance: rebalanceConfig{
+			Strategy:     rebalanceStrategyRange,
+			Timeout:      60 * time.Second,
+			MaxRetries:   4,
+			RetryBackoff: 2 * time.Second,
+		},
+	}
+}
+
+// Validate validates the kafka input configuration.
+func (c *kafkaInputConfig) Validate() error {
+	if len(c.Hosts) == 0 {
+		return errors.New("no hosts configured")
+	}
+
+	if len(c.Topics) == 0 {
+		return errors.New("no topics configured")
+	}
+
+	if err := c.Version.Validate(); err != nil {
+		return err
+	}
+
+	version, _ := c.Version.Get()
+	if !version.IsAtLeast(sarama.V0_10_2_0) {
+		return fmt.Errorf("consumer groups require kafka version 0.10.2.0 or newer, configured version is %v", c.Version)
+	}
+
+	if c.Username != "" && c.Password == "" {
+		return fmt.Errorf("password must be set when username is configured")
+	}
+
+	return nil
+}
+
+func newSaramaConfig(config kafkaInputConfig) (*sarama.Config, error) {
+	k := sarama.NewConfig()
+
+	version, ok := config.Version.Get()
+	if !ok {
+		return nil, fmt.Errorf("unknown/unsupported kafka version: %v", config.Version)
+	}
+	k.Version = version
+
+	k.Consumer.Return.Errors = true
+	k.Consumer.Offsets.Initial = config.InitialOffset.asSaramaOffset()
+	k.Consumer.Retry.Backoff = config.ConsumeBackoff
+	k.Consumer.MaxWaitTime = config.MaxWaitTime
+
+	k.Consumer.Fetch.Min = config.Fetch.Min
+	k.Consumer.Fetch.Default = config.Fetch.Default
+	k.Consumer.Fetch.Max = config.Fetch.Max
+
+	k.Consumer.Group.Rebalance.Strategy = config.Rebalance.Strategy.asSaramaStrategy()
+	k.Consumer.Group.Rebalance.Timeout = config.Rebalance.Timeout
+	k.Consumer.Group.Rebalance.Retry.Backoff = config.Rebalance.RetryBackoff
+	k.Consumer.Group.Rebalance.Retry.Max = config.Rebalance.MaxRetries
+
+	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	if tls != nil {
+		k.Net.TLS.Enable = true
+		k.Net.TLS.Config = tls.BuildModuleConfig("")
+	}
+
+	if config.Username != "" {
+		k.Net.SASL.Enable = true
+		k.Net.SASL.User = config.Username
+		k.Net.SASL.Password = config.Password
+	}
+
+	k.ClientID = config.ClientID
+	k.MetricRegistry = adapter.GetGoMetrics(
+		monitoring.Default,
+		"filebeat.inputs.kafka",
+		adapter.Rename("incoming-byte-rate", "bytes_read"),
+		adapter.Rename("outgoing-byte-rate", "bytes_write"),
+		adapter.GoMetricsNilify,
+	)
+
+	if err := k.Validate(); err != nil {
+		logp.Err("Invalid kafka configuration: %v", err)
+		return nil, err
+	}
+	return k, nil
+}
+
+func (off initialOffset) asSaramaOffset() int64 {
+	if off == initialOffsetNewest {
+		return sarama.OffsetNewest
+	}
+	return sarama.OffsetOldest
+}
+
+func (off *initialOffset) Unpack(value string) error {
+	v, ok := initialOffsets[value]
+	if !ok {
+		return fmt.Errorf("invalid initial_offset '%s'", value)
+	}
+	*off = v
+	return nil
+}
+
+func (st rebalanceStrategy) asSaramaStrategy() sarama.BalanceStrategy {
+	if st == rebalanceStrategyRoundRobin {
+		return sarama.BalanceStrategyRoundRobin
+	}
+	return sarama.BalanceStrategyRange
+}
+
+func (st *rebalanceStrategy) Unpack(value string) error {
+	v, ok := rebalanceStrategies[value]
+	if !ok {
+		return fmt.Errorf("invalid rebalance strategy '%s'", value)
+	}
+	*st = v
+	return nil
+}
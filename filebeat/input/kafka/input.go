@@ -0,0 +1,330 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/reader"
+	"github.com/elastic/beats/libbeat/reader/readjson"
+)
+
+func init() {
+	err := input.Register("kafka", NewInput)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input joins a kafka consumer group and publishes the consumed messages.
+// Offsets are marked for commit once the pipeline has ACKed the events.
+type Input struct {
+	sync.Mutex
+	config       kafkaInputConfig
+	saramaConfig *sarama.Config
+	outlet       channel.Outleter
+	forwarder    *harvester.Forwarder
+	started      bool
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	log          *logp.Logger
+}
+
+// groupHandler implements sarama.ConsumerGroupHandler.
+type groupHandler struct {
+	config    *kafkaInputConfig
+	forwarder *harvester.Forwarder
+	log       *logp.Logger
+}
+
+// eventMeta is stored in the events private field and used for marking the
+// message as consumed once the event has been ACKed.
+type eventMeta struct {
+	session sarama.ConsumerGroupSession
+	message *sarama.ConsumerMessage
+}
+
+// NewInput creates a new kafka input
+func NewInput(
+	cfg *common.Config,
+	connector channel.Connector,
+	context input.Context,
+) (input.Input, error) {
+	cfgwarn.Experimental("The kafka input is experimental")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	saramaConfig, err := newSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := connector.ConnectWith(cfg, beat.ClientConfig{
+		Processing: beat.ProcessingConfig{
+			DynamicFields: context.DynamicFields,
+		},
+		ACKEvents: onACK,
+		WaitClose: config.WaitClose,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Input{
+		config:       config,
+		saramaConfig: saramaConfig,
+		outlet:       out,
+		forwarder:    harvester.NewForwarder(out),
+		log:          logp.NewLogger("kafka input").With("hosts", config.Hosts),
+	}, nil
+}
+
+// Run starts consuming. Consecutive calls to Run have no effect once the
+// input has been started.
+func (p *Input) Run() {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.started {
+		return
+	}
+
+	p.log.Infow("Starting kafka input", "topics", p.config.Topics, "group_id", p.config.GroupID)
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.started = true
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.run()
+	}()
+}
+
+// Stop stops consuming and leaves the consumer group.
+func (p *Input) Stop() {
+	defer p.outlet.Close()
+	p.Lock()
+	defer p.Unlock()
+
+	if p.started {
+		p.log.Info("Stopping kafka input")
+		p.cancel()
+		// closing the outlet unblocks a publish waiting on the pipeline
+		p.outlet.Close()
+		p.wg.Wait()
+		p.started = false
+	}
+}
+
+// Wait stops the input.
+func (p *Input) Wait() {
+	p.Stop()
+}
+
+// run (re-)connects to the consumer group until the input is stopped.
+func (p *Input) run() {
+	handler := &groupHandler{
+		config:    &p.config,
+		forwarder: p.forwarder,
+		log:       p.log,
+	}
+
+	for p.ctx.Err() == nil {
+		group, err := sarama.NewConsumerGroup(p.config.Hosts, p.config.GroupID, p.saramaConfig)
+		if err != nil {
+			p.log.Errorw("Failed to connect to kafka", "error", err)
+			p.backoff(p.config.ConnectBackoff)
+			continue
+		}
+
+		p.consume(group, handler)
+		if err := group.Close(); err != nil {
+			p.log.Errorw("Failed to close consumer group", "error", err)
+		}
+	}
+}
+
+// consume joins the consumer group. A new session is created after every
+// rebalance of the group.
+func (p *Input) consume(group sarama.ConsumerGroup, handler *groupHandler) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case err, ok := <-group.Errors():
+				if !ok {
+					return
+				}
+				p.log.Errorw("Kafka consumer error", "error", err)
+			}
+		}
+	}()
+
+	for p.ctx.Err() == nil {
+		if err := group.Consume(p.ctx, p.config.Topics, handler); err != nil {
+			if err == sarama.ErrClosedConsumerGroup {
+				return
+			}
+			p.log.Errorw("Consumer group session failed", "error", err)
+			p.backoff(p.config.ConnectBackoff)
+		}
+	}
+}
+
+func (p *Input) backoff(d time.Duration) {
+	select {
+	case <-p.ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// Setup is run at the beginning of a new session.
+func (h *groupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.log.Debugw("Joined consumer group", "member_id", session.MemberID(), "claims", session.Claims())
+	return nil
+}
+
+// Cleanup is run at the end of a session, once all ConsumeClaim goroutines
+// have exited.
+func (h *groupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	h.log.Debugw("Leaving consumer group session", "member_id", session.MemberID())
+	return nil
+}
+
+// ConsumeClaim publishes the messages of a single partition.
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		data := h.createEvent(session, msg)
+		if err := h.forwarder.Send(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *groupHandler) createEvent(session sarama.ConsumerGroupSession, msg *sarama.ConsumerMessage) *util.Data {
+	ts := msg.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	kafkaMeta := common.MapStr{
+		"topic":     msg.Topic,
+		"partition": msg.Partition,
+		"offset":    msg.Offset,
+	}
+	if len(msg.Key) > 0 {
+		kafkaMeta["key"] = string(msg.Key)
+	}
+	if len(msg.Headers) > 0 {
+		headers := make([]string, 0, len(msg.Headers))
+		for _, h := range msg.Headers {
+			if h != nil {
+				headers = append(headers, string(h.Key)+": "+string(h.Value))
+			}
+		}
+		kafkaMeta["headers"] = headers
+	}
+
+	fields := common.MapStr{}
+	event := beat.Event{
+		Timestamp: ts,
+		Meta:      common.MapStr{"kafka": kafkaMeta},
+		Private: eventMeta{
+			session: session,
+			message: msg,
+		},
+	}
+
+	if h.config.JSON == nil {
+		fields["message"] = string(msg.Value)
+	} else {
+		message, _ := readjson.NewJSONReader(&messageReader{msg: msg}, h.config.JSON).Next()
+		fields.DeepUpdate(message.Fields)
+		text := string(message.Content)
+
+		var jsonFields common.MapStr
+		if f, ok := fields["json"]; ok {
+			jsonFields, _ = f.(common.MapStr)
+		}
+
+		if len(jsonFields) > 0 {
+			if jsonTs := readjson.MergeJSONFields(fields, jsonFields, &text, *h.config.JSON); !jsonTs.IsZero() {
+				event.Timestamp = jsonTs
+			}
+		} else {
+			fields["message"] = text
+		}
+	}
+
+	event.Fields = fields
+
+	data := util.NewData()
+	data.Event = event
+	return data
+}
+
+// onACK marks the messages of ACKed events as consumed. The offsets are
+// committed by the consumer group periodically.
+func onACK(privates []interface{}) {
+	for _, private := range privates {
+		if meta, ok := private.(eventMeta); ok {
+			meta.session.MarkMessage(meta.message, "")
+		}
+	}
+}
+
+// messageReader is a reader.Reader returning the value of a single message,
+// used for decoding the value with the JSON reader.
+type messageReader struct {
+	msg  *sarama.ConsumerMessage
+	done bool
+}
+
+func (r *messageReader) Next() (reader.Message, error) {
+	if r.done {
+		return reader.Message{}, io.EOF
+	}
+	r.done = true
+
+	return reader.Message{
+		Ts:      r.msg.Timestamp,
+		Content: []byte(strings.TrimSpace(string(r.msg.Value))),
+		Bytes:   len(r.msg.Value),
+	}, nil
+}
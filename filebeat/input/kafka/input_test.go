@@ -0,0 +1,213 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/channel"
+	"github.com/elastic/beats/filebeat/harvester"
+	"github.com/elastic/beats/filebeat/input"
+	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+type testSession struct {
+	sync.Mutex
+	marked []int64
+}
+
+func (s *testSession) Claims() map[string][]int32                                              { return nil }
+func (s *testSession) MemberID() string                                                        { return "test" }
+func (s *testSession) GenerationID() int32                                                     { return 1 }
+func (s *testSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {}
+func (s *testSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {
+}
+func (s *testSession) Context() context.Context { return context.Background() }
+func (s *testSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.Lock()
+	defer s.Unlock()
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type testClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testClaim) Topic() string                            { return "logs" }
+func (c *testClaim) Partition() int32                         { return 0 }
+func (c *testClaim) InitialOffset() int64                     { return 0 }
+func (c *testClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *testClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+type testOutlet struct {
+	sync.Mutex
+	events []beat.Event
+}
+
+func (o *testOutlet) Close() error          { return nil }
+func (o *testOutlet) Done() <-chan struct{} { return nil }
+func (o *testOutlet) OnEvent(d *util.Data) bool {
+	o.Lock()
+	defer o.Unlock()
+	o.events = append(o.events, d.GetEvent())
+	return true
+}
+
+func newTestHandler(t *testing.T, settings common.MapStr) (*groupHandler, *testOutlet) {
+	config := defaultConfig()
+	require.NoError(t, common.MustNewConfigFrom(settings).Unpack(&config))
+
+	out := &testOutlet{}
+	return &groupHandler{
+		config:    &config,
+		forwarder: harvester.NewForwarder(out),
+		log:       logp.NewLogger("kafka test"),
+	}, out
+}
+
+var testSettings = common.MapStr{
+	"hosts":    []string{"localhost:9092"},
+	"topics":   []string{"logs"},
+	"group_id": "filebeat",
+}
+
+func TestConsumeClaimMarksOffsetsOnACK(t *testing.T) {
+	h, out := newTestHandler(t, testSettings)
+	session := &testSession{}
+	claim := &testClaim{messages: make(chan *sarama.ConsumerMessage, 3)}
+
+	ts := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+	for i := int64(0); i < 3; i++ {
+		claim.messages <- &sarama.ConsumerMessage{
+			Topic:     "logs",
+			Partition: 2,
+			Offset:    10 + i,
+			Key:       []byte("key"),
+			Value:     []byte("hello"),
+			Timestamp: ts,
+			Headers:   []*sarama.RecordHeader{{Key: []byte("origin"), Value: []byte("app")}},
+		}
+	}
+	close(claim.messages)
+
+	require.NoError(t, h.ConsumeClaim(session, claim))
+	require.Len(t, out.events, 3)
+
+	event := out.events[0]
+	assert.Equal(t, ts, event.Timestamp)
+	assert.Equal(t, common.MapStr{"message": "hello"}, event.Fields)
+	assert.Equal(t, common.MapStr{
+		"kafka": common.MapStr{
+			"topic":     "logs",
+			"partition": int32(2),
+			"offset":    int64(10),
+			"key":       "key",
+			"headers":   []string{"origin: app"},
+		},
+	}, event.Meta)
+	assert.Empty(t, session.marked, "no offsets must be marked before ACK")
+
+	var privates []interface{}
+	for _, e := range out.events[:2] {
+		privates = append(privates, e.Private)
+	}
+	onACK(privates)
+	assert.Equal(t, []int64{10, 11}, session.marked)
+}
+
+func TestDecodeJSON(t *testing.T) {
+	settings := testSettings.Clone()
+	settings["json.keys_under_root"] = true
+	settings["json.add_error_key"] = true
+	h, _ := newTestHandler(t, settings)
+	session := &testSession{}
+
+	data := h.createEvent(session, &sarama.ConsumerMessage{
+		Topic: "logs",
+		Value: []byte(`{"level":"info","msg":"started"}`),
+	})
+	fields := data.GetEvent().Fields
+	assert.Equal(t, "info", fields["level"])
+	assert.Equal(t, "started", fields["msg"])
+	assert.NotContains(t, fields, "message")
+
+	// decoded objects can not overwrite the message metadata
+	data = h.createEvent(session, &sarama.ConsumerMessage{
+		Topic:  "logs",
+		Offset: 7,
+		Value:  []byte(`{"kafka":{"topic":"other","offset":1}}`),
+	})
+	event := data.GetEvent()
+	assert.Equal(t, common.MapStr{
+		"topic":     "logs",
+		"partition": int32(0),
+		"offset":    int64(7),
+	}, event.Meta["kafka"])
+	topic, err := event.GetValue("kafka.topic")
+	require.NoError(t, err)
+	assert.Equal(t, "other", topic)
+
+	data = h.createEvent(session, &sarama.ConsumerMessage{
+		Topic: "logs",
+		Value: []byte(`not json`),
+	})
+	fields = data.GetEvent().Fields
+	assert.Equal(t, "not json", fields["message"])
+	assert.Contains(t, fields, "error")
+}
+
+func TestNewInput(t *testing.T) {
+	var clientCfg beat.ClientConfig
+	connector := channel.ConnectorFunc(func(_ *common.Config, cfg beat.ClientConfig) (channel.Outleter, error) {
+		clientCfg = cfg
+		return &testOutlet{}, nil
+	})
+
+	_, err := NewInput(common.MustNewConfigFrom(testSettings), connector, input.Context{})
+	require.NoError(t, err)
+	assert.NotNil(t, clientCfg.ACKEvents)
+	assert.Equal(t, 2*time.Second, clientCfg.WaitClose)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := map[string]common.MapStr{
+		"no topics":        {"hosts": []string{"localhost:9092"}, "group_id": "fb"},
+		"no group":         {"hosts": []string{"localhost:9092"}, "topics": []string{"logs"}},
+		"old version":      {"hosts": []string{"localhost:9092"}, "topics": []string{"logs"}, "group_id": "fb", "version": "0.9"},
+		"unknown offset":   {"hosts": []string{"localhost:9092"}, "topics": []string{"logs"}, "group_id": "fb", "initial_offset": "latest"},
+		"unknown strategy": {"hosts": []string{"localhost:9092"}, "topics": []string{"logs"}, "group_id": "fb", "rebalance.strategy": "sticky"},
+		"username only":    {"hosts": []string{"localhost:9092"}, "topics": []string{"logs"}, "group_id": "fb", "username": "beat"},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig()
+			assert.Error(t, common.MustNewConfigFrom(settings).Unpack(&c))
+		})
+	}
+}
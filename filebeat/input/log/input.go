@@ -91,7 +91,7 @@ func NewInput(
 	//  The outlet generated here is the underlying outlet, only closed
 	//  once all workers have been shut down.
 	//  For state updates and events, separate sub-outlets will be used.
-	out, err := outlet(cfg, context.DynamicFields)
+	out, err := outlet.Connect(cfg, context.DynamicFields)
 	if err != nil {
 		return nil, err
 	}
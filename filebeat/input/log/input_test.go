@@ -33,6 +33,7 @@ import (
 	"github.com/elastic/beats/filebeat/input"
 	"github.com/elastic/beats/filebeat/input/file"
 	"github.com/elastic/beats/filebeat/util"
+	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/common/match"
 	"github.com/elastic/beats/libbeat/tests/resources"
@@ -151,9 +152,9 @@ func testInputLifecycle(t *testing.T, context input.Context, closer func(input.C
 	defer close(events)
 	capturer := NewEventCapturer(events)
 	defer capturer.Close()
-	connector := func(*common.Config, *common.MapStrPointer) (channel.Outleter, error) {
+	connector := channel.ConnectorFunc(func(*common.Config, beat.ClientConfig) (channel.Outleter, error) {
 		return channel.SubOutlet(capturer), nil
-	}
+	})
 
 	input, err := NewInput(config, connector, context)
 	if err != nil {
@@ -192,9 +193,9 @@ func TestNewInputDone(t *testing.T) {
 		"paths": path.Join(os.TempDir(), "logs", "*.log"),
 	})
 
-	connector := func(*common.Config, *common.MapStrPointer) (channel.Outleter, error) {
+	connector := channel.ConnectorFunc(func(*common.Config, beat.ClientConfig) (channel.Outleter, error) {
 		return TestOutlet{}, nil
-	}
+	})
 
 	context := input.Context{
 		Done: make(chan struct{}),
@@ -212,9 +213,9 @@ func TestNewInputError(t *testing.T) {
 
 	config := common.NewConfig()
 
-	connector := func(*common.Config, *common.MapStrPointer) (channel.Outleter, error) {
+	connector := channel.ConnectorFunc(func(*common.Config, beat.ClientConfig) (channel.Outleter, error) {
 		return TestOutlet{}, nil
-	}
+	})
 
 	context := input.Context{}
 
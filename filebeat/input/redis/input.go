@@ -58,7 +58,7 @@ func NewInput(cfg *common.Config, outletFactory channel.Connector, context input
 		return nil, err
 	}
 
-	outlet, err := outletFactory(cfg, context.DynamicFields)
+	outlet, err := outletFactory.Connect(cfg, context.DynamicFields)
 	if err != nil {
 		return nil, err
 	}
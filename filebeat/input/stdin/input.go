@@ -48,7 +48,7 @@ type Input struct {
 // NewInput creates a new stdin input
 // This input contains one harvester which is reading from stdin
 func NewInput(cfg *common.Config, outlet channel.Connector, context input.Context) (input.Input, error) {
-	out, err := outlet(cfg, context.DynamicFields)
+	out, err := outlet.Connect(cfg, context.DynamicFields)
 	if err != nil {
 		return nil, err
 	}
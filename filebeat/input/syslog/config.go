@@ -33,17 +33,20 @@ import (
 type config struct {
 	harvester.ForwarderConfig `config:",inline"`
 	Protocol                  common.ConfigNamespace `config:"protocol"`
+	Format                    Format                 `config:"format"`
 }
 
 var defaultConfig = config{
 	ForwarderConfig: harvester.ForwarderConfig{
 		Type: "syslog",
 	},
+	Format: FormatAuto,
 }
 
 type syslogTCP struct {
 	tcp.Config    `config:",inline"`
-	LineDelimiter string `config:"line_delimiter" validate:"nonzero"`
+	LineDelimiter string      `config:"line_delimiter" validate:"nonzero"`
+	Framing       tcp.Framing `config:"framing"`
 }
 
 var defaultTCP = syslogTCP{
@@ -72,7 +75,7 @@ func factory(
 			return nil, err
 		}
 
-		splitFunc := tcp.SplitFunc([]byte(config.LineDelimiter))
+		splitFunc := tcp.FramingSplitFunc(config.Framing, []byte(config.LineDelimiter))
 		if splitFunc == nil {
 			return nil, fmt.Errorf("error creating splitFunc from delimiter %s", config.LineDelimiter)
 		}
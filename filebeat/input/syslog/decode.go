@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// Decode parses a syslog message in the given format and returns its
+// timestamp and the ECS fields describing it. Timestamps without timezone
+// are interpreted in the given timezone.
+func Decode(data []byte, format Format, timezone *time.Location) (time.Time, common.MapStr, error) {
+	ev, err := parse(data, format)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	fields := common.MapStr{
+		"message": strings.TrimRight(ev.Message(), "\n"),
+	}
+
+	if ev.Hostname() != "" {
+		fields.Put("host.hostname", ev.Hostname())
+	}
+	if ev.Program() != "" {
+		fields.Put("process.name", ev.Program())
+	}
+	if ev.HasPid() {
+		fields.Put("process.pid", ev.Pid())
+	}
+
+	syslog := common.MapStr{}
+	if ev.HasPriority() {
+		syslog["priority"] = ev.Priority()
+
+		severity := common.MapStr{"code": ev.Severity()}
+		if name, err := mapValueToName(ev.Severity(), severityLabels); err == nil {
+			severity["name"] = name
+		}
+		syslog["severity"] = severity
+
+		facility := common.MapStr{"code": ev.Facility()}
+		if name, err := mapValueToName(ev.Facility(), facilityLabels); err == nil {
+			facility["name"] = name
+		}
+		syslog["facility"] = facility
+	}
+	if ev.Version() != -1 {
+		syslog["version"] = ev.Version()
+	}
+	if ev.ProcID() != "" {
+		syslog["procid"] = ev.ProcID()
+	}
+	if ev.MsgID() != "" {
+		syslog["msgid"] = ev.MsgID()
+	}
+	if sd := ev.StructuredData(); len(sd) > 0 {
+		syslog["structured_data"] = structuredDataFields(sd)
+	}
+	if len(syslog) > 0 {
+		fields.Put("log.syslog", syslog)
+	}
+
+	if ev.Sequence() != -1 {
+		fields.Put("event.sequence", ev.Sequence())
+	}
+
+	return ev.Timestamp(timezone), fields, nil
+}
+
+// structuredDataFields converts RFC5424 structured data to fields, with the
+// parameters of each SD-ELEMENT under its SD-ID.
+func structuredDataFields(sd map[string]map[string]string) common.MapStr {
+	fields := make(common.MapStr, len(sd))
+	for id, params := range sd {
+		p := make(common.MapStr, len(params))
+		for name, value := range params {
+			p[name] = value
+		}
+		fields[id] = p
+	}
+	return fields
+}
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		title  string
+		format Format
+		log    string
+		time   time.Time
+		fields common.MapStr
+	}{
+		{
+			title:  "RFC3164",
+			format: FormatAuto,
+			log:    "<34>Oct 11 22:14:15 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8\n",
+			time:   time.Date(time.Now().Year(), 10, 11, 22, 14, 15, 0, time.UTC),
+			fields: common.MapStr{
+				"message": "'su root' failed for lonvick on /dev/pts/8",
+				"host":    common.MapStr{"hostname": "mymachine"},
+				"process": common.MapStr{"name": "su", "pid": 230},
+				"log": common.MapStr{
+					"syslog": common.MapStr{
+						"priority": 34,
+						"severity": common.MapStr{"code": 2, "name": "Critical"},
+						"facility": common.MapStr{"code": 4, "name": "security/authorization"},
+					},
+				},
+			},
+		},
+		{
+			title:  "RFC3164 without priority",
+			format: FormatRFC3164,
+			log:    "Feb 8 18:55:31.306 mymachine sshd: session opened",
+			time:   time.Date(time.Now().Year(), 2, 8, 18, 55, 31, 306000000, time.UTC),
+			fields: common.MapStr{
+				"message": "session opened",
+				"host":    common.MapStr{"hostname": "mymachine"},
+				"process": common.MapStr{"name": "sshd"},
+			},
+		},
+		{
+			title:  "RFC5424",
+			format: FormatAuto,
+			log:    `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog worker ID47 [exampleSDID@32473 iut="3" eventSource="Application"] An application event log entry...`,
+			time:   time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+			fields: common.MapStr{
+				"message": "An application event log entry...",
+				"host":    common.MapStr{"hostname": "mymachine.example.com"},
+				"process": common.MapStr{"name": "evntslog"},
+				"log": common.MapStr{
+					"syslog": common.MapStr{
+						"priority": 165,
+						"severity": common.MapStr{"code": 5, "name": "Notice"},
+						"facility": common.MapStr{"code": 20, "name": "local4"},
+						"version":  1,
+						"procid":   "worker",
+						"msgid":    "ID47",
+						"structured_data": common.MapStr{
+							"exampleSDID@32473": common.MapStr{"iut": "3", "eventSource": "Application"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			ts, fields, err := Decode([]byte(test.log), test.format, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, test.time, ts)
+			assert.Equal(t, test.fields, fields)
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for format, log := range map[Format]string{
+		FormatAuto:    "not a syslog message",
+		FormatRFC3164: "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - msg",
+		FormatRFC5424: "<34>Oct 11 22:14:15 mymachine su: 'su root' failed",
+	} {
+		_, _, err := Decode([]byte(log), format, time.UTC)
+		assert.Error(t, err, "%v: %v", format, log)
+	}
+}
@@ -72,6 +72,12 @@ type event struct {
 	year       int
 	loc        *time.Location
 	sequence   int
+
+	// RFC5424 header fields.
+	version        int
+	procID         string
+	msgID          string
+	structuredData map[string]map[string]string
 }
 
 // newEvent() return a new event.
@@ -86,6 +92,7 @@ func newEvent() *event {
 		second:   -1,
 		year:     time.Now().Year(),
 		sequence: -1,
+		version:  -1,
 	}
 }
 
@@ -282,6 +289,27 @@ func (s *event) Sequence() int {
 	return s.sequence
 }
 
+// Version returns the RFC5424 protocol version, or -1 for RFC3164 events.
+func (s *event) Version() int {
+	return s.version
+}
+
+// ProcID returns the RFC5424 process ID when it is not a number.
+func (s *event) ProcID() string {
+	return s.procID
+}
+
+// MsgID returns the RFC5424 message type.
+func (s *event) MsgID() string {
+	return s.msgID
+}
+
+// StructuredData returns the RFC5424 structured data, as parameters by
+// SD-ID.
+func (s *event) StructuredData() map[string]map[string]string {
+	return s.structuredData
+}
+
 // SetNanoSecond sets the nanosecond.
 func (s *event) SetNanosecond(b []byte) {
 	// We assume that we receive a byte array representing a nanosecond, this might not be
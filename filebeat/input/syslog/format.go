@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format is the syslog message format.
+type Format uint8
+
+// Supported syslog formats.
+const (
+	// FormatAuto detects the format of each message.
+	FormatAuto Format = iota
+	// FormatRFC3164 is the BSD syslog format, https://tools.ietf.org/html/rfc3164.
+	FormatRFC3164
+	// FormatRFC5424 is the IETF syslog format, https://tools.ietf.org/html/rfc5424.
+	FormatRFC5424
+)
+
+var formatNames = map[Format]string{
+	FormatAuto:    "auto",
+	FormatRFC3164: "rfc3164",
+	FormatRFC5424: "rfc5424",
+}
+
+// Unpack parses the format name from the configuration.
+func (f *Format) Unpack(s string) error {
+	for format, name := range formatNames {
+		if strings.EqualFold(s, name) {
+			*f = format
+			return nil
+		}
+	}
+	return errors.Errorf("invalid syslog format '%v'", s)
+}
+
+func (f Format) String() string {
+	if name, found := formatNames[f]; found {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", uint8(f))
+}
+
+// detectFormat returns FormatRFC5424 when the message starts with a priority
+// followed by a version number, and FormatRFC3164 otherwise.
+func detectFormat(data []byte) Format {
+	if len(data) == 0 || data[0] != '<' {
+		return FormatRFC3164
+	}
+
+	i := 1
+	for i < len(data) && isDigit(data[i]) {
+		i++
+	}
+	if i == 1 || i >= len(data) || data[i] != '>' {
+		return FormatRFC3164
+	}
+
+	i++
+	start := i
+	for i < len(data) && isDigit(data[i]) {
+		i++
+	}
+	if i == start || i >= len(data) || data[i] != ' ' {
+		return FormatRFC3164
+	}
+	return FormatRFC5424
+}
+
+// parse parses data in the given format.
+func parse(data []byte, format Format) (*event, error) {
+	if format == FormatAuto {
+		format = detectFormat(data)
+	}
+
+	ev := newEvent()
+	switch format {
+	case FormatRFC3164:
+		Parse(data, ev)
+		if !ev.IsValid() {
+			return nil, errors.New("invalid RFC3164 syslog message")
+		}
+	case FormatRFC5424:
+		if err := parseRFC5424(data, ev); err != nil {
+			return nil, errors.Wrap(err, "invalid RFC5424 syslog message")
+		}
+	default:
+		return nil, errors.Errorf("unsupported syslog format %v", format)
+	}
+	return ev, nil
+}
@@ -113,7 +113,7 @@ func NewInput(
 
 	log := logp.NewLogger("syslog")
 
-	out, err := outlet(cfg, context.DynamicFields)
+	out, err := outlet.Connect(cfg, context.DynamicFields)
 	if err != nil {
 		return nil, err
 	}
@@ -125,11 +125,10 @@ func NewInput(
 
 	forwarder := harvester.NewForwarder(out)
 	cb := func(data []byte, metadata inputsource.NetworkMetadata) {
-		ev := newEvent()
-		Parse(data, ev)
+		ev, err := parse(data, config.Format)
 		var d *util.Data
-		if !ev.IsValid() {
-			log.Errorw("can't parse event as syslog rfc3164", "message", string(data))
+		if err != nil {
+			log.Errorw("can't parse event as syslog", "format", config.Format, "error", err, "message", string(data))
 			// On error revert to the raw bytes content, we need a better way to communicate this kind of
 			// error upstream this should be a global effort.
 			d = &util.Data{
@@ -247,6 +246,22 @@ func createEvent(ev *event, metadata inputsource.NetworkMetadata, timezone *time
 		}
 	}
 
+	if ev.Version() != -1 {
+		syslog["version"] = ev.Version()
+	}
+
+	if ev.ProcID() != "" {
+		syslog["procid"] = ev.ProcID()
+	}
+
+	if ev.MsgID() != "" {
+		syslog["msgid"] = ev.MsgID()
+	}
+
+	if sd := ev.StructuredData(); len(sd) > 0 {
+		syslog["structured_data"] = structuredDataFields(sd)
+	}
+
 	f["syslog"] = syslog
 	f["event"] = event
 	f["process"] = process
@@ -200,6 +200,48 @@ func TestSequence(t *testing.T) {
 	})
 }
 
+func TestRFC5424(t *testing.T) {
+	e := newEvent()
+	log := `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog worker ID47 [exampleSDID@32473 iut="3"] An application event log entry...`
+	if !assert.NoError(t, parseRFC5424([]byte(log), e)) {
+		return
+	}
+
+	m := dummyMetadata()
+	event := createEvent(e, m, time.Local, logp.NewLogger("syslog"))
+
+	expected := common.MapStr{
+		"log": common.MapStr{
+			"source": common.MapStr{
+				"address": "127.0.0.1",
+			},
+		},
+		"message":  "An application event log entry...",
+		"hostname": "mymachine.example.com",
+		"process": common.MapStr{
+			"program": "evntslog",
+		},
+		"event": common.MapStr{
+			"severity": 5,
+		},
+		"syslog": common.MapStr{
+			"facility":       20,
+			"severity_label": "Notice",
+			"facility_label": "local4",
+			"priority":       165,
+			"version":        1,
+			"procid":         "worker",
+			"msgid":          "ID47",
+			"structured_data": common.MapStr{
+				"exampleSDID@32473": common.MapStr{"iut": "3"},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, event.Fields)
+	assert.Equal(t, time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), event.Timestamp)
+}
+
 func dummyMetadata() inputsource.NetworkMetadata {
 	ip := "127.0.0.1"
 	parsedIP := net.ParseIP(ip)
@@ -0,0 +1,294 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Header field limits from https://tools.ietf.org/html/rfc5424#section-6.
+const (
+	maxHostnameLen = 255
+	maxAppNameLen  = 48
+	maxProcIDLen   = 128
+	maxMsgIDLen    = 32
+	maxSDNameLen   = 32
+)
+
+const nilValue = "-"
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// rfc5424Parser parses a message in the format defined by
+// https://tools.ietf.org/html/rfc5424#section-6:
+//
+//    <PRI>VERSION SP TIMESTAMP SP HOSTNAME SP APP-NAME SP PROCID SP MSGID SP STRUCTURED-DATA [SP MSG]
+type rfc5424Parser struct {
+	data []byte
+	pos  int
+}
+
+// parseRFC5424 parses data as an RFC5424 message into event.
+func parseRFC5424(data []byte, event *event) error {
+	p := rfc5424Parser{data: data}
+
+	priority, err := p.priority()
+	if err != nil {
+		return err
+	}
+	event.priority = priority
+
+	version, err := p.number(3)
+	if err != nil || version == 0 {
+		return errors.New("invalid version")
+	}
+	event.version = version
+
+	if err := p.space(); err != nil {
+		return err
+	}
+	if err := p.timestamp(event); err != nil {
+		return err
+	}
+
+	fields := []struct {
+		name   string
+		maxLen int
+		set    func(string)
+	}{
+		{"hostname", maxHostnameLen, func(v string) { event.hostname = v }},
+		{"app-name", maxAppNameLen, func(v string) { event.program = v }},
+		{"procid", maxProcIDLen, func(v string) {
+			if pid, err := strconv.Atoi(v); err == nil {
+				event.pid = pid
+			} else {
+				event.procID = v
+			}
+		}},
+		{"msgid", maxMsgIDLen, func(v string) { event.msgID = v }},
+	}
+	for _, f := range fields {
+		if err := p.space(); err != nil {
+			return err
+		}
+		v, err := p.headerField(f.maxLen)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %v", f.name)
+		}
+		if v != nilValue {
+			f.set(v)
+		}
+	}
+
+	if err := p.space(); err != nil {
+		return err
+	}
+	if err := p.structuredData(event); err != nil {
+		return errors.Wrap(err, "invalid structured data")
+	}
+
+	if p.pos < len(p.data) {
+		if err := p.space(); err != nil {
+			return err
+		}
+		event.message = string(bytes.TrimPrefix(p.data[p.pos:], utf8BOM))
+	}
+	return nil
+}
+
+func (p *rfc5424Parser) priority() (int, error) {
+	if p.pos >= len(p.data) || p.data[p.pos] != '<' {
+		return 0, errors.New("missing priority")
+	}
+	p.pos++
+
+	priority, err := p.number(3)
+	if err != nil || priority > 191 || p.pos >= len(p.data) || p.data[p.pos] != '>' {
+		return 0, errors.New("invalid priority")
+	}
+	p.pos++
+	return priority, nil
+}
+
+// number reads a decimal number of at most maxDigits digits.
+func (p *rfc5424Parser) number(maxDigits int) (int, error) {
+	start := p.pos
+	for p.pos < len(p.data) && p.pos-start < maxDigits && isDigit(p.data[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, errors.Errorf("expected a number at position %d", start)
+	}
+	return bytesToInt(p.data[start:p.pos]), nil
+}
+
+func (p *rfc5424Parser) space() error {
+	if p.pos >= len(p.data) || p.data[p.pos] != ' ' {
+		return errors.Errorf("expected a space at position %d", p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// headerField reads a field of printable US-ASCII characters.
+func (p *rfc5424Parser) headerField(maxLen int) (string, error) {
+	start := p.pos
+	for p.pos < len(p.data) && isPrintASCII(p.data[p.pos]) {
+		p.pos++
+	}
+	switch n := p.pos - start; {
+	case n == 0:
+		return "", errors.New("empty value")
+	case n > maxLen:
+		return "", errors.Errorf("value longer than %d characters", maxLen)
+	}
+	return string(p.data[start:p.pos]), nil
+}
+
+func (p *rfc5424Parser) timestamp(event *event) error {
+	v, err := p.headerField(len(time.RFC3339Nano))
+	if err != nil {
+		return errors.Wrap(err, "invalid timestamp")
+	}
+
+	// Messages without timestamp are timestamped when they are received.
+	t := time.Now()
+	if v != nilValue {
+		if t, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return errors.Wrap(err, "invalid timestamp")
+		}
+	}
+
+	event.year = t.Year()
+	event.month = t.Month()
+	event.day = t.Day()
+	event.hour = t.Hour()
+	event.minute = t.Minute()
+	event.second = t.Second()
+	event.nanosecond = t.Nanosecond()
+	event.loc = t.Location()
+	return nil
+}
+
+func (p *rfc5424Parser) structuredData(event *event) error {
+	if bytes.HasPrefix(p.data[p.pos:], []byte(nilValue)) {
+		p.pos += len(nilValue)
+		return nil
+	}
+
+	sd := map[string]map[string]string{}
+	for p.pos < len(p.data) && p.data[p.pos] == '[' {
+		p.pos++
+		id, err := p.sdName()
+		if err != nil {
+			return errors.Wrap(err, "invalid SD-ID")
+		}
+
+		params := map[string]string{}
+		for p.pos < len(p.data) && p.data[p.pos] == ' ' {
+			p.pos++
+			name, err := p.sdName()
+			if err != nil {
+				return errors.Wrapf(err, "invalid PARAM-NAME in element %v", id)
+			}
+			if p.pos >= len(p.data) || p.data[p.pos] != '=' {
+				return errors.Errorf("missing '=' after PARAM-NAME %v", name)
+			}
+			p.pos++
+			value, err := p.sdParamValue()
+			if err != nil {
+				return errors.Wrapf(err, "invalid PARAM-VALUE of %v", name)
+			}
+			params[name] = value
+		}
+
+		if p.pos >= len(p.data) || p.data[p.pos] != ']' {
+			return errors.Errorf("missing ']' at the end of element %v", id)
+		}
+		p.pos++
+		sd[id] = params
+	}
+
+	if len(sd) == 0 {
+		return errors.New("expected '-' or an SD-ELEMENT")
+	}
+	event.structuredData = sd
+	return nil
+}
+
+// sdName reads an SD-ID or a PARAM-NAME.
+func (p *rfc5424Parser) sdName() (string, error) {
+	start := p.pos
+	for p.pos < len(p.data) && isSDNameChar(p.data[p.pos]) {
+		p.pos++
+	}
+	switch n := p.pos - start; {
+	case n == 0:
+		return "", errors.New("empty name")
+	case n > maxSDNameLen:
+		return "", errors.Errorf("name longer than %d characters", maxSDNameLen)
+	}
+	return string(p.data[start:p.pos]), nil
+}
+
+// sdParamValue reads a quoted PARAM-VALUE, where '"', '\' and ']' are
+// escaped with '\'.
+func (p *rfc5424Parser) sdParamValue() (string, error) {
+	if p.pos >= len(p.data) || p.data[p.pos] != '"' {
+		return "", errors.New("missing opening quote")
+	}
+	p.pos++
+
+	var buf []byte
+	for ; p.pos < len(p.data); p.pos++ {
+		switch c := p.data[p.pos]; c {
+		case '"':
+			p.pos++
+			return string(buf), nil
+		case '\\':
+			if p.pos+1 < len(p.data) {
+				switch next := p.data[p.pos+1]; next {
+				case '"', '\\', ']':
+					buf = append(buf, next)
+					p.pos++
+					continue
+				}
+			}
+			buf = append(buf, c)
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return "", errors.New("missing closing quote")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isPrintASCII(c byte) bool {
+	return c >= 33 && c <= 126
+}
+
+func isSDNameChar(c byte) bool {
+	return isPrintASCII(c) && c != '=' && c != ']' && c != '"'
+}
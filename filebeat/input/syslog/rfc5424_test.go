@@ -0,0 +1,190 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRFC5424(t *testing.T) {
+	tests := []struct {
+		title  string
+		log    string
+		syslog event
+		time   time.Time
+	}{
+		{
+			title: "RFC example 1",
+			log:   "<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - \xEF\xBB\xBF'su root' failed for lonvick on /dev/pts/8",
+			syslog: event{
+				priority: 34,
+				version:  1,
+				hostname: "mymachine.example.com",
+				program:  "su",
+				pid:      -1,
+				msgID:    "ID47",
+				message:  "'su root' failed for lonvick on /dev/pts/8",
+			},
+			time: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+		},
+		{
+			title: "RFC example 2",
+			log:   "<165>1 2003-08-24T05:14:15.000003-07:00 192.0.2.1 myproc 8710 - - %% It's time to make the do-nuts.",
+			syslog: event{
+				priority: 165,
+				version:  1,
+				hostname: "192.0.2.1",
+				program:  "myproc",
+				pid:      8710,
+				message:  "%% It's time to make the do-nuts.",
+			},
+			time: time.Date(2003, 8, 24, 12, 14, 15, 3000, time.UTC),
+		},
+		{
+			title: "RFC example 3",
+			log:   `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"] An application event log entry...`,
+			syslog: event{
+				priority: 165,
+				version:  1,
+				hostname: "mymachine.example.com",
+				program:  "evntslog",
+				pid:      -1,
+				msgID:    "ID47",
+				structuredData: map[string]map[string]string{
+					"exampleSDID@32473": {"iut": "3", "eventSource": "Application", "eventID": "1011"},
+				},
+				message: "An application event log entry...",
+			},
+			time: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+		},
+		{
+			title: "RFC example 4, no message",
+			log:   `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3"][examplePriority@32473 class="high"]`,
+			syslog: event{
+				priority: 165,
+				version:  1,
+				hostname: "mymachine.example.com",
+				program:  "evntslog",
+				pid:      -1,
+				msgID:    "ID47",
+				structuredData: map[string]map[string]string{
+					"exampleSDID@32473":     {"iut": "3"},
+					"examplePriority@32473": {"class": "high"},
+				},
+			},
+			time: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+		},
+		{
+			title: "escaped parameter values",
+			log:   `<13>1 2019-01-02T03:04:05Z host app worker-1 - [meta path="C:\\logs\\\]" quote="say \"hi\"" other="a\b"] msg`,
+			syslog: event{
+				priority: 13,
+				version:  1,
+				hostname: "host",
+				program:  "app",
+				pid:      -1,
+				procID:   "worker-1",
+				structuredData: map[string]map[string]string{
+					"meta": {"path": `C:\logs\]`, "quote": `say "hi"`, "other": `a\b`},
+				},
+				message: "msg",
+			},
+			time: time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			ev := newEvent()
+			require.NoError(t, parseRFC5424([]byte(test.log), ev))
+			assert.Equal(t, test.syslog.Priority(), ev.Priority())
+			assert.Equal(t, test.syslog.Version(), ev.Version())
+			assert.Equal(t, test.syslog.Hostname(), ev.Hostname())
+			assert.Equal(t, test.syslog.Program(), ev.Program())
+			assert.Equal(t, test.syslog.Pid(), ev.Pid())
+			assert.Equal(t, test.syslog.ProcID(), ev.ProcID())
+			assert.Equal(t, test.syslog.MsgID(), ev.MsgID())
+			assert.Equal(t, test.syslog.StructuredData(), ev.StructuredData())
+			assert.Equal(t, test.syslog.Message(), ev.Message())
+			assert.True(t, test.time.Equal(ev.Timestamp(time.Local)), ev.Timestamp(time.Local))
+		})
+	}
+}
+
+func TestParseRFC5424NilTimestamp(t *testing.T) {
+	before := time.Now().UTC().Truncate(time.Second)
+	ev := newEvent()
+	require.NoError(t, parseRFC5424([]byte("<13>1 - - - - - - hello"), ev))
+	assert.False(t, ev.Timestamp(time.UTC).Before(before))
+	assert.Equal(t, "", ev.Hostname())
+	assert.Equal(t, "hello", ev.Message())
+}
+
+func TestParseRFC5424Invalid(t *testing.T) {
+	for _, log := range []string{
+		"",
+		"13>1 - - - - - -",
+		"<192>1 - - - - - -",
+		"<13>0 - - - - - -",
+		"<13>1 yesterday - - - - -",
+		"<13>1 - - - - -",
+		"<13>1 - - - - - [id",
+		"<13>1 - - - - - [id a=b]",
+		`<13>1 - - - - - [id a="b]`,
+		"<13>1 - - - - - [] msg",
+		"<13>1 - - 0123456789012345678901234567890123456789012345678 - - -",
+	} {
+		ev := newEvent()
+		assert.Error(t, parseRFC5424([]byte(log), ev), log)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	for log, format := range map[string]Format{
+		"<34>1 2003-10-11T22:14:15.003Z host su - ID47 - msg": FormatRFC5424,
+		"<13>1 - - - - - -": FormatRFC5424,
+		"<34>Oct 11 22:14:15 mymachine su: 'su root' failed": FormatRFC3164,
+		"<190>589265: Feb 8 18:55:31.306: %SEC-11":           FormatRFC3164,
+		"<13>2018-06-19 02:13:38 super mon message":          FormatRFC3164,
+		"Oct 11 22:14:15 mymachine su: 'su root' failed":     FormatRFC3164,
+		"":     FormatRFC3164,
+		"<13>": FormatRFC3164,
+	} {
+		assert.Equal(t, format, detectFormat([]byte(log)), log)
+	}
+}
+
+func TestFormatUnpack(t *testing.T) {
+	for s, expected := range map[string]Format{
+		"auto":    FormatAuto,
+		"rfc3164": FormatRFC3164,
+		"RFC5424": FormatRFC5424,
+	} {
+		var f Format
+		if assert.NoError(t, f.Unpack(s), s) {
+			assert.Equal(t, expected, f)
+		}
+	}
+
+	var f Format
+	assert.Error(t, f.Unpack("rfc9999"))
+}
@@ -54,7 +54,7 @@ func NewInput(
 	context input.Context,
 ) (input.Input, error) {
 
-	out, err := outlet(cfg, context.DynamicFields)
+	out, err := outlet.Connect(cfg, context.DynamicFields)
 	if err != nil {
 		return nil, err
 	}
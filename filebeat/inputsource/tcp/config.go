@@ -19,6 +19,7 @@ package tcp
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/libbeat/common/cfgtype"
@@ -46,3 +47,39 @@ func (c *Config) Validate() error {
 	}
 	return nil
 }
+
+// Framing is the way messages are delimited in a TCP stream.
+type Framing uint8
+
+// Supported framings.
+const (
+	// FramingDelimiter splits messages on a line delimiter.
+	FramingDelimiter Framing = iota
+	// FramingRFC6587 reads octet-counted messages, as defined in
+	// https://tools.ietf.org/html/rfc6587#section-3.4.1, and falls back to
+	// the line delimiter for messages that are not prefixed by their length.
+	FramingRFC6587
+)
+
+var framingNames = map[Framing]string{
+	FramingDelimiter: "delimiter",
+	FramingRFC6587:   "rfc6587",
+}
+
+// Unpack parses the framing name from the configuration.
+func (f *Framing) Unpack(s string) error {
+	for framing, name := range framingNames {
+		if strings.EqualFold(s, name) {
+			*f = framing
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid framing '%v'", s)
+}
+
+func (f Framing) String() string {
+	if name, found := framingNames[f]; found {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", uint8(f))
+}
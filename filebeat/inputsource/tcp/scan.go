@@ -20,8 +20,13 @@ package tcp
 import (
 	"bufio"
 	"bytes"
+	"strconv"
 )
 
+// maxOctetCountDigits is the maximum number of digits of the length of an
+// octet-counted message.
+const maxOctetCountDigits = 10
+
 // factoryDelimiter return a function to split line using a custom delimiter supporting multibytes
 // delimiter, the delimiter is stripped from the returned value.
 func factoryDelimiter(delimiter []byte) bufio.SplitFunc {
@@ -46,3 +51,47 @@ func dropDelimiter(data []byte, delimiter []byte) []byte {
 	}
 	return data
 }
+
+// factoryOctetCounted returns a function to split octet-counted messages,
+// written as the length of the message in bytes followed by a space and the
+// message. Messages that don't start with their length are split by the
+// fallback function, allowing to mix both framings in a stream.
+func factoryOctetCounted(fallback bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, eof bool) (int, []byte, error) {
+		if eof && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		// The length is a number without leading zero.
+		if data[0] < '1' || data[0] > '9' {
+			return fallback(data, eof)
+		}
+
+		i := 1
+		for i < len(data) && i <= maxOctetCountDigits && data[i] >= '0' && data[i] <= '9' {
+			i++
+		}
+		if i == len(data) && !eof {
+			// Wait for the end of the length.
+			return 0, nil, nil
+		}
+		if i > maxOctetCountDigits || i == len(data) || data[i] != ' ' {
+			return fallback(data, eof)
+		}
+
+		length, err := strconv.Atoi(string(data[:i]))
+		if err != nil {
+			return fallback(data, eof)
+		}
+
+		start := i + 1
+		if len(data)-start < length {
+			if eof {
+				// Return the truncated message.
+				return len(data), data[start:], nil
+			}
+			return 0, nil, nil
+		}
+		return start + length, data[start : start+length], nil
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"bufio"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -106,3 +107,73 @@ func TestCustomDelimiter(t *testing.T) {
 		})
 	}
 }
+
+func TestOctetCounted(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name: "Octet-counted messages",
+			text: "5 hello7 bonjour4 hola",
+			expected: []string{
+				"hello",
+				"bonjour",
+				"hola",
+			},
+		},
+		{
+			name: "Message containing delimiters and spaces",
+			text: "12 hello\nworld 3 hey",
+			expected: []string{
+				"hello\nworld ",
+				"hey",
+			},
+		},
+		{
+			name: "Mixed with delimited messages",
+			text: "5 hello<13>bonjour\n4 hola<14>hey\n",
+			expected: []string{
+				"hello",
+				"<13>bonjour",
+				"hola",
+				"<14>hey",
+			},
+		},
+		{
+			name: "Delimited messages starting with digits",
+			text: "123: hello\n0 bonjour\n",
+			expected: []string{
+				"123: hello",
+				"0 bonjour",
+			},
+		},
+		{
+			name: "Truncated message",
+			text: "10 hello",
+			expected: []string{
+				"hello",
+			},
+		},
+		{
+			name:     "Empty string",
+			text:     "",
+			expected: []string(nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Read one byte at a time to exercise partial frames.
+			scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(test.text)))
+			scanner.Split(FramingSplitFunc(FramingRFC6587, []byte("\n")))
+			var elements []string
+			for scanner.Scan() {
+				elements = append(elements, scanner.Text())
+			}
+			assert.NoError(t, scanner.Err())
+			assert.EqualValues(t, test.expected, elements)
+		})
+	}
+}
@@ -216,3 +216,13 @@ func SplitFunc(lineDelimiter []byte) bufio.SplitFunc {
 	}
 	return factoryDelimiter(ld)
 }
+
+// FramingSplitFunc creates a `bufio.SplitFunc` for the given framing, using the
+// line delimiter for messages that are not octet-counted.
+func FramingSplitFunc(framing Framing, lineDelimiter []byte) bufio.SplitFunc {
+	splitFunc := SplitFunc(lineDelimiter)
+	if framing == FramingRFC6587 {
+		return factoryOctetCounted(splitFunc)
+	}
+	return splitFunc
+}
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decode_syslog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/filebeat/input/syslog"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+const (
+	processorName = "decode_syslog"
+	logName       = "processor." + processorName
+)
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.AllowedFields("field", "format", "timezone", "ignore_missing", "tag_on_failure", "when")))
+}
+
+type config struct {
+	Field         string        `config:"field"`          // Field containing the syslog message.
+	Format        syslog.Format `config:"format"`         // Syslog format: auto, rfc3164 or rfc5424.
+	Timezone      string        `config:"timezone"`       // Timezone of timestamps without offset.
+	IgnoreMissing bool          `config:"ignore_missing"` // Skip events without the source field.
+	TagOnFailure  []string      `config:"tag_on_failure"` // Tags to append when a failure occurs.
+}
+
+func defaultConfig() config {
+	return config{
+		Field:    "message",
+		Format:   syslog.FormatAuto,
+		Timezone: "Local",
+	}
+}
+
+type processor struct {
+	config
+	timezone *time.Location
+	log      *logp.Logger
+}
+
+// New constructs a new decode_syslog processor. It parses a syslog message
+// and writes the timestamp, message and ECS fields it contains to the event.
+func New(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the decode_syslog configuration")
+	}
+
+	timezone, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid timezone '%v'", c.Timezone)
+	}
+
+	return &processor{
+		config:   c,
+		timezone: timezone,
+		log:      logp.NewLogger(logName),
+	}, nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[field=%v, format=%v, timezone=%v]",
+		processorName, p.Field, p.Format, p.Timezone)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if err := p.decode(event); err != nil {
+		p.log.Debugf("decode_syslog failed: %v", err)
+		common.AddTags(event.Fields, p.TagOnFailure)
+	}
+	return event, nil
+}
+
+func (p *processor) decode(event *beat.Event) error {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for field %s", p.Field)
+	}
+
+	data, ok := v.(string)
+	if !ok {
+		return errors.Errorf("field %s is not a string but %T", p.Field, v)
+	}
+
+	timestamp, fields, err := syslog.Decode([]byte(data), p.Format, p.timezone)
+	if err != nil {
+		return err
+	}
+
+	event.Timestamp = timestamp
+	event.Fields.DeepUpdate(fields)
+	return nil
+}
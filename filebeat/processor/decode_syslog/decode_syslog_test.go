@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decode_syslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestDecodeSyslog(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"timezone": "UTC",
+	}))
+	require.NoError(t, err)
+
+	event := &beat.Event{
+		Fields: common.MapStr{
+			"message": `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3"] An application event log entry...`,
+			"host":    common.MapStr{"name": "collector"},
+		},
+	}
+	event, err = p.Run(event)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), event.Timestamp)
+	assert.Equal(t, common.MapStr{
+		"message": "An application event log entry...",
+		"host":    common.MapStr{"name": "collector", "hostname": "mymachine.example.com"},
+		"process": common.MapStr{"name": "evntslog", "pid": 1234},
+		"log": common.MapStr{
+			"syslog": common.MapStr{
+				"priority": 165,
+				"severity": common.MapStr{"code": 5, "name": "Notice"},
+				"facility": common.MapStr{"code": 20, "name": "local4"},
+				"version":  1,
+				"msgid":    "ID47",
+				"structured_data": common.MapStr{
+					"exampleSDID@32473": common.MapStr{"iut": "3"},
+				},
+			},
+		},
+	}, event.Fields)
+}
+
+func TestDecodeSyslogTimezone(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":    "log",
+		"format":   "rfc3164",
+		"timezone": "America/New_York",
+	}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{
+		Fields: common.MapStr{"log": "<34>Oct 11 22:14:15 mymachine su: 'su root' failed"},
+	})
+	require.NoError(t, err)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	expected := time.Date(time.Now().Year(), 10, 11, 22, 14, 15, 0, newYork)
+	assert.True(t, expected.Equal(event.Timestamp), event.Timestamp)
+	assert.Equal(t, "'su root' failed", event.Fields["message"])
+}
+
+func TestDecodeSyslogFailure(t *testing.T) {
+	for name, test := range map[string]struct {
+		config common.MapStr
+		fields common.MapStr
+		tagged bool
+	}{
+		"invalid message": {
+			config: common.MapStr{"tag_on_failure": []string{"_syslogparsefailure"}},
+			fields: common.MapStr{"message": "not syslog"},
+			tagged: true,
+		},
+		"wrong format": {
+			config: common.MapStr{"format": "rfc5424", "tag_on_failure": []string{"_syslogparsefailure"}},
+			fields: common.MapStr{"message": "<34>Oct 11 22:14:15 mymachine su: 'su root' failed"},
+			tagged: true,
+		},
+		"not a string": {
+			config: common.MapStr{"tag_on_failure": []string{"_syslogparsefailure"}},
+			fields: common.MapStr{"message": 42},
+			tagged: true,
+		},
+		"missing field": {
+			config: common.MapStr{"tag_on_failure": []string{"_syslogparsefailure"}},
+			fields: common.MapStr{},
+			tagged: true,
+		},
+		"ignore missing field": {
+			config: common.MapStr{"ignore_missing": true, "tag_on_failure": []string{"_syslogparsefailure"}},
+			fields: common.MapStr{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, err := New(common.MustNewConfigFrom(test.config))
+			require.NoError(t, err)
+
+			original := test.fields.Clone()
+			event, err := p.Run(&beat.Event{Fields: test.fields})
+			require.NoError(t, err)
+
+			if test.tagged {
+				original.Put("tags", []string{"_syslogparsefailure"})
+			}
+			assert.Equal(t, original, event.Fields)
+		})
+	}
+}
+
+func TestDecodeSyslogInvalidConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"invalid format":   {"format": "rfc1234"},
+		"invalid timezone": {"timezone": "Mars/Olympus_Mons"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registrar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/elastic/beats/filebeat/input/file"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+const (
+	logStoreVersion = "1"
+
+	opSet    = "set"
+	opRemove = "remove"
+)
+
+// logStore appends state updates and removals to an update log. Once the
+// update log grows beyond checkpointSize, all states are written to the data
+// file (checkpoint) and the update log is truncated.
+// The data file uses the same format as the jsonStore data file. On load the
+// update log is replayed on top of the last checkpoint.
+type logStore struct {
+	dataFile       string
+	logFile        string
+	perm           os.FileMode
+	checkpointSize int64
+
+	// persisted holds the states as they have been written last, in order to
+	// only write changed states to the update log.
+	persisted map[string]file.State
+	log       *os.File
+	logSize   int64
+}
+
+type logEntry struct {
+	Op    string      `json:"op"`
+	ID    string      `json:"id"`
+	State *file.State `json:"state,omitempty"`
+}
+
+func newLogStore(regHome string, perm os.FileMode, checkpointSize int64) *logStore {
+	return &logStore{
+		dataFile:       dataFilePath(regHome),
+		logFile:        logFilePath(regHome),
+		perm:           perm,
+		checkpointSize: checkpointSize,
+		persisted:      map[string]file.State{},
+	}
+}
+
+func logFilePath(regHome string) string {
+	return filepath.Join(regHome, "log.json")
+}
+
+func (s *logStore) Load() ([]file.State, error) {
+	states, err := s.readCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := make(map[string]file.State, len(states))
+	for _, st := range states {
+		idx[st.ID()] = st
+	}
+
+	n, err := s.replayLog(idx)
+	if err != nil {
+		return nil, err
+	}
+
+	states = make([]file.State, 0, len(idx))
+	for _, st := range idx {
+		states = append(states, st)
+	}
+	states = resetStates(fixStates(states))
+
+	s.persisted = make(map[string]file.State, len(states))
+	for _, st := range states {
+		s.persisted[st.ID()] = st
+	}
+
+	logp.Debug("registrar", "Loaded %v states, replayed %v updates from %v", len(states), n, s.logFile)
+	return states, nil
+}
+
+func (s *logStore) readCheckpoint() ([]file.State, error) {
+	f, err := os.Open(s.dataFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return decodeStates(f)
+}
+
+// replayLog applies the entries of the update log to idx. A partially
+// written or corrupted entry at the end of the update log is removed.
+func (s *logStore) replayLog(idx map[string]file.State) (int, error) {
+	if err := s.openLog(); err != nil {
+		return 0, err
+	}
+
+	if _, err := s.log.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	var (
+		reader = bufio.NewReader(s.log)
+		offset int64
+		count  int
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				logp.Warn("Ignoring incomplete entry at the end of the registry update log %v", s.logFile)
+			}
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+
+		var entry logEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			logp.Warn("Ignoring invalid registry update log entries in %v starting at offset %v: %v", s.logFile, offset, err)
+			break
+		}
+
+		switch entry.Op {
+		case opSet:
+			if entry.State == nil {
+				return count, fmt.Errorf("missing state in registry update log entry at offset %v", offset)
+			}
+			idx[entry.ID] = *entry.State
+		case opRemove:
+			delete(idx, entry.ID)
+		default:
+			return count, fmt.Errorf("unknown operation '%v' in registry update log at offset %v", entry.Op, offset)
+		}
+
+		offset += int64(len(line))
+		count++
+	}
+
+	if err := s.log.Truncate(offset); err != nil {
+		return count, err
+	}
+	s.logSize = offset
+	return count, nil
+}
+
+func (s *logStore) Write(states []file.State) error {
+	if s.logSize >= s.checkpointSize || !isFile(s.dataFile) {
+		return s.checkpoint(states)
+	}
+
+	var (
+		buf     bytes.Buffer
+		enc     = json.NewEncoder(&buf)
+		updated = make(map[string]file.State)
+		seen    = make(map[string]struct{}, len(states))
+	)
+	for i := range states {
+		st := &states[i]
+		id := st.ID()
+		seen[id] = struct{}{}
+
+		if old, exists := s.persisted[id]; exists && stateEqual(&old, st) {
+			continue
+		}
+		if err := enc.Encode(logEntry{Op: opSet, ID: id, State: st}); err != nil {
+			return err
+		}
+		updated[id] = *st
+	}
+
+	var removed []string
+	for id := range s.persisted {
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		if err := enc.Encode(logEntry{Op: opRemove, ID: id}); err != nil {
+			return err
+		}
+		removed = append(removed, id)
+	}
+
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	if err := s.appendLog(buf.Bytes()); err != nil {
+		return err
+	}
+
+	for id, st := range updated {
+		s.persisted[id] = st
+	}
+	for _, id := range removed {
+		delete(s.persisted, id)
+	}
+
+	logp.Debug("registrar", "Registry update log written. %d states updated, %d removed.", len(updated), len(removed))
+	return nil
+}
+
+func (s *logStore) appendLog(b []byte) error {
+	if err := s.openLog(); err != nil {
+		return err
+	}
+
+	n, err := s.log.Write(b)
+	if err == nil {
+		err = s.log.Sync()
+	}
+	if err != nil {
+		// remove partially written entries, so new entries are not appended to
+		// a corrupted line.
+		if n > 0 {
+			s.log.Truncate(s.logSize)
+		}
+		return err
+	}
+
+	s.logSize += int64(n)
+	return nil
+}
+
+// checkpoint writes all states to the data file and truncates the update log.
+func (s *logStore) checkpoint(states []file.State) error {
+	logp.Debug("registrar", "Write registry checkpoint with %v states", len(states))
+
+	if err := writeStateFile(s.dataFile, s.perm, states); err != nil {
+		return err
+	}
+
+	if err := s.openLog(); err != nil {
+		return err
+	}
+	if err := s.log.Truncate(0); err != nil {
+		return err
+	}
+	if err := s.log.Sync(); err != nil {
+		return err
+	}
+	s.logSize = 0
+
+	s.persisted = make(map[string]file.State, len(states))
+	for _, st := range states {
+		s.persisted[st.ID()] = st
+	}
+	return nil
+}
+
+func (s *logStore) openLog() error {
+	if s.log != nil {
+		return nil
+	}
+
+	f, err := os.OpenFile(s.logFile, os.O_RDWR|os.O_CREATE|os.O_APPEND, s.perm)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.log = f
+	s.logSize = info.Size()
+	return nil
+}
+
+func (s *logStore) Close() error {
+	if s.log == nil {
+		return nil
+	}
+
+	err := s.log.Close()
+	s.log = nil
+	return err
+}
+
+// stateEqual compares the persisted fields of two states.
+func stateEqual(a, b *file.State) bool {
+	if a.Source != b.Source ||
+		a.Offset != b.Offset ||
+		!a.Timestamp.Equal(b.Timestamp) ||
+		a.TTL != b.TTL ||
+		a.Type != b.Type ||
+		a.FileStateOS != b.FileStateOS ||
+		len(a.Meta) != len(b.Meta) {
+		return false
+	}
+
+	for k, v := range a.Meta {
+		if other, exists := b.Meta[k]; !exists || other != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registrar
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/filebeat/input/file"
+)
+
+func newTestState(id string, offset int64) file.State {
+	return file.State{
+		Type:      "log",
+		Source:    id + ".log",
+		Offset:    offset,
+		Timestamp: time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		TTL:       -2,
+		Finished:  true,
+		Meta:      map[string]string{"id": id},
+	}
+}
+
+func loadOffsets(t *testing.T, s store) map[string]int64 {
+	states, err := s.Load()
+	require.NoError(t, err)
+
+	offsets := map[string]int64{}
+	for _, st := range states {
+		offsets[st.Meta["id"]] = st.Offset
+	}
+	return offsets
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func TestLogStoreIncrementalWrites(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registrar")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := newLogStore(dir, 0600, 1<<20)
+	require.NoError(t, s.Write([]file.State{newTestState("a", 1), newTestState("b", 2)}))
+	checkpointSize := fileSize(t, s.dataFile)
+	assert.Equal(t, int64(0), fileSize(t, s.logFile))
+
+	// update a, remove b, add c
+	require.NoError(t, s.Write([]file.State{newTestState("a", 10), newTestState("c", 3)}))
+	logSize := fileSize(t, s.logFile)
+	assert.True(t, logSize > 0)
+	assert.Equal(t, checkpointSize, fileSize(t, s.dataFile))
+
+	// unchanged states are not written again
+	require.NoError(t, s.Write([]file.State{newTestState("a", 10), newTestState("c", 3)}))
+	assert.Equal(t, logSize, fileSize(t, s.logFile))
+	require.NoError(t, s.Close())
+
+	reloaded := newLogStore(dir, 0600, 1<<20)
+	defer reloaded.Close()
+	assert.Equal(t, map[string]int64{"a": 10, "c": 3}, loadOffsets(t, reloaded))
+}
+
+func TestLogStoreCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registrar")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := newLogStore(dir, 0600, 1)
+	defer s.Close()
+
+	require.NoError(t, s.Write([]file.State{newTestState("a", 1)}))
+	require.NoError(t, s.Write([]file.State{newTestState("a", 2)}))
+	assert.True(t, fileSize(t, s.logFile) > 0)
+
+	// update log exceeds the checkpoint size -> compact into data file
+	require.NoError(t, s.Write([]file.State{newTestState("a", 3)}))
+	assert.Equal(t, int64(0), fileSize(t, s.logFile))
+
+	f, err := os.Open(s.dataFile)
+	require.NoError(t, err)
+	defer f.Close()
+	states, err := decodeStates(f)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, int64(3), states[0].Offset)
+}
+
+func TestLogStoreIgnoresIncompleteEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registrar")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := newLogStore(dir, 0600, 1<<20)
+	require.NoError(t, s.Write([]file.State{newTestState("a", 1)}))
+	require.NoError(t, s.Write([]file.State{newTestState("a", 2)}))
+	validSize := fileSize(t, s.logFile)
+	require.NoError(t, s.Close())
+
+	f, err := os.OpenFile(s.logFile, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"set","id":"`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	reloaded := newLogStore(dir, 0600, 1<<20)
+	defer reloaded.Close()
+	assert.Equal(t, map[string]int64{"a": 2}, loadOffsets(t, reloaded))
+	assert.Equal(t, validSize, fileSize(t, s.logFile))
+}
+
+func TestMigrateStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registrar")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	regHome := filepath.Join(dir, "filebeat")
+	require.NoError(t, initRegistry(regHome, 0600))
+	require.NoError(t, newJSONStore(regHome, 0600).Write([]file.State{newTestState("a", 1)}))
+
+	// json -> log
+	require.NoError(t, migrateStore(regHome, "log", 0600))
+	version, err := readVersion(regHome, "")
+	require.NoError(t, err)
+	assert.Equal(t, logStoreVersion, version)
+
+	s := newLogStore(regHome, 0600, 1<<20)
+	assert.Equal(t, map[string]int64{"a": 1}, loadOffsets(t, s))
+	require.NoError(t, s.Write([]file.State{newTestState("a", 5), newTestState("b", 6)}))
+	require.NoError(t, s.Close())
+
+	// log -> json
+	require.NoError(t, migrateStore(regHome, "json", 0600))
+	version, err = readVersion(regHome, "")
+	require.NoError(t, err)
+	assert.Equal(t, currentVersion, version)
+	assert.False(t, isFile(logFilePath(regHome)))
+
+	assert.Equal(t, map[string]int64{"a": 5, "b": 6}, loadOffsets(t, newJSONStore(regHome, 0600)))
+}
@@ -35,7 +35,7 @@ const (
 	currentVersion = "0"
 )
 
-func ensureCurrent(home, migrateFile string, perm os.FileMode) error {
+func ensureCurrent(home, migrateFile string, perm os.FileMode, storeType string) error {
 	if migrateFile == "" {
 		if isFile(home) {
 			migrateFile = home
@@ -52,18 +52,61 @@ func ensureCurrent(home, migrateFile string, perm os.FileMode) error {
 
 	switch version {
 	case legacyVersion:
-		return migrateLegacy(home, fbRegHome, migrateFile, perm)
-	case currentVersion:
-		return nil
+		err = migrateLegacy(home, fbRegHome, migrateFile, perm)
+	case currentVersion, logStoreVersion:
 	case "":
 		backupFile := migrateFile + ".bak"
 		if isFile(backupFile) {
-			return migrateLegacy(home, fbRegHome, backupFile, perm)
+			err = migrateLegacy(home, fbRegHome, backupFile, perm)
+		} else {
+			err = initRegistry(fbRegHome, perm)
 		}
-		return initRegistry(fbRegHome, perm)
 	default:
 		return fmt.Errorf("registry file version %v not supported", version)
 	}
+	if err != nil {
+		return err
+	}
+
+	return migrateStore(fbRegHome, storeType, perm)
+}
+
+// migrateStore converts the registry to the format required by the configured
+// store type. The data file of the json store is a valid checkpoint of the
+// log store, such that only the update log needs to be merged when switching
+// back to the json store.
+func migrateStore(regHome, storeType string, perm os.FileMode) error {
+	version, err := readVersion(regHome, "")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case storeType == "log" && version == currentVersion:
+		logp.Info("Migrate registry to log store")
+		return writeMeta(regHome, logStoreVersion, perm)
+
+	case storeType == "json" && version == logStoreVersion:
+		logp.Info("Migrate registry from log store to json store")
+		s := newLogStore(regHome, perm, 0)
+		states, err := s.Load()
+		if err == nil {
+			err = s.checkpoint(states)
+		}
+		if err1 := s.Close(); err == nil {
+			err = err1
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to merge registry update log")
+		}
+
+		if err := os.Remove(logFilePath(regHome)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return writeMeta(regHome, currentVersion, perm)
+	}
+
+	return nil
 }
 
 func migrateLegacy(home, regHome, migrateFile string, perm os.FileMode) error {
@@ -110,15 +153,25 @@ func initRegistry(regHome string, perm os.FileMode) error {
 	metaFile := filepath.Join(regHome, "meta.json")
 	if !isFile(metaFile) {
 		logp.Info("Initialize registry meta file")
-		err := safeWriteFile(metaFile, []byte(`{"version": "0"}`), perm)
-		if err != nil {
-			return errors.Wrap(err, "failed writing registry meta.json")
-		}
+		return writeMeta(regHome, currentVersion, perm)
 	}
 
 	return nil
 }
 
+func writeMeta(regHome, version string, perm os.FileMode) error {
+	metaFile := filepath.Join(regHome, "meta.json")
+	tmpFile := metaFile + ".new"
+	err := safeWriteFile(tmpFile, []byte(fmt.Sprintf(`{"version": "%v"}`, version)), perm)
+	if err == nil {
+		err = helper.SafeFileRotate(metaFile, tmpFile)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed writing registry meta.json")
+	}
+	return nil
+}
+
 func readVersion(regHome, migrateFile string) (string, error) {
 	if isFile(migrateFile) {
 		return legacyVersion, nil
@@ -28,7 +28,6 @@ import (
 
 	"github.com/elastic/beats/filebeat/config"
 	"github.com/elastic/beats/filebeat/input/file"
-	helper "github.com/elastic/beats/libbeat/common/file"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/monitoring"
 	"github.com/elastic/beats/libbeat/paths"
@@ -40,6 +39,7 @@ type Registrar struct {
 	done         chan struct{}
 	registryFile string      // Path to the Registry File
 	fileMode     os.FileMode // Permissions to apply on the Registry File
+	store        store
 	wg           sync.WaitGroup
 
 	states               *file.States // Map with all file paths inside and the corresponding state
@@ -71,15 +71,21 @@ func New(cfg config.Registry, out successLogger) (*Registrar, error) {
 		migrateFile = paths.Resolve(paths.Data, migrateFile)
 	}
 
-	err := ensureCurrent(home, migrateFile, cfg.Permissions)
+	regHome := filepath.Join(home, "filebeat")
+	store, err := newStore(cfg.Type, regHome, cfg.Permissions, int64(cfg.CheckpointSize))
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureCurrent(home, migrateFile, cfg.Permissions, cfg.Type)
 	if err != nil {
 		return nil, err
 	}
 
-	dataFile := filepath.Join(home, "filebeat", "data.json")
 	r := &Registrar{
-		registryFile: dataFile,
+		registryFile: dataFilePath(regHome),
 		fileMode:     cfg.Permissions,
+		store:        store,
 		done:         make(chan struct{}),
 		states:       file.NewStates(),
 		Channel:      make(chan []file.State, 1),
@@ -135,16 +141,9 @@ func (r *Registrar) GetStates() []file.State {
 // loadStates fetches the previous reading state from the configure RegistryFile file
 // The default file is `registry` in the data path.
 func (r *Registrar) loadStates() error {
-	f, err := os.Open(r.registryFile)
-	if err != nil {
-		return err
-	}
-
-	defer f.Close()
-
 	logp.Info("Loading registrar data from %s", r.registryFile)
 
-	states, err := readStatesFrom(f)
+	states, err := r.store.Load()
 	if err != nil {
 		return err
 	}
@@ -154,15 +153,27 @@ func (r *Registrar) loadStates() error {
 	return nil
 }
 
+func dataFilePath(regHome string) string {
+	return filepath.Join(regHome, "data.json")
+}
+
 func readStatesFrom(in io.Reader) ([]file.State, error) {
+	states, err := decodeStates(in)
+	if err != nil {
+		return nil, err
+	}
+
+	states = fixStates(states)
+	states = resetStates(states)
+	return states, nil
+}
+
+func decodeStates(in io.Reader) ([]file.State, error) {
 	states := []file.State{}
 	decoder := json.NewDecoder(in)
 	if err := decoder.Decode(&states); err != nil {
 		return nil, fmt.Errorf("Error decoding states: %s", err)
 	}
-
-	states = fixStates(states)
-	states = resetStates(states)
 	return states, nil
 }
 
@@ -279,6 +290,7 @@ func (r *Registrar) Run() {
 	// Writes registry on shutdown
 	defer func() {
 		r.writeRegistry()
+		r.store.Close()
 		r.wg.Done()
 	}()
 
@@ -380,7 +392,7 @@ func (r *Registrar) flushRegistry() {
 	r.bufferedStateUpdates = 0
 }
 
-// writeRegistry writes the new json registry file to disk.
+// writeRegistry persists the current states using the configured store.
 func (r *Registrar) writeRegistry() error {
 	// First clean up states
 	r.gcStates()
@@ -389,19 +401,11 @@ func (r *Registrar) writeRegistry() error {
 
 	registryWrites.Inc()
 
-	tempfile, err := writeTmpFile(r.registryFile, r.fileMode, states)
-	if err != nil {
-		registryFails.Inc()
-		return err
-	}
-
-	err = helper.SafeFileRotate(r.registryFile, tempfile)
-	if err != nil {
+	if err := r.store.Write(states); err != nil {
 		registryFails.Inc()
 		return err
 	}
 
-	logp.Debug("registrar", "Registry file updated. %d states written.", len(states))
 	registrySuccess.Inc()
 
 	return nil
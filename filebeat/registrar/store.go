@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registrar
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/elastic/beats/filebeat/input/file"
+	helper "github.com/elastic/beats/libbeat/common/file"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// store persists the registrar states.
+type store interface {
+	// Load reads all persisted states. States are reset, such that they can
+	// be picked up by the inputs.
+	Load() ([]file.State, error)
+
+	// Write persists the complete set of current states. States not passed
+	// anymore are removed from the store.
+	Write(states []file.State) error
+
+	Close() error
+}
+
+// jsonStore rewrites all states into a single JSON file on every write.
+type jsonStore struct {
+	dataFile string
+	perm     os.FileMode
+}
+
+func newStore(typ, regHome string, perm os.FileMode, checkpointSize int64) (store, error) {
+	switch typ {
+	case "json":
+		return newJSONStore(regHome, perm), nil
+	case "log":
+		return newLogStore(regHome, perm, checkpointSize), nil
+	default:
+		return nil, fmt.Errorf("unknown registry type '%v'", typ)
+	}
+}
+
+func newJSONStore(regHome string, perm os.FileMode) *jsonStore {
+	return &jsonStore{
+		dataFile: dataFilePath(regHome),
+		perm:     perm,
+	}
+}
+
+func (s *jsonStore) Load() ([]file.State, error) {
+	f, err := os.Open(s.dataFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readStatesFrom(f)
+}
+
+func (s *jsonStore) Write(states []file.State) error {
+	return writeStateFile(s.dataFile, s.perm, states)
+}
+
+func (s *jsonStore) Close() error {
+	return nil
+}
+
+// writeStateFile atomically replaces the JSON file storing all states.
+func writeStateFile(path string, perm os.FileMode, states []file.State) error {
+	tempfile, err := writeTmpFile(path, perm, states)
+	if err != nil {
+		return err
+	}
+
+	if err := helper.SafeFileRotate(path, tempfile); err != nil {
+		return err
+	}
+
+	logp.Debug("registrar", "Registry file updated. %d states written.", len(states))
+	return nil
+}
@@ -0,0 +1,317 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+const (
+	counterType = "counter"
+	gaugeType   = "gauge"
+	untypedType = "untyped"
+)
+
+// labelRule reports the sub-registries of the registry at path as a label,
+// with the name of the sub-registry as value.
+type labelRule struct {
+	namespace string
+	path      []string
+	label     string
+}
+
+// labelRules group the metrics reported per dataset or per pipeline client.
+var labelRules = []labelRule{
+	{namespace: "dataset", label: "id"},
+	{namespace: "stats", path: []string{"libbeat", "pipeline", "client"}, label: "id"},
+}
+
+// counterNames and gaugeNames are the last elements of the names of the
+// metrics reported as counters and gauges. All other numeric metrics are
+// untyped.
+var (
+	counterNames = map[string]bool{
+		"acked": true, "added": true, "batches": true, "bytes": true,
+		"cleanup": true, "closed": true, "dead_letter": true, "done": true, "dropped": true,
+		"duplicates": true, "errors": true, "fail": true, "failed": true,
+		"failure": true, "failures": true, "filtered": true, "hits": true,
+		"matches": true, "misses": true, "ms": true, "published": true,
+		"received": true, "reloads": true, "renamed": true, "retry": true,
+		"skipped": true, "started": true, "starts": true, "stops": true,
+		"success": true, "ticks": true, "toomany": true, "total": true,
+		"truncated": true, "update": true, "memory_total": true,
+	}
+
+	gaugeNames = map[string]bool{
+		"active": true, "clients": true, "cores": true, "current": true, "endpoints": true,
+		"gc_next": true, "goroutines": true, "hard": true, "memory_alloc": true,
+		"monitors": true, "open": true, "open_files": true, "rss": true,
+		"running": true, "soft": true, "1": true, "5": true, "15": true,
+	}
+)
+
+// metricFamily is a set of samples sharing the same metric name.
+type metricFamily struct {
+	typ     string
+	samples []sample
+}
+
+type sample struct {
+	labels string
+	value  string
+}
+
+// prometheusVisitor collects the metrics of a monitoring registry as
+// Prometheus metric families. Metric names are built from the metric path,
+// string values are reported as labels of an `_info` gauge per registry.
+type prometheusVisitor struct {
+	prefix   string
+	rules    []labelRule
+	families map[string]*metricFamily
+
+	path    []string
+	strings []map[string]string
+}
+
+// writePrometheus renders the given namespaces in the Prometheus text
+// exposition format. Metrics of the stats namespace are not prefixed, metrics
+// of all other namespaces are prefixed with the namespace name.
+func writePrometheus(w io.Writer, namespaces map[string]*monitoring.Registry) error {
+	families := map[string]*metricFamily{}
+	for name, registry := range namespaces {
+		prefix := name
+		if name == "stats" {
+			prefix = ""
+		}
+		var rules []labelRule
+		for _, rule := range labelRules {
+			if rule.namespace == name {
+				rules = append(rules, rule)
+			}
+		}
+		registry.Visit(monitoring.Full, &prometheusVisitor{
+			prefix:   prefix,
+			rules:    rules,
+			families: families,
+		})
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := bufio.NewWriter(w)
+	for _, name := range names {
+		family := families[name]
+		sort.Slice(family.samples, func(i, j int) bool {
+			return family.samples[i].labels < family.samples[j].labels
+		})
+
+		out.WriteString("# TYPE " + name + " " + family.typ + "\n")
+		for _, s := range family.samples {
+			out.WriteString(name + s.labels + " " + s.value + "\n")
+		}
+	}
+	return out.Flush()
+}
+
+func (vs *prometheusVisitor) OnRegistryStart() {
+	vs.strings = append(vs.strings, map[string]string{})
+}
+
+func (vs *prometheusVisitor) OnRegistryFinished() {
+	last := len(vs.strings) - 1
+	if values := vs.strings[last]; len(values) > 0 {
+		vs.add(vs.path, values, gaugeType, "1", true)
+	}
+	vs.strings = vs.strings[:last]
+
+	if len(vs.path) > 0 {
+		vs.dropKey()
+	}
+}
+
+func (vs *prometheusVisitor) OnKey(name string) {
+	vs.path = append(vs.path, name)
+}
+
+func (vs *prometheusVisitor) OnString(s string) {
+	vs.strings[len(vs.strings)-1][vs.path[len(vs.path)-1]] = s
+	vs.dropKey()
+}
+
+func (vs *prometheusVisitor) OnStringSlice(f []string) {
+	vs.OnString(strings.Join(f, ","))
+}
+
+func (vs *prometheusVisitor) OnBool(b bool) {
+	value := "0"
+	if b {
+		value = "1"
+	}
+	vs.add(vs.path, nil, gaugeType, value, false)
+	vs.dropKey()
+}
+
+func (vs *prometheusVisitor) OnInt(i int64) {
+	vs.add(vs.path, nil, metricType(vs.path), strconv.FormatInt(i, 10), false)
+	vs.dropKey()
+}
+
+func (vs *prometheusVisitor) OnFloat(f float64) {
+	vs.add(vs.path, nil, metricType(vs.path), formatFloat(f), false)
+	vs.dropKey()
+}
+
+func (vs *prometheusVisitor) dropKey() {
+	vs.path = vs.path[:len(vs.path)-1]
+}
+
+// add adds a sample for the metric at the given path. Path elements matching
+// a label rule are reported as labels. Info metrics get the `_info` suffix.
+func (vs *prometheusVisitor) add(path []string, values map[string]string, typ, value string, info bool) {
+	labels := map[string]string{}
+	for k, v := range values {
+		labels[sanitizeName(k, false)] = v
+	}
+
+	var nameParts []string
+	if vs.prefix != "" {
+		nameParts = append(nameParts, vs.prefix)
+	}
+	if rule, ok := vs.matchRule(path); ok {
+		labels[rule.label] = path[len(rule.path)]
+		nameParts = append(nameParts, rule.path...)
+		path = path[len(rule.path)+1:]
+	}
+	nameParts = append(nameParts, path...)
+	if info && (len(nameParts) == 0 || nameParts[len(nameParts)-1] != "info") {
+		nameParts = append(nameParts, "info")
+	}
+	if len(nameParts) == 0 || (!info && len(path) == 0) {
+		return
+	}
+
+	name := sanitizeName(strings.Join(nameParts, "_"), true)
+
+	family := vs.families[name]
+	if family == nil {
+		family = &metricFamily{typ: typ}
+		vs.families[name] = family
+	}
+	family.samples = append(family.samples, sample{labels: formatLabels(labels), value: value})
+}
+
+// matchRule returns the label rule for the metric at path, if any.
+func (vs *prometheusVisitor) matchRule(path []string) (labelRule, bool) {
+	for _, rule := range vs.rules {
+		if len(path) <= len(rule.path) {
+			continue
+		}
+		matches := true
+		for i, name := range rule.path {
+			if path[i] != name {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return rule, true
+		}
+	}
+	return labelRule{}, false
+}
+
+// metricType returns the Prometheus type of the numeric metric at path.
+func metricType(path []string) string {
+	name := path[len(path)-1]
+	switch {
+	case counterNames[name], strings.HasSuffix(name, "_starts"), strings.HasSuffix(name, "_stops"),
+		strings.HasSuffix(name, "_lost"), strings.HasPrefix(name, "unmatched_"):
+		return counterType
+	case gaugeNames[name]:
+		return gaugeType
+	}
+	return untypedType
+}
+
+// sanitizeName replaces all characters not allowed in metric or label names
+// by an underscore. Names starting with a digit are prefixed with one.
+func sanitizeName(name string, metric bool) string {
+	b := []byte(name)
+	for i, c := range b {
+		valid := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9') || (metric && c == ':')
+		if !valid {
+			b[i] = '_'
+		}
+	}
+	if len(b) > 0 && b[0] >= '0' && b[0] <= '9' {
+		return "_" + string(b)
+	}
+	return string(b)
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(labelValueEscaper.Replace(labels[name]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	stats := monitoring.NewRegistry()
+	monitoring.NewUint(stats, "libbeat.output.events.acked").Set(42)
+	monitoring.NewInt(stats, "libbeat.pipeline.events.active").Set(3)
+	monitoring.NewString(stats, "libbeat.output.type").Set("elasticsearch")
+	monitoring.NewFloat(stats, "system.load.1").Set(0.5)
+	monitoring.NewBool(stats, "beat.enabled").Set(true)
+	monitoring.NewInt(stats, "beat.some-value").Set(7)
+	client := stats.NewRegistry("libbeat.pipeline.client.log-1")
+	monitoring.NewUint(client, "events.acked").Set(5)
+	monitoring.NewString(client, "type").Set("log")
+
+	info := monitoring.NewRegistry()
+	monitoring.NewString(info, "beat").Set("testbeat")
+	monitoring.NewString(info, "version").Set(`7.3.0 "snapshot"`)
+
+	dataset := monitoring.NewRegistry()
+	for _, id := range []string{"b", "a"} {
+		r := dataset.NewRegistry(id)
+		monitoring.NewInt(r, "events.total").Set(int64(len(id) + 1))
+		monitoring.NewString(r, "starttime").Set("2019-07-01")
+	}
+
+	var buf bytes.Buffer
+	err := writePrometheus(&buf, map[string]*monitoring.Registry{
+		"stats":   stats,
+		"info":    info,
+		"dataset": dataset,
+	})
+	require.NoError(t, err)
+
+	expected := `# TYPE beat_enabled gauge
+beat_enabled 1
+# TYPE beat_some_value untyped
+beat_some_value 7
+# TYPE dataset_events_total counter
+dataset_events_total{id="a"} 2
+dataset_events_total{id="b"} 2
+# TYPE dataset_info gauge
+dataset_info{id="a",starttime="2019-07-01"} 1
+dataset_info{id="b",starttime="2019-07-01"} 1
+# TYPE info gauge
+info{beat="testbeat",version="7.3.0 \"snapshot\""} 1
+# TYPE libbeat_output_events_acked counter
+libbeat_output_events_acked 42
+# TYPE libbeat_output_info gauge
+libbeat_output_info{type="elasticsearch"} 1
+# TYPE libbeat_pipeline_client_events_acked counter
+libbeat_pipeline_client_events_acked{id="log-1"} 5
+# TYPE libbeat_pipeline_client_info gauge
+libbeat_pipeline_client_info{id="log-1",type="log"} 1
+# TYPE libbeat_pipeline_events_active gauge
+libbeat_pipeline_events_active 3
+# TYPE system_load_1 gauge
+system_load_1 0.5
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestSanitizeName(t *testing.T) {
+	assert.Equal(t, "cpu_total_time_ms", sanitizeName("cpu_total.time-ms", true))
+	assert.Equal(t, "_1", sanitizeName("1", true))
+	assert.Equal(t, "a:b", sanitizeName("a:b", true))
+	assert.Equal(t, "a_b", sanitizeName("a:b", false))
+}
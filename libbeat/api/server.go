@@ -44,6 +44,7 @@ func Start(cfg *common.Config) {
 		mux.HandleFunc("/state", stateHandler)
 		mux.HandleFunc("/stats", statsHandler)
 		mux.HandleFunc("/dataset", datasetHandler)
+		mux.HandleFunc("/metrics", metricsHandler)
 
 		url := config.Host + ":" + strconv.Itoa(config.Port)
 		logp.Info("Metrics endpoint listening on: %s", url)
@@ -94,6 +95,21 @@ func datasetHandler(w http.ResponseWriter, r *http.Request) {
 	print(w, data, r.URL)
 }
 
+// metricsHandler reports all libbeat/monitoring metrics in the Prometheus
+// text format
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	namespaces := map[string]*monitoring.Registry{}
+	for _, name := range monitoring.GetNamespaceNames() {
+		namespaces[name] = monitoring.GetNamespace(name).GetRegistry()
+	}
+
+	if err := writePrometheus(w, namespaces); err != nil {
+		logp.Debug("api", "Failed to write metrics: %v", err)
+	}
+}
+
 func print(w http.ResponseWriter, data common.MapStr, u *url.URL) {
 	query := u.Query()
 	if _, ok := query["pretty"]; ok {
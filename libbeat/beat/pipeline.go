@@ -18,8 +18,11 @@
 package beat
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/mitchellh/hashstructure"
+
 	"github.com/elastic/beats/libbeat/common"
 )
 
@@ -57,6 +60,11 @@ type ClientConfig struct {
 	// Events configures callbacks for common client callbacks
 	Events ClientEventer
 
+	// Identity identifies the client in the monitoring metrics, like an input
+	// or a module. If set, the number of events published, filtered, dropped
+	// and acknowledged are reported per client as well.
+	Identity *ClientIdentity
+
 	// ACK handler strategies.
 	// Note: ack handlers are run in another go-routine owned by the publisher pipeline.
 	//       They should not block for to long, to not block the internal buffers for
@@ -78,6 +86,42 @@ type ClientConfig struct {
 	ACKLastEvent func(interface{})
 }
 
+// ClientIdentity identifies a pipeline client in the monitoring metrics.
+type ClientIdentity struct {
+	// ID uniquely identifies the client. Clients connecting with the same ID
+	// share their metrics.
+	ID string
+
+	// Fields describe the client, for example with the type of an input or the
+	// name of a module. They are reported next to the metrics of the client.
+	Fields map[string]string
+}
+
+// NewClientIdentity creates the identity of a client configured by cfg, like
+// an input or a module. If id is empty, the ID is derived from the prefix and
+// a hash of the configuration. Empty fields are left out.
+func NewClientIdentity(id, prefix string, cfg *common.Config, fields map[string]string) (*ClientIdentity, error) {
+	if id == "" {
+		var settings map[string]interface{}
+		if err := cfg.Unpack(&settings); err != nil {
+			return nil, fmt.Errorf("failed to read the configuration of %s: %v", prefix, err)
+		}
+		hash, err := hashstructure.Hash(settings, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash the configuration of %s: %v", prefix, err)
+		}
+		id = fmt.Sprintf("%s-%d", prefix, hash)
+	}
+
+	identity := &ClientIdentity{ID: id, Fields: map[string]string{}}
+	for k, v := range fields {
+		if v != "" {
+			identity.Fields[k] = v
+		}
+	}
+	return identity, nil
+}
+
 // ProcessingConfig provides additional event processing settings a client can
 // pass to the publisher pipeline on Connect.
 type ProcessingConfig struct {
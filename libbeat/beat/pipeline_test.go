@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestNewClientIdentity(t *testing.T) {
+	cfg := common.MustNewConfigFrom(common.MapStr{"type": "log", "paths": []string{"/var/log/a.log"}})
+
+	identity, err := NewClientIdentity("nginx", "log", cfg, map[string]string{"type": "log", "module": ""})
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", identity.ID)
+	assert.Equal(t, map[string]string{"type": "log"}, identity.Fields)
+
+	identity, err = NewClientIdentity("", "log", cfg, nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(identity.ID, "log-"), identity.ID)
+
+	again, err := NewClientIdentity("", "log", common.MustNewConfigFrom(common.MapStr{"type": "log", "paths": []string{"/var/log/a.log"}}), nil)
+	require.NoError(t, err)
+	assert.Equal(t, identity.ID, again.ID, "the ID must be stable for the same configuration")
+
+	other, err := NewClientIdentity("", "log", common.MustNewConfigFrom(common.MapStr{"type": "log", "paths": []string{"/var/log/b.log"}}), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, identity.ID, other.ID)
+
+	// an ID is never derived from a configuration that can not be read
+	_, err = NewClientIdentity("", "log", common.MustNewConfigFrom(common.MapStr{"path": "${missing}"}), nil)
+	assert.Error(t, err)
+}
@@ -988,9 +988,20 @@ func initKibanaConfig(beatConfig beatConfig) (*common.Config, error) {
 		kibanaConfig = common.NewConfig()
 	}
 
-	if esConfig.Enabled() {
+	// Credentials are only copied from the Elasticsearch output if no other
+	// authentication method is configured for Kibana.
+	if esConfig.Enabled() && !kibanaConfig.HasField("api_key") && !kibanaConfig.HasField("bearer_token") {
 		username, _ := esConfig.String("username", -1)
 		password, _ := esConfig.String("password", -1)
+		apiKey, _ := esConfig.String("api_key", -1)
+		bearerToken, _ := esConfig.String("bearer_token", -1)
+
+		hasBasicAuth := kibanaConfig.HasField("username") || kibanaConfig.HasField("password")
+		if !hasBasicAuth && apiKey != "" {
+			kibanaConfig.SetString("api_key", -1, apiKey)
+		} else if !hasBasicAuth && bearerToken != "" {
+			kibanaConfig.SetString("bearer_token", -1, bearerToken)
+		}
 
 		if !kibanaConfig.HasField("username") && username != "" {
 			kibanaConfig.SetString("username", -1, username)
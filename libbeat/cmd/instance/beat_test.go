@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/elastic/beats/libbeat/cfgfile"
+	"github.com/elastic/beats/libbeat/common"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
@@ -95,6 +96,44 @@ func TestInitKibanaConfig(t *testing.T) {
 	assert.Equal(t, "127.0.0.1:5601", host)
 }
 
+func TestInitKibanaConfigAPIKey(t *testing.T) {
+	cases := map[string]struct {
+		kibana   common.MapStr
+		expected common.MapStr
+	}{
+		"inherit api key": {
+			kibana:   common.MapStr{"host": "127.0.0.1:5601"},
+			expected: common.MapStr{"host": "127.0.0.1:5601", "api_key": "id:key"},
+		},
+		"keep basic auth": {
+			kibana:   common.MapStr{"username": "kibana", "password": "secret"},
+			expected: common.MapStr{"username": "kibana", "password": "secret"},
+		},
+		"keep bearer token": {
+			kibana:   common.MapStr{"bearer_token": "token"},
+			expected: common.MapStr{"bearer_token": "token"},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			var config beatConfig
+			err := common.MustNewConfigFrom(common.MapStr{
+				"output.elasticsearch.api_key": "id:key",
+				"setup.kibana":                 test.kibana,
+			}).Unpack(&config)
+			assert.NoError(t, err)
+
+			kibanaConfig, err := initKibanaConfig(config)
+			assert.NoError(t, err)
+
+			var actual common.MapStr
+			assert.NoError(t, kibanaConfig.Unpack(&actual))
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestEmptyMetaJson(t *testing.T) {
 	b, err := NewBeat("filebeat", "testidx", "0.9")
 	if err != nil {
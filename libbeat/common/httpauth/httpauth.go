@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package httpauth sets the credentials of requests sent to Elasticsearch,
+// or to Kibana using Elasticsearch credentials.
+package httpauth
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+)
+
+// Validate checks that at most one authentication method is configured.
+func Validate(username, password, apiKey, bearerToken string) error {
+	methods := 0
+	if username != "" || password != "" {
+		methods++
+	}
+	if apiKey != "" {
+		methods++
+	}
+	if bearerToken != "" {
+		methods++
+	}
+	if methods > 1 {
+		return errors.New("only one of username and password, api_key or bearer_token can be set")
+	}
+	return nil
+}
+
+// SetHeader adds the credentials to a request. An API key, in the id:api_key
+// format, takes precedence over a bearer token, which takes precedence over
+// basic authentication.
+func SetHeader(req *http.Request, username, password, apiKey, bearerToken string) {
+	switch {
+	case apiKey != "":
+		req.Header.Set("Authorization", "ApiKey "+base64.StdEncoding.EncodeToString([]byte(apiKey)))
+	case bearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	case username != "" || password != "":
+		req.SetBasicAuth(username, password)
+	}
+}
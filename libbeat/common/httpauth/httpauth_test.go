@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpauth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("", "", "", ""))
+	assert.NoError(t, Validate("elastic", "changeme", "", ""))
+	assert.NoError(t, Validate("", "", "id:key", ""))
+	assert.NoError(t, Validate("", "", "", "token"))
+
+	assert.Error(t, Validate("elastic", "", "id:key", ""))
+	assert.Error(t, Validate("", "changeme", "", "token"))
+	assert.Error(t, Validate("", "", "id:key", "token"))
+}
+
+func TestSetHeader(t *testing.T) {
+	cases := map[string]struct {
+		username, password, apiKey, bearerToken string
+		expected                                string
+	}{
+		"none":                    {expected: ""},
+		"basic auth":              {username: "elastic", password: "changeme", expected: "Basic ZWxhc3RpYzpjaGFuZ2VtZQ=="},
+		"api key":                 {apiKey: "id:key", expected: "ApiKey aWQ6a2V5"},
+		"bearer token":            {bearerToken: "token", expected: "Bearer token"},
+		"api key precedence":      {username: "elastic", apiKey: "id:key", bearerToken: "token", expected: "ApiKey aWQ6a2V5"},
+		"bearer token precedence": {username: "elastic", password: "changeme", bearerToken: "token", expected: "Bearer token"},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "http://localhost:9200", nil)
+			require.NoError(t, err)
+
+			SetHeader(req, test.username, test.password, test.apiKey, test.bearerToken)
+			assert.Equal(t, test.expected, req.Header.Get("Authorization"))
+		})
+	}
+}
@@ -31,15 +31,18 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/httpauth"
 	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/outputs/transport"
 )
 
 type Connection struct {
-	URL      string
-	Username string
-	Password string
+	URL         string
+	Username    string
+	Password    string
+	APIKey      string
+	BearerToken string
 
 	HTTP    *http.Client
 	Version common.Version
@@ -131,9 +134,11 @@ func NewClientWithConfig(config *ClientConfig) (*Client, error) {
 
 	client := &Client{
 		Connection: Connection{
-			URL:      kibanaURL,
-			Username: username,
-			Password: password,
+			URL:         kibanaURL,
+			Username:    username,
+			Password:    password,
+			APIKey:      config.APIKey,
+			BearerToken: config.BearerToken,
 			HTTP: &http.Client{
 				Transport: &http.Transport{
 					Dial:    dialer.Dial,
@@ -187,9 +192,7 @@ func (conn *Connection) Send(method, extraPath string,
 		return nil, fmt.Errorf("fail to create the HTTP %s request: %+v", method, err)
 	}
 
-	if conn.Username != "" || conn.Password != "" {
-		req.SetBasicAuth(conn.Username, conn.Password)
-	}
+	httpauth.SetHeader(req, conn.Username, conn.Password, conn.APIKey, conn.BearerToken)
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
@@ -20,6 +20,7 @@ package kibana
 import (
 	"time"
 
+	"github.com/elastic/beats/libbeat/common/httpauth"
 	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
 )
 
@@ -31,6 +32,8 @@ type ClientConfig struct {
 	SpaceID       string            `config:"space.id" yaml:"space.id,omitempty"`
 	Username      string            `config:"username" yaml:"username,omitempty"`
 	Password      string            `config:"password" yaml:"password,omitempty"`
+	APIKey        string            `config:"api_key" yaml:"api_key,omitempty"`
+	BearerToken   string            `config:"bearer_token" yaml:"bearer_token,omitempty"`
 	TLS           *tlscommon.Config `config:"ssl" yaml:"ssl"`
 	Timeout       time.Duration     `config:"timeout" yaml:"timeout"`
 	IgnoreVersion bool
@@ -38,13 +41,20 @@ type ClientConfig struct {
 
 var (
 	defaultClientConfig = ClientConfig{
-		Protocol: "http",
-		Host:     "localhost:5601",
-		Path:     "",
-		SpaceID:  "",
-		Username: "",
-		Password: "",
-		Timeout:  90 * time.Second,
-		TLS:      nil,
+		Protocol:    "http",
+		Host:        "localhost:5601",
+		Path:        "",
+		SpaceID:     "",
+		Username:    "",
+		Password:    "",
+		APIKey:      "",
+		BearerToken: "",
+		Timeout:     90 * time.Second,
+		TLS:         nil,
 	}
 )
+
+// Validate checks that at most one authentication method is configured.
+func (c *ClientConfig) Validate() error {
+	return httpauth.Validate(c.Username, c.Password, c.APIKey, c.BearerToken)
+}
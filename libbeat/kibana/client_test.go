@@ -24,6 +24,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/common"
 )
 
 func TestErrorJson(t *testing.T) {
@@ -74,3 +76,61 @@ func TestSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, code)
 	assert.NoError(t, err)
 }
+
+func TestAuthHeader(t *testing.T) {
+	cases := map[string]struct {
+		conn     Connection
+		expected string
+	}{
+		"basic auth":   {Connection{Username: "elastic", Password: "changeme"}, "Basic ZWxhc3RpYzpjaGFuZ2VtZQ=="},
+		"api key":      {Connection{APIKey: "id:key"}, "ApiKey aWQ6a2V5"},
+		"bearer token": {Connection{BearerToken: "token"}, "Bearer token"},
+		"api key precedence": {
+			Connection{Username: "elastic", Password: "changeme", APIKey: "id:key"},
+			"ApiKey aWQ6a2V5",
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			kibanaTs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expected, r.Header.Get("Authorization"))
+				w.Write([]byte(`{}`))
+			}))
+			defer kibanaTs.Close()
+
+			conn := test.conn
+			conn.URL = kibanaTs.URL
+			conn.HTTP = http.DefaultClient
+			code, _, err := conn.Request(http.MethodGet, "", url.Values{}, nil, nil)
+			assert.Equal(t, http.StatusOK, code)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClientConfigAuth(t *testing.T) {
+	for name, test := range map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"basic auth":   {map[string]interface{}{"username": "elastic", "password": "changeme"}, true},
+		"api key":      {map[string]interface{}{"api_key": "id:key"}, true},
+		"bearer token": {map[string]interface{}{"bearer_token": "token"}, true},
+		"basic auth and api key": {
+			map[string]interface{}{"username": "elastic", "password": "changeme", "api_key": "id:key"},
+			false,
+		},
+		"api key and bearer token": {map[string]interface{}{"api_key": "id:key", "bearer_token": "token"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := defaultClientConfig
+			err := common.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -17,7 +17,10 @@
 
 package monitoring
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 var namespaces = NewNamespaces()
 
@@ -40,6 +43,11 @@ func GetNamespace(name string) *Namespace {
 	return namespaces.Get(name)
 }
 
+// GetNamespaceNames returns the names of all namespaces, sorted.
+func GetNamespaceNames() []string {
+	return namespaces.Names()
+}
+
 // SetRegistry sets the registry of the namespace
 func (n *Namespace) SetRegistry(r *Registry) {
 	n.registry = r
@@ -77,3 +85,16 @@ func (n *Namespaces) Get(key string) *Namespace {
 	n.namespaces[key] = newNamespace(key)
 	return n.namespaces[key]
 }
+
+// Names returns the names of all namespaces, sorted.
+func (n *Namespaces) Names() []string {
+	n.Lock()
+	defer n.Unlock()
+
+	names := make([]string, 0, len(n.namespaces))
+	for name := range n.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -22,6 +22,8 @@ import (
 
 	"github.com/elastic/beats/libbeat/monitoring/report"
 
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/httpauth"
 	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
 )
 
@@ -34,6 +36,8 @@ type config struct {
 	Headers          map[string]string `config:"headers"`
 	Username         string            `config:"username"`
 	Password         string            `config:"password"`
+	APIKey           string            `config:"api_key"`
+	BearerToken      string            `config:"bearer_token"`
 	ProxyURL         string            `config:"proxy_url"`
 	CompressionLevel int               `config:"compression_level" validate:"min=0, max=9"`
 	TLS              *tlscommon.Config `config:"ssl"`
@@ -48,6 +52,15 @@ type config struct {
 	Format           report.Format     `config:"_format"`
 }
 
+// validateAuth checks that at most one authentication method is configured.
+// The default username is not used if an API key or a bearer token is set.
+func (c *config) validateAuth(cfg *common.Config) error {
+	if !cfg.HasField("username") && (c.APIKey != "" || c.BearerToken != "") {
+		c.Username = ""
+	}
+	return httpauth.Validate(c.Username, c.Password, c.APIKey, c.BearerToken)
+}
+
 type backoff struct {
 	Init time.Duration
 	Max  time.Duration
@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring/report"
+)
+
+func TestConfigAuth(t *testing.T) {
+	cases := map[string]struct {
+		config   common.MapStr
+		username string
+		err      bool
+	}{
+		"default username":          {common.MapStr{"password": "secret"}, "beats_system", false},
+		"api key":                   {common.MapStr{"api_key": "id:key"}, "", false},
+		"bearer token":              {common.MapStr{"bearer_token": "token"}, "", false},
+		"username and api key":      {common.MapStr{"username": "elastic", "api_key": "id:key"}, "", true},
+		"password and bearer token": {common.MapStr{"password": "secret", "bearer_token": "token"}, "", true},
+		"api key and bearer token":  {common.MapStr{"api_key": "id:key", "bearer_token": "token"}, "", true},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := common.MustNewConfigFrom(test.config)
+			config := defaultConfig(report.Settings{})
+			require.NoError(t, cfg.Unpack(&config))
+
+			err := config.validateAuth(cfg)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.username, config.Username)
+		})
+	}
+}
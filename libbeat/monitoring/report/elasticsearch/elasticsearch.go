@@ -84,6 +84,8 @@ func defaultConfig(settings report.Settings) config {
 		Headers:          nil,
 		Username:         "beats_system",
 		Password:         "",
+		APIKey:           "",
+		BearerToken:      "",
 		ProxyURL:         "",
 		CompressionLevel: 0,
 		TLS:              nil,
@@ -118,6 +120,9 @@ func makeReporter(beat beat.Info, settings report.Settings, cfg *common.Config)
 	if err := cfg.Unpack(&config); err != nil {
 		return nil, err
 	}
+	if err := config.validateAuth(cfg); err != nil {
+		return nil, err
+	}
 
 	// check endpoint availability on startup only every 30 seconds
 	checkRetry := 30 * time.Second
@@ -336,6 +341,8 @@ func makeClient(
 		TLS:              tlsConfig,
 		Username:         config.Username,
 		Password:         config.Password,
+		APIKey:           config.APIKey,
+		BearerToken:      config.BearerToken,
 		Parameters:       params,
 		Headers:          config.Headers,
 		Index:            outil.MakeSelector(outil.ConstSelectorExpr("_xpack")),
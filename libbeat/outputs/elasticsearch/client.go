@@ -30,6 +30,7 @@ import (
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/httpauth"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/outputs"
 	"github.com/elastic/beats/libbeat/outputs/outil"
@@ -58,6 +59,9 @@ type Client struct {
 	compressionLevel int
 	proxyURL         *url.URL
 
+	// sink for events Elasticsearch can't index
+	deadLetter *deadLetter
+
 	observer outputs.Observer
 }
 
@@ -68,6 +72,8 @@ type ClientSettings struct {
 	ProxyDisable       bool
 	TLS                *transport.TLSConfig
 	Username, Password string
+	APIKey             string
+	BearerToken        string
 	EscapeHTML         bool
 	Parameters         map[string]string
 	Headers            map[string]string
@@ -76,6 +82,7 @@ type ClientSettings struct {
 	Timeout            time.Duration
 	CompressionLevel   int
 	Observer           outputs.Observer
+	DeadLetter         *deadLetter
 }
 
 // ConnectCallback defines the type for the function to be called when the Elasticsearch client successfully connects to the cluster
@@ -83,10 +90,12 @@ type ConnectCallback func(client *Client) error
 
 // Connection manages the connection for a given client.
 type Connection struct {
-	URL      string
-	Username string
-	Password string
-	Headers  map[string]string
+	URL         string
+	Username    string
+	Password    string
+	APIKey      string
+	BearerToken string
+	Headers     map[string]string
 
 	http              *http.Client
 	onConnectCallback func() error
@@ -115,6 +124,7 @@ type bulkResultStats struct {
 	duplicates   int // number of events failed with `create` due to ID already being indexed
 	fails        int // number of failed events (can be retried)
 	nonIndexable int // number of failed events (not indexable -> must be dropped)
+	deadLetters  int // number of failed events captured by the dead letter sink
 	tooMany      int // number of events receiving HTTP 429 Too Many Requests
 }
 
@@ -202,10 +212,12 @@ func NewClient(
 
 	client := &Client{
 		Connection: Connection{
-			URL:      s.URL,
-			Username: s.Username,
-			Password: s.Password,
-			Headers:  s.Headers,
+			URL:         s.URL,
+			Username:    s.Username,
+			Password:    s.Password,
+			APIKey:      s.APIKey,
+			BearerToken: s.BearerToken,
+			Headers:     s.Headers,
 			http: &http.Client{
 				Transport: &http.Transport{
 					Dial:    dialer.Dial,
@@ -226,6 +238,7 @@ func NewClient(
 
 		compressionLevel: compression,
 		proxyURL:         s.Proxy,
+		deadLetter:       s.DeadLetter,
 		observer:         s.Observer,
 	}
 
@@ -277,6 +290,8 @@ func (client *Client) Clone() *Client {
 			TLS:              client.tlsConfig,
 			Username:         client.Username,
 			Password:         client.Password,
+			APIKey:           client.APIKey,
+			BearerToken:      client.BearerToken,
 			Parameters:       nil, // XXX: do not pass params?
 			Headers:          client.Headers,
 			Timeout:          client.http.Timeout,
@@ -349,26 +364,37 @@ func (client *Client) publishEvents(
 		time.Now().Sub(begin))
 
 	// check response for transient errors
-	var failedEvents []publisher.Event
+	var failedEvents, deadLetters []publisher.Event
 	var stats bulkResultStats
 	if status != 200 {
 		failedEvents = data
 		stats.fails = len(failedEvents)
 	} else {
 		client.json.init(result.raw)
-		failedEvents, stats = bulkCollectPublishFails(&client.json, data)
+		failedEvents, deadLetters, stats = bulkCollectPublishFails(&client.json, data, client.deadLetter)
+	}
+
+	// Dead letter documents replacing rejected events are indexed right away,
+	// only the ones failing again are retried with the failed events.
+	if len(deadLetters) > 0 {
+		retry, dropped := client.publishDeadLetters(deadLetters, eventType)
+		stats.deadLetters -= len(retry) + dropped
+		stats.nonIndexable += dropped
+		failedEvents = append(failedEvents, retry...)
 	}
 
 	failed := len(failedEvents)
 	if st := client.observer; st != nil {
 		dropped := stats.nonIndexable
 		duplicates := stats.duplicates
-		acked := len(data) - failed - dropped - duplicates
+		deadLettered := stats.deadLetters
+		acked := len(data) - failed - dropped - duplicates - deadLettered
 
 		st.Acked(acked)
 		st.Failed(failed)
 		st.Dropped(dropped)
 		st.Duplicate(duplicates)
+		st.DeadLetter(deadLettered)
 		st.ErrTooMany(stats.tooMany)
 	}
 
@@ -381,6 +407,39 @@ func (client *Client) publishEvents(
 	return nil, nil
 }
 
+// publishDeadLetters indexes the dead letter documents replacing events
+// Elasticsearch rejected. It returns the documents that must be retried and
+// the number of documents that were rejected as well and have been dropped.
+func (client *Client) publishDeadLetters(
+	data []publisher.Event,
+	eventType string,
+) ([]publisher.Event, int) {
+	body := client.encoder
+	body.Reset()
+
+	origCount := len(data)
+	data = bulkEncodePublishRequest(body, client.index, client.pipeline, eventType, data)
+	dropped := origCount - len(data)
+	if len(data) == 0 {
+		return nil, dropped
+	}
+
+	requ := client.bulkRequ
+	requ.Reset(body)
+	status, result, err := client.sendBulkRequest(requ)
+	if err != nil {
+		logp.Err("Failed to index dead letter documents: %s", err)
+		return data, dropped
+	}
+	if status != 200 {
+		return data, dropped
+	}
+
+	client.json.init(result.raw)
+	failed, _, stats := bulkCollectPublishFails(&client.json, data, client.deadLetter)
+	return failed, dropped + stats.nonIndexable
+}
+
 // fillBulkRequest encodes all bulk requests and returns slice of events
 // successfully added to bulk request.
 func bulkEncodePublishRequest(
@@ -414,6 +473,12 @@ func createEventBulkMeta(
 	eventType string,
 	event *beat.Event,
 ) (interface{}, error) {
+	if isDeadLetter(event) {
+		// Dead letter documents are sent to the dead letter index as is.
+		index, _ := event.Meta[deadLetterIndexKey].(string)
+		return bulkIndexAction{bulkEventMeta{Index: index, DocType: eventType}}, nil
+	}
+
 	pipeline, err := getPipeline(event, pipelineSel)
 	if err != nil {
 		err := fmt.Errorf("failed to select pipeline: %v", err)
@@ -469,14 +534,16 @@ func getPipeline(event *beat.Event, pipelineSel *outil.Selector) (string, error)
 // bulkCollectPublishFails checks per item errors returning all events
 // to be tried again due to error code returned for that items. If indexing an
 // event failed due to some error in the event itself (e.g. does not respect mapping),
-// the event will be dropped.
+// the event will be dropped, or passed to the dead letter sink if one is configured.
+// Events replaced by a document for the dead letter index are returned separately.
 func bulkCollectPublishFails(
 	reader *jsonReader,
 	data []publisher.Event,
-) ([]publisher.Event, bulkResultStats) {
+	deadLetter *deadLetter,
+) ([]publisher.Event, []publisher.Event, bulkResultStats) {
 	if err := reader.expectDict(); err != nil {
 		logp.Err("Failed to parse bulk response: expected JSON object")
-		return nil, bulkResultStats{}
+		return nil, nil, bulkResultStats{}
 	}
 
 	// find 'items' field in response
@@ -484,12 +551,12 @@ func bulkCollectPublishFails(
 		kind, name, err := reader.nextFieldName()
 		if err != nil {
 			logp.Err("Failed to parse bulk response")
-			return nil, bulkResultStats{}
+			return nil, nil, bulkResultStats{}
 		}
 
 		if kind == dictEnd {
 			logp.Err("Failed to parse bulk response: no 'items' field in response")
-			return nil, bulkResultStats{}
+			return nil, nil, bulkResultStats{}
 		}
 
 		// found items array -> continue
@@ -503,16 +570,17 @@ func bulkCollectPublishFails(
 	// check items field is an array
 	if err := reader.expectArray(); err != nil {
 		logp.Err("Failed to parse bulk response: expected items array")
-		return nil, bulkResultStats{}
+		return nil, nil, bulkResultStats{}
 	}
 
 	count := len(data)
 	failed := data[:0]
+	var deadLetters []publisher.Event
 	stats := bulkResultStats{}
 	for i := 0; i < count; i++ {
 		status, msg, err := itemStatus(reader)
 		if err != nil {
-			return nil, bulkResultStats{}
+			return nil, nil, bulkResultStats{}
 		}
 
 		if status < 300 {
@@ -533,6 +601,16 @@ func bulkCollectPublishFails(
 			} else {
 				// hard failure, don't collect
 				logp.Warn("Cannot index event %#v (status=%v): %s", data[i], status, msg)
+				if deadLetter != nil {
+					if captured, index := deadLetter.handle(&data[i], status, msg); captured {
+						if index {
+							debugf("Bulk item re-routed to the dead letter index (i=%v)", i)
+							deadLetters = append(deadLetters, data[i])
+						}
+						stats.deadLetters++
+						continue
+					}
+				}
 				stats.nonIndexable++
 				continue
 			}
@@ -543,7 +621,7 @@ func bulkCollectPublishFails(
 		failed = append(failed, data[i])
 	}
 
-	return failed, stats
+	return failed, deadLetters, stats
 }
 
 func itemStatus(reader *jsonReader) (int, []byte, error) {
@@ -797,9 +875,7 @@ func (conn *Connection) execRequest(
 
 func (conn *Connection) execHTTPRequest(req *http.Request) (int, []byte, error) {
 	req.Header.Add("Accept", "application/json")
-	if conn.Username != "" || conn.Password != "" {
-		req.SetBasicAuth(conn.Username, conn.Password)
-	}
+	httpauth.SetHeader(req, conn.Username, conn.Password, conn.APIKey, conn.BearerToken)
 
 	for name, value := range conn.Headers {
 		req.Header.Add(name, value)
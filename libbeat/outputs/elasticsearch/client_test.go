@@ -103,7 +103,7 @@ func TestCollectPublishFailsNone(t *testing.T) {
 	}
 
 	reader := newJSONReader(response)
-	res, _ := bulkCollectPublishFails(reader, events)
+	res, _, _ := bulkCollectPublishFails(reader, events, nil)
 	assert.Equal(t, 0, len(res))
 }
 
@@ -121,7 +121,7 @@ func TestCollectPublishFailMiddle(t *testing.T) {
 	events := []publisher.Event{event, eventFail, event}
 
 	reader := newJSONReader(response)
-	res, stats := bulkCollectPublishFails(reader, events)
+	res, _, stats := bulkCollectPublishFails(reader, events, nil)
 	assert.Equal(t, 1, len(res))
 	if len(res) == 1 {
 		assert.Equal(t, eventFail, res[0])
@@ -142,7 +142,7 @@ func TestCollectPublishFailAll(t *testing.T) {
 	events := []publisher.Event{event, event, event}
 
 	reader := newJSONReader(response)
-	res, stats := bulkCollectPublishFails(reader, events)
+	res, _, stats := bulkCollectPublishFails(reader, events, nil)
 	assert.Equal(t, 3, len(res))
 	assert.Equal(t, events, res)
 	assert.Equal(t, stats, bulkResultStats{fails: 3, tooMany: 3})
@@ -184,7 +184,7 @@ func TestCollectPipelinePublishFail(t *testing.T) {
 	events := []publisher.Event{event}
 
 	reader := newJSONReader(response)
-	res, _ := bulkCollectPublishFails(reader, events)
+	res, _, _ := bulkCollectPublishFails(reader, events, nil)
 	assert.Equal(t, 1, len(res))
 	assert.Equal(t, events, res)
 }
@@ -204,7 +204,7 @@ func BenchmarkCollectPublishFailsNone(b *testing.B) {
 	reader := newJSONReader(nil)
 	for i := 0; i < b.N; i++ {
 		reader.init(response)
-		res, _ := bulkCollectPublishFails(reader, events)
+		res, _, _ := bulkCollectPublishFails(reader, events, nil)
 		if len(res) != 0 {
 			b.Fail()
 		}
@@ -227,7 +227,7 @@ func BenchmarkCollectPublishFailMiddle(b *testing.B) {
 	reader := newJSONReader(nil)
 	for i := 0; i < b.N; i++ {
 		reader.init(response)
-		res, _ := bulkCollectPublishFails(reader, events)
+		res, _, _ := bulkCollectPublishFails(reader, events, nil)
 		if len(res) != 1 {
 			b.Fail()
 		}
@@ -249,7 +249,7 @@ func BenchmarkCollectPublishFailAll(b *testing.B) {
 	reader := newJSONReader(nil)
 	for i := 0; i < b.N; i++ {
 		reader.init(response)
-		res, _ := bulkCollectPublishFails(reader, events)
+		res, _, _ := bulkCollectPublishFails(reader, events, nil)
 		if len(res) != 3 {
 			b.Fail()
 		}
@@ -297,6 +297,77 @@ func TestClientWithHeaders(t *testing.T) {
 	assert.Equal(t, 2, requestCount)
 }
 
+func TestClientAuthHeader(t *testing.T) {
+	cases := map[string]struct {
+		settings ClientSettings
+		expected string
+	}{
+		"basic auth": {
+			settings: ClientSettings{Username: "elastic", Password: "changeme"},
+			expected: "Basic ZWxhc3RpYzpjaGFuZ2VtZQ==",
+		},
+		"api key": {
+			settings: ClientSettings{APIKey: "id:key"},
+			expected: "ApiKey aWQ6a2V5",
+		},
+		"bearer token": {
+			settings: ClientSettings{BearerToken: "token"},
+			expected: "Bearer token",
+		},
+		"no credentials": {},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			var authorization string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				fmt.Fprintln(w, "{}")
+			}))
+			defer ts.Close()
+
+			test.settings.URL = ts.URL
+			client, err := NewClient(test.settings, nil)
+			require.NoError(t, err)
+
+			_, _, err = client.Connection.Request("GET", "/", "", nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, authorization)
+
+			// clones keep the credentials
+			_, _, err = client.Clone().Connection.Request("GET", "/", "", nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, authorization)
+		})
+	}
+}
+
+func TestConfigAuthValidation(t *testing.T) {
+	cases := map[string]struct {
+		config common.MapStr
+		valid  bool
+	}{
+		"basic auth":         {common.MapStr{"username": "elastic", "password": "changeme"}, true},
+		"api key":            {common.MapStr{"api_key": "id:key"}, true},
+		"bearer token":       {common.MapStr{"bearer_token": "token"}, true},
+		"api key and user":   {common.MapStr{"api_key": "id:key", "username": "elastic"}, false},
+		"api key and token":  {common.MapStr{"api_key": "id:key", "bearer_token": "token"}, false},
+		"token and password": {common.MapStr{"bearer_token": "token", "password": "changeme"}, false},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := defaultConfig
+			err := common.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestAddToURL(t *testing.T) {
 	type Test struct {
 		url      string
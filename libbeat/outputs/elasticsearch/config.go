@@ -20,6 +20,7 @@ package elasticsearch
 import (
 	"time"
 
+	"github.com/elastic/beats/libbeat/common/httpauth"
 	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
 )
 
@@ -30,6 +31,8 @@ type elasticsearchConfig struct {
 	Headers          map[string]string `config:"headers"`
 	Username         string            `config:"username"`
 	Password         string            `config:"password"`
+	APIKey           string            `config:"api_key"`
+	BearerToken      string            `config:"bearer_token"`
 	ProxyURL         string            `config:"proxy_url"`
 	ProxyDisable     bool              `config:"proxy_disable"`
 	LoadBalance      bool              `config:"loadbalance"`
@@ -40,6 +43,7 @@ type elasticsearchConfig struct {
 	MaxRetries       int               `config:"max_retries"`
 	Timeout          time.Duration     `config:"timeout"`
 	Backoff          Backoff           `config:"backoff"`
+	DeadLetter       *deadLetterConfig `config:"dead_letter"`
 }
 
 type Backoff struct {
@@ -60,6 +64,8 @@ var (
 		Params:           nil,
 		Username:         "",
 		Password:         "",
+		APIKey:           "",
+		BearerToken:      "",
 		Timeout:          90 * time.Second,
 		MaxRetries:       3,
 		CompressionLevel: 0,
@@ -74,6 +80,10 @@ var (
 )
 
 func (c *elasticsearchConfig) Validate() error {
+	if err := httpauth.Validate(c.Username, c.Password, c.APIKey, c.BearerToken); err != nil {
+		return err
+	}
+
 	if c.ProxyURL != "" && !c.ProxyDisable {
 		if _, err := parseProxyURL(c.ProxyURL); err != nil {
 			return err
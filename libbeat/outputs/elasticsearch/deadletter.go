@@ -0,0 +1,259 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/file"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/testing"
+)
+
+// deadLetterIndexKey is the metadata key holding the index of an event
+// re-routed to the dead letter index.
+const deadLetterIndexKey = "dead_letter_index"
+
+type deadLetterConfig struct {
+	Index *fmtstr.EventFormatString `config:"index"`
+	File  *common.Config            `config:"file"`
+}
+
+type deadLetterFileConfig struct {
+	Path          string `config:"path" validate:"required"`
+	Filename      string `config:"filename"`
+	RotateEveryKb uint   `config:"rotate_every_kb" validate:"min=1"`
+	NumberOfFiles uint   `config:"number_of_files"`
+	Permissions   uint32 `config:"permissions"`
+}
+
+var defaultDeadLetterFileConfig = deadLetterFileConfig{
+	RotateEveryKb: 10 * 1024,
+	NumberOfFiles: 7,
+	Permissions:   0600,
+}
+
+func (c *deadLetterConfig) Validate() error {
+	if (c.Index == nil) == (c.File == nil) {
+		return errors.New("exactly one of dead_letter.index or dead_letter.file must be set")
+	}
+	return nil
+}
+
+func (c *deadLetterFileConfig) Validate() error {
+	if c.NumberOfFiles < 2 || c.NumberOfFiles > file.MaxBackupsLimit {
+		return errors.Errorf("dead_letter.file.number_of_files should be between 2 and %v",
+			file.MaxBackupsLimit)
+	}
+	return nil
+}
+
+// deadLetter captures the events Elasticsearch permanently rejected. The
+// events are either replaced by a document describing the failure that is
+// sent to the dead letter index, or that document is written to a local
+// file.
+type deadLetter struct {
+	index    *fmtstr.EventFormatString
+	indexSel outputs.IndexSelector
+	rotator  *file.Rotator
+	log      *logp.Logger
+
+	refs int32 // number of open output clients sharing the sink
+}
+
+func newDeadLetter(
+	beat beat.Info,
+	config *deadLetterConfig,
+	indexSel outputs.IndexSelector,
+) (*deadLetter, error) {
+	dl := &deadLetter{
+		index:    config.Index,
+		indexSel: indexSel,
+		log:      logp.NewLogger("elasticsearch.dead_letter"),
+	}
+
+	if config.File != nil {
+		c := defaultDeadLetterFileConfig
+		if err := config.File.Unpack(&c); err != nil {
+			return nil, errors.Wrap(err, "invalid dead_letter.file configuration")
+		}
+
+		filename := c.Filename
+		if filename == "" {
+			filename = beat.Beat + "-dead-letter"
+		}
+		path := filepath.Join(c.Path, filename)
+
+		var err error
+		dl.rotator, err = file.NewFileRotator(
+			path,
+			file.MaxSizeBytes(c.RotateEveryKb*1024),
+			file.MaxBackups(c.NumberOfFiles),
+			file.Permissions(os.FileMode(c.Permissions)),
+			file.RotateOnStartup(false),
+			file.WithLogger(logp.NewLogger("rotator").With(logp.Namespace("rotator"))),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create the dead letter file")
+		}
+		dl.log.Infof("Events rejected by Elasticsearch are written to %v", path)
+	}
+
+	return dl, nil
+}
+
+// isDeadLetter reports whether an event is already a dead letter document.
+func isDeadLetter(event *beat.Event) bool {
+	_, found := event.Meta[deadLetterIndexKey]
+	return found
+}
+
+// handle captures an event rejected with the given status and error. It
+// reports whether the event was captured, and whether it was replaced by a
+// dead letter document that must still be indexed.
+func (dl *deadLetter) handle(event *publisher.Event, status int, msg []byte) (captured, index bool) {
+	content := &event.Content
+	if isDeadLetter(content) {
+		dl.log.Errorf("Dropping dead letter event rejected by Elasticsearch (status=%v): %s", status, msg)
+		return false, false
+	}
+
+	doc, err := dl.document(content, status, msg)
+	if err != nil {
+		dl.log.Errorf("Failed to create dead letter document: %v", err)
+		return false, false
+	}
+
+	if dl.rotator != nil {
+		doc["@timestamp"] = content.Timestamp
+		line, err := json.Marshal(doc)
+		if err == nil {
+			_, err = dl.rotator.Write(append(line, '\n'))
+		}
+		if err != nil {
+			dl.log.Errorf("Failed to write to the dead letter file: %v", err)
+			return false, false
+		}
+		return true, false
+	}
+
+	deadLetterEvent := beat.Event{Timestamp: content.Timestamp, Fields: doc}
+	indexName, err := dl.index.Run(&deadLetterEvent)
+	if err != nil {
+		dl.log.Errorf("Failed to select the dead letter index: %v", err)
+		return false, false
+	}
+	deadLetterEvent.Meta = common.MapStr{deadLetterIndexKey: indexName}
+	event.Content = deadLetterEvent
+	return true, true
+}
+
+// document creates the document describing a rejected event. It contains the
+// original event as a JSON string, the error returned by Elasticsearch and
+// the index the event was sent to.
+func (dl *deadLetter) document(event *beat.Event, status int, msg []byte) (common.MapStr, error) {
+	original, err := json.Marshal(event.Fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the rejected event")
+	}
+
+	var itemErr struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(msg, &itemErr); err != nil || itemErr.Type == "" {
+		itemErr.Reason = string(msg)
+	}
+
+	doc := common.MapStr{
+		"message": string(original),
+		"error": common.MapStr{
+			"message": itemErr.Reason,
+		},
+		"dead_letter": common.MapStr{
+			"status": status,
+		},
+	}
+	if itemErr.Type != "" {
+		doc.Put("error.type", itemErr.Type)
+	}
+	if index, err := dl.indexSel.Select(event); err == nil {
+		doc.Put("dead_letter.index", index)
+	}
+	return doc, nil
+}
+
+// close closes the dead letter file. It can be called on a nil dead letter
+// sink, for outputs without one.
+func (dl *deadLetter) close() error {
+	if dl == nil || dl.rotator == nil {
+		return nil
+	}
+	return dl.rotator.Close()
+}
+
+// closeWith wraps the clients of the output, so the dead letter sink they
+// share is closed once all of them have been closed. The clients of an output
+// are closed by the pipeline only when the output is shut down, while the
+// Elasticsearch clients are also closed on every reconnect.
+func (dl *deadLetter) closeWith(clients []outputs.Client) []outputs.Client {
+	dl.refs = int32(len(clients))
+	wrapped := make([]outputs.Client, len(clients))
+	for i, client := range clients {
+		wrapped[i] = &deadLetterClient{NetworkClient: client.(outputs.NetworkClient), deadLetter: dl}
+	}
+	return wrapped
+}
+
+func (dl *deadLetter) release() {
+	if atomic.AddInt32(&dl.refs, -1) != 0 {
+		return
+	}
+	if err := dl.close(); err != nil {
+		dl.log.Errorf("Failed to close the dead letter file: %v", err)
+	}
+}
+
+type deadLetterClient struct {
+	outputs.NetworkClient
+	deadLetter *deadLetter
+}
+
+func (c *deadLetterClient) Close() error {
+	err := c.NetworkClient.Close()
+	c.deadLetter.release()
+	return err
+}
+
+func (c *deadLetterClient) Test(d testing.Driver) {
+	client, ok := c.NetworkClient.(testing.Testable)
+	if !ok {
+		d.Fatal("output", errors.New("client doesn't support testing"))
+	}
+	client.Test(d)
+}
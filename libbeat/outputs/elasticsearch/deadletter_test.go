@@ -0,0 +1,253 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+var mappingConflict = []byte(`
+    { "items": [
+      {"index": {"status": 200}},
+      {"index": {"status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse field [port]"}}}
+    ]}
+  `)
+
+func TestCollectPublishFailDeadLetterIndex(t *testing.T) {
+	var config deadLetterConfig
+	require.NoError(t, common.MustNewConfigFrom(common.MapStr{"index": "dead-letter-%{+yyyy.MM.dd}"}).Unpack(&config))
+	dl, err := newDeadLetter(beat.Info{Beat: "test"}, &config, testIndexSelector())
+	require.NoError(t, err)
+
+	ts := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+	event := publisher.Event{Content: beat.Event{Timestamp: ts, Fields: common.MapStr{"port": 80}}}
+	eventFail := publisher.Event{Content: beat.Event{Timestamp: ts, Fields: common.MapStr{"port": "http"}}}
+	events := []publisher.Event{event, eventFail}
+
+	res, deadLetters, stats := bulkCollectPublishFails(newJSONReader(mappingConflict), events, dl)
+	assert.Equal(t, bulkResultStats{acked: 1, deadLetters: 1}, stats)
+	assert.Empty(t, res)
+	require.Len(t, deadLetters, 1)
+
+	deadLetter := deadLetters[0].Content
+	assert.Equal(t, ts, deadLetter.Timestamp)
+	assert.Equal(t, common.MapStr{deadLetterIndexKey: "dead-letter-2019.07.01"}, deadLetter.Meta)
+	assert.Equal(t, common.MapStr{
+		"message": `{"port":"http"}`,
+		"error": common.MapStr{
+			"type":    "mapper_parsing_exception",
+			"message": "failed to parse field [port]",
+		},
+		"dead_letter": common.MapStr{
+			"status": 400,
+			"index":  "test",
+		},
+	}, deadLetter.Fields)
+
+	// The dead letter document is sent to the dead letter index, without
+	// pipeline.
+	pipeline := outil.MakeSelector(outil.ConstSelectorExpr("pipeline"))
+	meta, err := createEventBulkMeta(testIndexSelector(), &pipeline, "", &deadLetter)
+	require.NoError(t, err)
+	assert.Equal(t, bulkIndexAction{bulkEventMeta{Index: "dead-letter-2019.07.01"}}, meta)
+
+	// A rejected dead letter document is dropped.
+	res, deadLetters, stats = bulkCollectPublishFails(newJSONReader(mappingConflict), []publisher.Event{event, deadLetters[0]}, dl)
+	assert.Empty(t, res)
+	assert.Empty(t, deadLetters)
+	assert.Equal(t, bulkResultStats{acked: 1, nonIndexable: 1}, stats)
+}
+
+func TestCollectPublishFailDeadLetterFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dead-letter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var config deadLetterConfig
+	require.NoError(t, common.MustNewConfigFrom(common.MapStr{"file.path": dir}).Unpack(&config))
+	dl, err := newDeadLetter(beat.Info{Beat: "test"}, &config, testIndexSelector())
+	require.NoError(t, err)
+	defer dl.close()
+
+	ts := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+	event := publisher.Event{Content: beat.Event{Timestamp: ts, Fields: common.MapStr{"port": 80}}}
+	eventFail := publisher.Event{Content: beat.Event{Timestamp: ts, Fields: common.MapStr{"port": "http"}}}
+
+	res, deadLetters, stats := bulkCollectPublishFails(newJSONReader(mappingConflict), []publisher.Event{event, eventFail}, dl)
+	assert.Empty(t, res)
+	assert.Empty(t, deadLetters)
+	assert.Equal(t, bulkResultStats{acked: 1, deadLetters: 1}, stats)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "test-dead-letter"))
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, map[string]interface{}{
+		"@timestamp": "2019-07-01T12:00:00Z",
+		"message":    `{"port":"http"}`,
+		"error": map[string]interface{}{
+			"type":    "mapper_parsing_exception",
+			"message": "failed to parse field [port]",
+		},
+		"dead_letter": map[string]interface{}{
+			"status": float64(400),
+			"index":  "test",
+		},
+	}, doc)
+}
+
+func TestDeadLetterUnstructuredError(t *testing.T) {
+	var config deadLetterConfig
+	require.NoError(t, common.MustNewConfigFrom(common.MapStr{"index": "dead-letter"}).Unpack(&config))
+	dl, err := newDeadLetter(beat.Info{Beat: "test"}, &config, testIndexSelector())
+	require.NoError(t, err)
+
+	event := publisher.Event{Content: beat.Event{Fields: common.MapStr{"a": 1}}}
+	captured, index := dl.handle(&event, 400, []byte(`"test error"`))
+	require.True(t, captured)
+	require.True(t, index)
+	assert.Equal(t, common.MapStr{"message": `"test error"`}, event.Content.Fields["error"])
+}
+
+func TestPublishDeadLetterIndex(t *testing.T) {
+	var bulks []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bulks = append(bulks, string(body))
+		if len(bulks) == 1 {
+			w.Write(mappingConflict)
+			return
+		}
+		fmt.Fprintln(w, `{"items": [{"index": {"status": 201}}]}`)
+	}))
+	defer ts.Close()
+
+	var config deadLetterConfig
+	require.NoError(t, common.MustNewConfigFrom(common.MapStr{"index": "dead-letter"}).Unpack(&config))
+	dl, err := newDeadLetter(beat.Info{Beat: "test"}, &config, testIndexSelector())
+	require.NoError(t, err)
+
+	reg := monitoring.NewRegistry()
+	client, err := NewClient(ClientSettings{
+		URL:        ts.URL,
+		Index:      testIndexSelector(),
+		Observer:   outputs.NewStats(reg),
+		DeadLetter: dl,
+	}, nil)
+	require.NoError(t, err)
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"port": 80}},
+		beat.Event{Fields: common.MapStr{"port": "http"}},
+	)
+
+	// The dead letter document is indexed in the same publish call, so the
+	// batch is ACKed without error.
+	require.NoError(t, client.Publish(batch))
+	assert.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	require.Len(t, bulks, 2)
+	assert.Contains(t, bulks[1], `"_index":"dead-letter"`)
+	assert.Contains(t, bulks[1], `"status":400`)
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(1), snapshot.Ints["events.acked"])
+	assert.Equal(t, int64(1), snapshot.Ints["events.dead_letter"])
+	assert.Equal(t, int64(0), snapshot.Ints["events.failed"])
+	assert.Equal(t, int64(0), snapshot.Ints["events.active"])
+}
+
+func TestDeadLetterCloseWith(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dead-letter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var config deadLetterConfig
+	require.NoError(t, common.MustNewConfigFrom(common.MapStr{"file.path": dir}).Unpack(&config))
+	dl, err := newDeadLetter(beat.Info{Beat: "test"}, &config, testIndexSelector())
+	require.NoError(t, err)
+	event := publisher.Event{Content: beat.Event{Fields: common.MapStr{"a": 1}}}
+	captured, _ := dl.handle(&event, 400, []byte(`"test error"`))
+	require.True(t, captured)
+
+	clients := dl.closeWith([]outputs.Client{&mockNetworkClient{}, &mockNetworkClient{}})
+
+	// The sink stays open until all the clients of the output are closed.
+	require.NoError(t, clients[0].Close())
+	assert.Equal(t, int32(1), dl.refs)
+	require.NoError(t, clients[1].Close())
+	assert.Equal(t, int32(0), dl.refs)
+
+	for _, client := range clients {
+		assert.True(t, client.(*deadLetterClient).NetworkClient.(*mockNetworkClient).closed)
+	}
+}
+
+func TestDeadLetterConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"no sink":       {},
+		"both sinks":    {"index": "dead-letter", "file.path": "/tmp"},
+		"missing path":  {"file.filename": "dead-letter"},
+		"too few files": {"file.path": "/tmp", "file.number_of_files": 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var c deadLetterConfig
+			err := common.MustNewConfigFrom(config).Unpack(&c)
+			if err == nil {
+				_, err = newDeadLetter(beat.Info{Beat: "test"}, &c, testIndexSelector())
+			}
+			assert.Error(t, err)
+		})
+	}
+}
+
+type mockNetworkClient struct {
+	closed bool
+}
+
+func (c *mockNetworkClient) Connect() error                { return nil }
+func (c *mockNetworkClient) Close() error                  { c.closed = true; return nil }
+func (c *mockNetworkClient) Publish(publisher.Batch) error { return nil }
+func (c *mockNetworkClient) String() string                { return "mock" }
+
+func testIndexSelector() outil.Selector {
+	return outil.MakeSelector(outil.ConstSelectorExpr("test"))
+}
@@ -180,11 +180,19 @@ func makeES(
 		params = nil
 	}
 
+	var dl *deadLetter
+	if config.DeadLetter != nil {
+		if dl, err = newDeadLetter(beat, config.DeadLetter, index); err != nil {
+			return outputs.Fail(err)
+		}
+	}
+
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
 		esURL, err := common.MakeURL(config.Protocol, config.Path, host, 9200)
 		if err != nil {
 			logp.Err("Invalid host param set: %s, Error: %v", host, err)
+			dl.close()
 			return outputs.Fail(err)
 		}
 
@@ -198,14 +206,18 @@ func makeES(
 			TLS:              tlsConfig,
 			Username:         config.Username,
 			Password:         config.Password,
+			APIKey:           config.APIKey,
+			BearerToken:      config.BearerToken,
 			Parameters:       params,
 			Headers:          config.Headers,
 			Timeout:          config.Timeout,
 			CompressionLevel: config.CompressionLevel,
 			Observer:         observer,
 			EscapeHTML:       config.EscapeHTML,
+			DeadLetter:       dl,
 		}, &connectCallbackRegistry)
 		if err != nil {
+			dl.close()
 			return outputs.Fail(err)
 		}
 
@@ -213,7 +225,15 @@ func makeES(
 		clients[i] = client
 	}
 
-	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
+	group, err := outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
+	if err != nil {
+		dl.close()
+		return group, err
+	}
+	if dl != nil {
+		group.Clients = dl.closeWith(group.Clients)
+	}
+	return group, nil
 }
 
 func buildSelectors(
@@ -319,6 +339,8 @@ func NewElasticsearchClients(cfg *common.Config) ([]Client, error) {
 			TLS:              tlsConfig,
 			Username:         config.Username,
 			Password:         config.Password,
+			APIKey:           config.APIKey,
+			BearerToken:      config.BearerToken,
 			Parameters:       params,
 			Headers:          config.Headers,
 			Timeout:          config.Timeout,
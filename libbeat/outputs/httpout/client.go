@@ -0,0 +1,327 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/testing"
+)
+
+type client struct {
+	url      string
+	username string
+	password string
+	headers  map[string]string
+	http     *http.Client
+
+	index            string
+	codec            codec.Codec
+	format           bodyFormat
+	compressionLevel int
+
+	tlsConfig *transport.TLSConfig
+	timeout   time.Duration
+	observer  outputs.Observer
+
+	body bytes.Buffer
+}
+
+// clientSettings configures a client.
+type clientSettings struct {
+	URL              string
+	Proxy            *url.URL
+	ProxyDisable     bool
+	TLS              *transport.TLSConfig
+	Username         string
+	Password         string
+	Parameters       map[string]string
+	Headers          map[string]string
+	Timeout          time.Duration
+	CompressionLevel int
+	Format           bodyFormat
+	Index            string
+	Codec            codec.Codec
+	Observer         outputs.Observer
+}
+
+// errPermanent is used to mark batches rejected by the remote endpoint, that
+// must not be retried.
+var errPermanent = errors.New("request rejected")
+
+func newClient(s clientSettings) (*client, error) {
+	var proxy func(*http.Request) (*url.URL, error)
+	if !s.ProxyDisable {
+		proxy = http.ProxyFromEnvironment
+		if s.Proxy != nil {
+			proxy = http.ProxyURL(s.Proxy)
+		}
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse http output URL: %v", err)
+	}
+	if u.User != nil {
+		s.Username = u.User.Username()
+		s.Password, _ = u.User.Password()
+		u.User = nil
+	}
+	if len(s.Parameters) > 0 {
+		values := u.Query()
+		for k, v := range s.Parameters {
+			values.Add(k, v)
+		}
+		u.RawQuery = values.Encode()
+	}
+	s.URL = u.String()
+
+	logp.Info("HTTP output url: %s", s.URL)
+
+	var dialer, tlsDialer transport.Dialer
+	dialer = transport.NetDialer(s.Timeout)
+	tlsDialer, err = transport.TLSDialer(dialer, s.TLS, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	observer := s.Observer
+	if observer == nil {
+		observer = outputs.NewNilObserver()
+	} else {
+		dialer = transport.StatsDialer(dialer, observer)
+		tlsDialer = transport.StatsDialer(tlsDialer, observer)
+	}
+
+	if s.CompressionLevel > 0 {
+		if _, err := gzip.NewWriterLevel(ioutil.Discard, s.CompressionLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	return &client{
+		url:      s.URL,
+		username: s.Username,
+		password: s.Password,
+		headers:  s.Headers,
+		http: &http.Client{
+			Transport: &http.Transport{
+				Dial:    dialer.Dial,
+				DialTLS: tlsDialer.Dial,
+				Proxy:   proxy,
+			},
+			Timeout: s.Timeout,
+		},
+		index:            s.Index,
+		codec:            s.Codec,
+		format:           s.Format,
+		compressionLevel: s.CompressionLevel,
+		tlsConfig:        s.TLS,
+		timeout:          s.Timeout,
+		observer:         observer,
+	}, nil
+}
+
+// Connect is a no-op. Connections are established by the HTTP client on
+// demand.
+func (c *client) Connect() error {
+	return nil
+}
+
+// Close closes all idle connections.
+func (c *client) Close() error {
+	if t, ok := c.http.Transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Publish sends all events in the batch with a single request.
+// The batch is retried if the request fails or the endpoint responds with
+// 429 or a 5xx status code. All other non 2xx responses drop the batch.
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	st := c.observer
+	st.NewBatch(len(events))
+
+	count, err := c.encodeBody(events)
+	if dropped := len(events) - count; dropped > 0 {
+		st.Dropped(dropped)
+	}
+	if count == 0 {
+		batch.ACK()
+		return err
+	}
+
+	status, err := c.send()
+	switch {
+	case err == nil:
+		batch.ACK()
+		st.Acked(count)
+		return nil
+	case err == errPermanent:
+		logp.Err("Dropping %v events, rejected by %v with status %v", count, c.url, status)
+		batch.ACK()
+		st.Dropped(count)
+		return nil
+	default:
+		if status == http.StatusTooManyRequests {
+			st.ErrTooMany(count)
+		} else {
+			st.Failed(count)
+		}
+		batch.Retry()
+		return err
+	}
+}
+
+// encodeBody serializes the events into the request body buffer. Events
+// failing to be encoded are dropped. The number of encoded events is returned.
+func (c *client) encodeBody(events []publisher.Event) (int, error) {
+	c.body.Reset()
+
+	var w io.Writer = &c.body
+	var gz *gzip.Writer
+	if c.compressionLevel > 0 {
+		gz, _ = gzip.NewWriterLevel(&c.body, c.compressionLevel)
+		w = gz
+	}
+
+	count := 0
+	if c.format == formatJSONArray {
+		w.Write([]byte{'['})
+	}
+	for i := range events {
+		event := &events[i]
+		serialized, err := c.codec.Encode(c.index, &event.Content)
+		if err != nil {
+			if event.Guaranteed() {
+				logp.Critical("Failed to serialize the event: %v", err)
+			} else {
+				logp.Warn("Failed to serialize the event: %v", err)
+			}
+			debugf("Failed event: %v", event)
+			continue
+		}
+
+		if c.format == formatJSONArray && count > 0 {
+			w.Write([]byte{','})
+		}
+		w.Write(serialized)
+		if c.format == formatNDJSON {
+			w.Write([]byte{'\n'})
+		}
+		count++
+	}
+	if c.format == formatJSONArray {
+		w.Write([]byte{']'})
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// send posts the current body to the endpoint.
+func (c *client) send() (int, error) {
+	req, err := http.NewRequest("POST", c.url, bytes.NewReader(c.body.Bytes()))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", c.format.contentType())
+	req.Header.Set("Accept", "application/json")
+	if c.compressionLevel > 0 {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.observer.WriteError(err)
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	c.observer.WriteBytes(c.body.Len())
+	n, _ := io.Copy(ioutil.Discard, resp.Body)
+	c.observer.ReadBytes(int(n))
+
+	status := resp.StatusCode
+	switch {
+	case status >= 200 && status < 300:
+		return status, nil
+	case status == http.StatusTooManyRequests || status >= 500:
+		return status, fmt.Errorf("%v responded with status %v", c.url, resp.Status)
+	default:
+		return status, errPermanent
+	}
+}
+
+func (c *client) Test(d testing.Driver) {
+	d.Run("http: "+c.url, func(d testing.Driver) {
+		u, err := url.Parse(c.url)
+		d.Fatal("parse url", err)
+
+		address := u.Hostname()
+		if u.Port() != "" {
+			address += ":" + u.Port()
+		}
+		d.Run("connection", func(d testing.Driver) {
+			netDialer := transport.TestNetDialer(d, c.timeout)
+			_, err = netDialer.Dial("tcp", address)
+			d.Fatal("dial up", err)
+		})
+
+		if u.Scheme != "https" {
+			d.Warn("TLS", "secure connection disabled")
+		} else {
+			d.Run("TLS", func(d testing.Driver) {
+				netDialer := transport.NetDialer(c.timeout)
+				tlsDialer, err := transport.TestTLSDialer(d, netDialer, c.tlsConfig, c.timeout)
+				_, err = tlsDialer.Dial("tcp", address)
+				d.Fatal("dial up", err)
+			})
+		}
+	})
+}
+
+func (c *client) String() string {
+	return "http(" + c.url + ")"
+}
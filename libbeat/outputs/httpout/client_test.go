@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	_ "github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+)
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+func TestPublishNDJSON(t *testing.T) {
+	requests, server := startServer(http.StatusOK)
+	defer server.Close()
+
+	client := makeTestClient(t, server.URL, common.MapStr{
+		"headers": map[string]string{"X-Custom": "value"},
+	})
+	batch := outest.NewBatch(makeEvent(1), makeEvent(2))
+	require.NoError(t, client.Publish(batch))
+	assertSignal(t, batch, outest.BatchACK)
+
+	req := <-requests
+	assert.Equal(t, "application/x-ndjson", req.header.Get("Content-Type"))
+	assert.Equal(t, "value", req.header.Get("X-Custom"))
+
+	scanner := bufio.NewScanner(bytes.NewReader(req.body))
+	var values []int
+	for scanner.Scan() {
+		var doc struct{ Value int }
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc))
+		values = append(values, doc.Value)
+	}
+	assert.Equal(t, []int{1, 2}, values)
+}
+
+func TestPublishJSONArrayGzip(t *testing.T) {
+	requests, server := startServer(http.StatusAccepted)
+	defer server.Close()
+
+	client := makeTestClient(t, server.URL, common.MapStr{
+		"format":            "json_array",
+		"compression_level": 5,
+	})
+	batch := outest.NewBatch(makeEvent(1), makeEvent(2), makeEvent(3))
+	require.NoError(t, client.Publish(batch))
+	assertSignal(t, batch, outest.BatchACK)
+
+	req := <-requests
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "gzip", req.header.Get("Content-Encoding"))
+
+	gz, err := gzip.NewReader(bytes.NewReader(req.body))
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(gz)
+	require.NoError(t, err)
+
+	var docs []struct{ Value int }
+	require.NoError(t, json.Unmarshal(body, &docs))
+	assert.Len(t, docs, 3)
+}
+
+func TestPublishRetryOnServerError(t *testing.T) {
+	_, server := startServer(http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := makeTestClient(t, server.URL, nil)
+	batch := outest.NewBatch(makeEvent(1))
+	assert.Error(t, client.Publish(batch))
+	assertSignal(t, batch, outest.BatchRetry)
+}
+
+func TestPublishDropOnClientError(t *testing.T) {
+	_, server := startServer(http.StatusBadRequest)
+	defer server.Close()
+
+	client := makeTestClient(t, server.URL, nil)
+	batch := outest.NewBatch(makeEvent(1))
+	assert.NoError(t, client.Publish(batch))
+	assertSignal(t, batch, outest.BatchACK)
+}
+
+func startServer(status int) (<-chan request, *httptest.Server) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	return requests, server
+}
+
+func makeTestClient(t *testing.T, url string, settings common.MapStr) *client {
+	cfg, err := common.NewConfigFrom(settings)
+	require.NoError(t, err)
+
+	config := defaultConfig
+	require.NoError(t, cfg.Unpack(&config))
+
+	enc, err := codec.CreateEncoder(beat.Info{Beat: "test", Version: "1.2.3"}, config.Codec)
+	require.NoError(t, err)
+
+	client, err := newClient(clientSettings{
+		URL:              url,
+		Headers:          config.Headers,
+		Timeout:          time.Second,
+		CompressionLevel: config.CompressionLevel,
+		Format:           config.Format,
+		Index:            "test",
+		Codec:            enc,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func makeEvent(value int) beat.Event {
+	return beat.Event{
+		Timestamp: time.Now(),
+		Fields:    common.MapStr{"value": value},
+	}
+}
+
+func assertSignal(t *testing.T, batch *outest.Batch, tag outest.BatchSignalTag) {
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, tag, batch.Signals[0].Tag)
+}
@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+)
+
+type httpConfig struct {
+	Protocol         string            `config:"protocol"`
+	Path             string            `config:"path"`
+	Params           map[string]string `config:"parameters"`
+	Headers          map[string]string `config:"headers"`
+	Username         string            `config:"username"`
+	Password         string            `config:"password"`
+	ProxyURL         string            `config:"proxy_url"`
+	ProxyDisable     bool              `config:"proxy_disable"`
+	LoadBalance      bool              `config:"loadbalance"`
+	CompressionLevel int               `config:"compression_level" validate:"min=0, max=9"`
+	Format           bodyFormat        `config:"format"`
+	Codec            codec.Config      `config:"codec"`
+	TLS              *tlscommon.Config `config:"ssl"`
+	BulkMaxSize      int               `config:"bulk_max_size"`
+	MaxRetries       int               `config:"max_retries"`
+	Timeout          time.Duration     `config:"timeout"`
+	Backoff          backoff           `config:"backoff"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+// bodyFormat configures how the encoded events of a batch are combined into
+// the request body.
+type bodyFormat uint8
+
+const (
+	formatNDJSON bodyFormat = iota
+	formatJSONArray
+)
+
+const (
+	defaultBulkSize = 50
+	defaultPort     = 80
+)
+
+var (
+	defaultConfig = httpConfig{
+		Protocol:         "",
+		Path:             "",
+		ProxyURL:         "",
+		ProxyDisable:     false,
+		Params:           nil,
+		Username:         "",
+		Password:         "",
+		Timeout:          90 * time.Second,
+		MaxRetries:       3,
+		CompressionLevel: 0,
+		Format:           formatNDJSON,
+		TLS:              nil,
+		LoadBalance:      true,
+		Backoff: backoff{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+	}
+)
+
+var formats = map[string]bodyFormat{
+	"ndjson":     formatNDJSON,
+	"json_array": formatJSONArray,
+}
+
+func (c *httpConfig) Validate() error {
+	if c.ProxyURL != "" && !c.ProxyDisable {
+		if _, err := parseProxyURL(c.ProxyURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *bodyFormat) Unpack(s string) error {
+	format, exists := formats[s]
+	if !exists {
+		return fmt.Errorf("unsupported body format '%v'", s)
+	}
+	*f = format
+	return nil
+}
+
+func (f bodyFormat) contentType() string {
+	if f == formatJSONArray {
+		return "application/json"
+	}
+	return "application/x-ndjson"
+}
+
+func parseProxyURL(raw string) (*url.URL, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(raw)
+	if err == nil && u.Scheme != "" {
+		return u, nil
+	}
+
+	// Proxy was bogus. Try prepending "http://" to it and see if that parses
+	// correctly.
+	return url.Parse("http://" + raw)
+}
@@ -33,6 +33,7 @@ type Stats struct {
 	active     *monitoring.Uint // events sent and waiting for ACK/fail from output
 	duplicates *monitoring.Uint // events sent and waiting for ACK/fail from output
 	dropped    *monitoring.Uint // total number of invalid events dropped by the output
+	deadLetter *monitoring.Uint // total number of rejected events captured by a dead letter sink
 	tooMany    *monitoring.Uint // total number of too many requests replies from output
 
 	//
@@ -56,6 +57,7 @@ func NewStats(reg *monitoring.Registry) *Stats {
 		failed:     monitoring.NewUint(reg, "events.failed"),
 		dropped:    monitoring.NewUint(reg, "events.dropped"),
 		duplicates: monitoring.NewUint(reg, "events.duplicates"),
+		deadLetter: monitoring.NewUint(reg, "events.dead_letter"),
 		active:     monitoring.NewUint(reg, "events.active"),
 		tooMany:    monitoring.NewUint(reg, "events.toomany"),
 
@@ -100,6 +102,14 @@ func (s *Stats) Duplicate(n int) {
 	}
 }
 
+// DeadLetter updates the active and dead letter event metrics.
+func (s *Stats) DeadLetter(n int) {
+	if s != nil {
+		s.deadLetter.Add(uint64(n))
+		s.active.Sub(uint64(n))
+	}
+}
+
 // Dropped updates total number of event drops as reported by the output.
 // Outputs will only report dropped events on fatal errors which lead to the
 // event not being publishable. For example encoding errors or total event size
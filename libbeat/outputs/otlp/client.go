@@ -0,0 +1,465 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/http2"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/testing"
+)
+
+type client struct {
+	url        string
+	logsURL    string
+	metricsURL string
+	protocol   protocol
+	headers    map[string]string
+	http       *http.Client
+
+	encoder          *encoder
+	compressionLevel int
+
+	tlsConfig *transport.TLSConfig
+	timeout   time.Duration
+	observer  outputs.Observer
+
+	body bytes.Buffer
+}
+
+// clientSettings configures a client.
+type clientSettings struct {
+	URL              string
+	Protocol         protocol
+	TLS              *transport.TLSConfig
+	Headers          map[string]string
+	Timeout          time.Duration
+	CompressionLevel int
+	Encoder          *encoder
+	Observer         outputs.Observer
+}
+
+// exportError is returned if the collector rejects an export request.
+type exportError struct {
+	msg       string
+	retry     bool // the request can be retried
+	throttled bool // the collector asked to reduce the request rate
+}
+
+func (e *exportError) Error() string {
+	return e.msg
+}
+
+// Paths of the OTLP/HTTP endpoints and of the gRPC export methods.
+const (
+	httpLogsPath    = "/v1/logs"
+	httpMetricsPath = "/v1/metrics"
+	grpcLogsPath    = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+	grpcMetricsPath = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+)
+
+// gRPC status codes reported by the collector.
+const (
+	grpcOK                = 0
+	grpcCanceled          = 1
+	grpcDeadlineExceeded  = 4
+	grpcResourceExhausted = 8
+	grpcAborted           = 10
+	grpcOutOfRange        = 11
+	grpcUnavailable       = 14
+	grpcDataLoss          = 15
+)
+
+// grpcRetryable lists the gRPC status codes the OTLP specification considers
+// to be retryable.
+var grpcRetryable = map[int]bool{
+	grpcCanceled:          true,
+	grpcDeadlineExceeded:  true,
+	grpcResourceExhausted: true,
+	grpcAborted:           true,
+	grpcOutOfRange:        true,
+	grpcUnavailable:       true,
+	grpcDataLoss:          true,
+}
+
+func newClient(s clientSettings) (*client, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OTLP output URL: %v", err)
+	}
+	useTLS := u.Scheme == "https"
+
+	logp.Info("OTLP output url: %s (%v)", s.URL, s.Protocol)
+
+	var dialer, tlsDialer transport.Dialer
+	dialer = transport.NetDialer(s.Timeout)
+	tlsDialer, err = transport.TLSDialer(dialer, s.TLS, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	observer := s.Observer
+	if observer == nil {
+		observer = outputs.NewNilObserver()
+	} else {
+		dialer = transport.StatsDialer(dialer, observer)
+		tlsDialer = transport.StatsDialer(tlsDialer, observer)
+	}
+
+	if s.CompressionLevel > 0 {
+		if _, err := gzip.NewWriterLevel(ioutil.Discard, s.CompressionLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	var rt http.RoundTripper
+	logsPath, metricsPath := httpLogsPath, httpMetricsPath
+	switch s.Protocol {
+	case protocolGRPC:
+		// gRPC requires HTTP/2, which is also used on plain text connections.
+		// The path configured in the URL is replaced by the export methods.
+		u.Path = ""
+		logsPath, metricsPath = grpcLogsPath, grpcMetricsPath
+		timeout := s.Timeout
+		rt = &http2.Transport{
+			AllowHTTP:       true,
+			TLSClientConfig: s.TLS.BuildModuleConfig(""),
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				if !useTLS {
+					return dialer.Dial(network, addr)
+				}
+				return dialTLS(dialer, network, addr, cfg, timeout)
+			},
+		}
+	default:
+		rt = &http.Transport{
+			Dial:    dialer.Dial,
+			DialTLS: tlsDialer.Dial,
+		}
+	}
+
+	base := strings.TrimSuffix(u.String(), "/")
+	return &client{
+		url:        s.URL,
+		logsURL:    base + logsPath,
+		metricsURL: base + metricsPath,
+		protocol:   s.Protocol,
+		headers:    s.Headers,
+		http: &http.Client{
+			Transport: rt,
+			Timeout:   s.Timeout,
+		},
+		encoder:          s.Encoder,
+		compressionLevel: s.CompressionLevel,
+		tlsConfig:        s.TLS,
+		timeout:          s.Timeout,
+		observer:         observer,
+	}, nil
+}
+
+// dialTLS establishes a TLS connection negotiating HTTP/2.
+func dialTLS(
+	dialer transport.Dialer,
+	network, addr string,
+	cfg *tls.Config,
+	timeout time.Duration,
+) (net.Conn, error) {
+	socket, err := dialer.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := tls.Client(socket, cfg)
+	if timeout > 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+	if err := conn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	if p := conn.ConnectionState().NegotiatedProtocol; p != http2.NextProtoTLS {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected ALPN protocol %q, gRPC requires %q", p, http2.NextProtoTLS)
+	}
+	return conn, nil
+}
+
+// Connect is a no-op. Connections are established by the HTTP client on
+// demand.
+func (c *client) Connect() error {
+	return nil
+}
+
+// Close closes all idle connections.
+func (c *client) Close() error {
+	if t, ok := c.http.Transport.(interface{ CloseIdleConnections() }); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Publish sends the events of the batch to the collector. Events reported by
+// metricsets are sent as metrics, all other events as log records. Each
+// signal is exported with a separate request, and only the events of failed
+// requests are retried.
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	logs := c.encoder.newLogs()
+	metrics := c.encoder.newMetrics()
+	var logEvents, metricEvents []publisher.Event
+	for i := range events {
+		event := &events[i]
+		if metrics.add(&event.Content) {
+			metricEvents = append(metricEvents, *event)
+		} else {
+			logs.add(&event.Content)
+			logEvents = append(logEvents, *event)
+		}
+	}
+
+	var failed []publisher.Event
+	var err error
+	if len(logEvents) > 0 {
+		if e := c.export(c.logsURL, &logs.req, len(logEvents)); e != nil {
+			failed = append(failed, logEvents...)
+			err = e
+		}
+	}
+	if len(metricEvents) > 0 {
+		if e := c.export(c.metricsURL, &metrics.req, len(metricEvents)); e != nil {
+			failed = append(failed, metricEvents...)
+			err = e
+		}
+	}
+
+	if len(failed) == 0 {
+		batch.ACK()
+		return nil
+	}
+	batch.RetryEvents(failed)
+	return err
+}
+
+// export sends a single export request. Events rejected by the collector
+// are dropped. An error is only returned if the request must be retried.
+func (c *client) export(endpoint string, req proto.Message, count int) error {
+	st := c.observer
+
+	err := c.send(endpoint, req)
+	if err == nil {
+		st.Acked(count)
+		return nil
+	}
+
+	if e, ok := err.(*exportError); ok {
+		if !e.retry {
+			logp.Err("Dropping %v events: %v", count, err)
+			st.Dropped(count)
+			return nil
+		}
+		if e.throttled {
+			st.ErrTooMany(count)
+			return err
+		}
+	}
+	st.Failed(count)
+	return err
+}
+
+// send encodes the export request and posts it to the collector.
+func (c *client) send(endpoint string, msg proto.Message) error {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return &exportError{msg: fmt.Sprintf("failed to encode export request: %v", err)}
+	}
+	if err := c.encodeBody(payload); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(c.body.Bytes()))
+	if err != nil {
+		return err
+	}
+
+	if c.protocol == protocolGRPC {
+		req.Header.Set("Content-Type", "application/grpc")
+		req.Header.Set("TE", "trailers")
+		if c.compressionLevel > 0 {
+			req.Header.Set("Grpc-Encoding", "gzip")
+		}
+	} else {
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		if c.compressionLevel > 0 {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.observer.WriteError(err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	c.observer.WriteBytes(c.body.Len())
+	n, _ := io.Copy(ioutil.Discard, resp.Body)
+	c.observer.ReadBytes(int(n))
+
+	if c.protocol == protocolGRPC && resp.StatusCode == http.StatusOK {
+		return grpcStatus(resp)
+	}
+	return httpStatus(resp)
+}
+
+// encodeBody writes the serialized export request into the body buffer,
+// optionally compressing it. gRPC requests are prefixed with the compression
+// flag and the length of the message.
+func (c *client) encodeBody(payload []byte) error {
+	c.body.Reset()
+	grpc := c.protocol == protocolGRPC
+	if grpc {
+		c.body.Write(make([]byte, 5))
+	}
+
+	if c.compressionLevel > 0 {
+		gz, _ := gzip.NewWriterLevel(&c.body, c.compressionLevel)
+		gz.Write(payload)
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	} else {
+		c.body.Write(payload)
+	}
+
+	if grpc {
+		b := c.body.Bytes()
+		if c.compressionLevel > 0 {
+			b[0] = 1
+		}
+		binary.BigEndian.PutUint32(b[1:5], uint32(len(b)-5))
+	}
+	return nil
+}
+
+// httpStatus checks the status of an OTLP/HTTP response. Requests rejected
+// with 429, 502, 503 or 504 are retried.
+func httpStatus(resp *http.Response) error {
+	status := resp.StatusCode
+	if status >= 200 && status < 300 {
+		return nil
+	}
+
+	var retry bool
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		retry = true
+	}
+	return &exportError{
+		msg:       fmt.Sprintf("collector responded with status %v", resp.Status),
+		retry:     retry,
+		throttled: status == http.StatusTooManyRequests,
+	}
+}
+
+// grpcStatus checks the gRPC status of a response. The status is reported in
+// the trailers, or in the headers if the response has no body.
+func grpcStatus(resp *http.Response) error {
+	status, msg := resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+	if status == "" {
+		status, msg = resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	}
+	if status == "" {
+		return errors.New("collector response is missing the gRPC status")
+	}
+
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return fmt.Errorf("invalid gRPC status '%v'", status)
+	}
+	if code == grpcOK {
+		return nil
+	}
+
+	if unescaped, err := url.PathUnescape(msg); err == nil {
+		msg = unescaped
+	}
+	return &exportError{
+		msg:       fmt.Sprintf("collector responded with gRPC status %v: %v", code, msg),
+		retry:     grpcRetryable[code],
+		throttled: code == grpcResourceExhausted,
+	}
+}
+
+func (c *client) Test(d testing.Driver) {
+	d.Run("otlp: "+c.url, func(d testing.Driver) {
+		u, err := url.Parse(c.url)
+		d.Fatal("parse url", err)
+
+		address := u.Hostname()
+		if u.Port() != "" {
+			address += ":" + u.Port()
+		}
+		d.Run("connection", func(d testing.Driver) {
+			netDialer := transport.TestNetDialer(d, c.timeout)
+			_, err = netDialer.Dial("tcp", address)
+			d.Fatal("dial up", err)
+		})
+
+		if u.Scheme != "https" {
+			d.Warn("TLS", "secure connection disabled")
+		} else {
+			d.Run("TLS", func(d testing.Driver) {
+				netDialer := transport.NetDialer(c.timeout)
+				tlsDialer, err := transport.TestTLSDialer(d, netDialer, c.tlsConfig, c.timeout)
+				_, err = tlsDialer.Dial("tcp", address)
+				d.Fatal("dial up", err)
+			})
+		}
+	})
+}
+
+func (c *client) String() string {
+	return "otlp(" + c.url + ")"
+}
@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+)
+
+type request struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func TestPublishHTTP(t *testing.T) {
+	requests, server := startHTTPServer(http.StatusOK)
+	defer server.Close()
+
+	client := makeTestClient(t, server.URL+"/otel", protocolHTTP, 0)
+	batch := outest.NewBatch(logEvent("hello"), metricEvent(4))
+	require.NoError(t, client.Publish(batch))
+	assertSignal(t, batch, outest.BatchACK)
+
+	logs := <-requests
+	assert.Equal(t, "/otel/v1/logs", logs.path)
+	assert.Equal(t, "application/x-protobuf", logs.header.Get("Content-Type"))
+	var logsReq exportLogsServiceRequest
+	require.NoError(t, proto.Unmarshal(logs.body, &logsReq))
+	record := logsReq.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, "hello", *record.Body.StringValue)
+
+	metrics := <-requests
+	assert.Equal(t, "/otel/v1/metrics", metrics.path)
+	var metricsReq exportMetricsServiceRequest
+	require.NoError(t, proto.Unmarshal(metrics.body, &metricsReq))
+	m := metricsReq.ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, "system.cpu.cores", m.Name)
+	assert.Equal(t, int64(4), *m.Gauge.DataPoints[0].AsInt)
+}
+
+func TestPublishHTTPStatus(t *testing.T) {
+	cases := map[int]outest.BatchSignalTag{
+		http.StatusBadRequest:         outest.BatchACK,
+		http.StatusTooManyRequests:    outest.BatchRetryEvents,
+		http.StatusServiceUnavailable: outest.BatchRetryEvents,
+	}
+
+	for status, signal := range cases {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			_, server := startHTTPServer(status)
+			defer server.Close()
+
+			client := makeTestClient(t, server.URL, protocolHTTP, 0)
+			batch := outest.NewBatch(logEvent("hello"))
+			err := client.Publish(batch)
+			assertSignal(t, batch, signal)
+			assert.Equal(t, signal == outest.BatchRetryEvents, err != nil)
+		})
+	}
+}
+
+func TestPublishGRPC(t *testing.T) {
+	requests, l := startGRPCServer(t, grpcOK)
+	defer l.Close()
+
+	client := makeTestClient(t, l.Addr().String(), protocolGRPC, 5)
+	defer client.Close()
+
+	batch := outest.NewBatch(logEvent("hello"))
+	require.NoError(t, client.Publish(batch))
+	assertSignal(t, batch, outest.BatchACK)
+
+	req := <-requests
+	assert.Equal(t, grpcLogsPath, req.path)
+	assert.Equal(t, "application/grpc", req.header.Get("Content-Type"))
+	assert.Equal(t, "gzip", req.header.Get("Grpc-Encoding"))
+
+	require.True(t, len(req.body) > 5)
+	assert.Equal(t, byte(1), req.body[0])
+	assert.Equal(t, len(req.body)-5, int(binary.BigEndian.Uint32(req.body[1:5])))
+
+	gz, err := gzip.NewReader(bytes.NewReader(req.body[5:]))
+	require.NoError(t, err)
+	payload, err := ioutil.ReadAll(gz)
+	require.NoError(t, err)
+
+	var logsReq exportLogsServiceRequest
+	require.NoError(t, proto.Unmarshal(payload, &logsReq))
+	record := logsReq.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, "hello", *record.Body.StringValue)
+}
+
+func TestPublishGRPCStatus(t *testing.T) {
+	cases := map[string]struct {
+		code   int
+		signal outest.BatchSignalTag
+	}{
+		"invalid argument": {3, outest.BatchACK},
+		"unavailable":      {grpcUnavailable, outest.BatchRetryEvents},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, l := startGRPCServer(t, test.code)
+			defer l.Close()
+
+			client := makeTestClient(t, l.Addr().String(), protocolGRPC, 0)
+			defer client.Close()
+
+			batch := outest.NewBatch(logEvent("hello"), metricEvent(4))
+			err := client.Publish(batch)
+			assertSignal(t, batch, test.signal)
+			if test.signal == outest.BatchRetryEvents {
+				assert.Error(t, err)
+				assert.Len(t, batch.Signals[0].Events, 2)
+			}
+		})
+	}
+}
+
+func startHTTPServer(status int) (<-chan request, *httptest.Server) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{path: r.URL.Path, header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	return requests, server
+}
+
+// startGRPCServer starts a plain text HTTP/2 server responding to all
+// requests with the given gRPC status. Successful responses report the
+// status in the trailers, errors in the headers.
+func startGRPCServer(t *testing.T, code int) (<-chan request, net.Listener) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	requests := make(chan request, 10)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{path: r.URL.Path, header: r.Header, body: body}
+
+		w.Header().Set("Content-Type", "application/grpc")
+		if code != grpcOK {
+			w.Header().Set("Grpc-Status", strconv.Itoa(code))
+			w.Header().Set("Grpc-Message", "request%20failed")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.WriteHeader(http.StatusOK)
+		w.Write(make([]byte, 5))
+		w.Header().Set("Grpc-Status", "0")
+	})
+
+	server := &http2.Server{}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go server.ServeConn(conn, &http2.ServeConnOpts{Handler: handler})
+		}
+	}()
+	return requests, l
+}
+
+func makeTestClient(t *testing.T, url string, p protocol, compressionLevel int) *client {
+	hostURL, err := common.MakeURL("http", "", url, p.defaultPort())
+	require.NoError(t, err)
+
+	client, err := newClient(clientSettings{
+		URL:              hostURL,
+		Protocol:         p,
+		Timeout:          time.Second,
+		CompressionLevel: compressionLevel,
+		Encoder:          newEncoder(beat.Info{Beat: "test"}, defaultConfig.ResourceFields, true),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func logEvent(msg string) beat.Event {
+	return beat.Event{
+		Timestamp: time.Now(),
+		Fields:    common.MapStr{"message": msg},
+	}
+}
+
+func metricEvent(cores int) beat.Event {
+	return beat.Event{
+		Timestamp: time.Now(),
+		Fields: common.MapStr{
+			"event":     common.MapStr{"module": "system"},
+			"metricset": common.MapStr{"name": "cpu"},
+			"system":    common.MapStr{"cpu": common.MapStr{"cores": cores}},
+		},
+	}
+}
+
+func assertSignal(t *testing.T, batch *outest.Batch, tag outest.BatchSignalTag) {
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, tag, batch.Signals[0].Tag)
+}
@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+)
+
+type otlpConfig struct {
+	Protocol         protocol          `config:"protocol"`
+	Path             string            `config:"path"`
+	Headers          map[string]string `config:"headers"`
+	ResourceFields   []string          `config:"resource_fields"`
+	Metrics          metricsConfig     `config:"metrics"`
+	LoadBalance      bool              `config:"loadbalance"`
+	CompressionLevel int               `config:"compression_level" validate:"min=0, max=9"`
+	TLS              *tlscommon.Config `config:"ssl"`
+	BulkMaxSize      int               `config:"bulk_max_size"`
+	MaxRetries       int               `config:"max_retries"`
+	Timeout          time.Duration     `config:"timeout"`
+	Backoff          backoff           `config:"backoff"`
+}
+
+type metricsConfig struct {
+	Enabled bool `config:"enabled"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+// protocol selects how export requests are sent to the collector.
+type protocol uint8
+
+const (
+	protocolGRPC protocol = iota
+	protocolHTTP
+)
+
+const defaultBulkSize = 50
+
+var (
+	defaultConfig = otlpConfig{
+		Protocol:         protocolGRPC,
+		Path:             "",
+		ResourceFields:   []string{"agent", "cloud", "container", "host", "kubernetes"},
+		Metrics:          metricsConfig{Enabled: true},
+		LoadBalance:      true,
+		CompressionLevel: 0,
+		TLS:              nil,
+		MaxRetries:       3,
+		Timeout:          90 * time.Second,
+		Backoff: backoff{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+	}
+)
+
+var protocols = map[string]protocol{
+	"grpc":          protocolGRPC,
+	"http/protobuf": protocolHTTP,
+}
+
+func (p *protocol) Unpack(s string) error {
+	v, exists := protocols[s]
+	if !exists {
+		return fmt.Errorf("unsupported OTLP protocol '%v'", s)
+	}
+	*p = v
+	return nil
+}
+
+func (p protocol) String() string {
+	for name, v := range protocols {
+		if v == p {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// defaultPort returns the port used by the collector for the protocol if the
+// host does not configure a port explicitly.
+func (p protocol) defaultPort() int {
+	if p == protocolHTTP {
+		return 4318
+	}
+	return 4317
+}
@@ -0,0 +1,343 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// encoder converts beat events into OTLP log records and metrics.
+//
+// Fields listed in resourceFields describe the entity that produced an event,
+// like the host or container. They are reported as resource attributes, and
+// events with the same resource are grouped together. All other fields are
+// reported as attributes of the log record or data point.
+type encoder struct {
+	scope          *instrumentationScope
+	resourceFields map[string]bool
+	metrics        bool
+}
+
+// logsBatch collects the log records of a single export request.
+type logsBatch struct {
+	enc    *encoder
+	req    exportLogsServiceRequest
+	scopes map[string]*scopeLogs
+	now    uint64
+}
+
+// metricsBatch collects the metrics of a single export request. Data points
+// of metrics with the same name and resource are merged into one metric.
+type metricsBatch struct {
+	enc     *encoder
+	req     exportMetricsServiceRequest
+	scopes  map[string]*scopeMetrics
+	metrics map[string]*metric
+}
+
+// severities maps common log level names to OTLP severity numbers.
+var severities = map[string]int32{
+	"trace":         1,
+	"debug":         5,
+	"info":          9,
+	"informational": 9,
+	"notice":        10,
+	"warn":          13,
+	"warning":       13,
+	"err":           17,
+	"error":         17,
+	"crit":          18,
+	"critical":      18,
+	"alert":         19,
+	"emerg":         21,
+	"emergency":     21,
+	"fatal":         21,
+}
+
+func newEncoder(info beat.Info, resourceFields []string, metrics bool) *encoder {
+	fields := make(map[string]bool, len(resourceFields))
+	for _, name := range resourceFields {
+		fields[name] = true
+	}
+	return &encoder{
+		scope:          &instrumentationScope{Name: info.Beat, Version: info.Version},
+		resourceFields: fields,
+		metrics:        metrics,
+	}
+}
+
+func (e *encoder) newLogs() *logsBatch {
+	return &logsBatch{
+		enc:    e,
+		scopes: map[string]*scopeLogs{},
+		now:    unixNano(time.Now()),
+	}
+}
+
+func (e *encoder) newMetrics() *metricsBatch {
+	return &metricsBatch{
+		enc:     e,
+		scopes:  map[string]*scopeMetrics{},
+		metrics: map[string]*metric{},
+	}
+}
+
+// splitResource separates the resource fields from all other fields of an
+// event, leaving out the skipped field. The resource is returned together
+// with a key identifying it.
+func (e *encoder) splitResource(fields common.MapStr, skip string) (*resource, string, common.MapStr) {
+	rest := make(common.MapStr, len(fields))
+	res := common.MapStr{}
+	for k, v := range fields {
+		if k == skip {
+			continue
+		}
+		if e.resourceFields[k] {
+			res[k] = v
+		} else {
+			rest[k] = v
+		}
+	}
+
+	attrs := toAttributes(res.Flatten())
+	var key strings.Builder
+	for _, attr := range attrs {
+		key.WriteString(attr.Key)
+		key.WriteByte('=')
+		key.WriteString(attr.Value.String())
+		key.WriteByte('\n')
+	}
+	return &resource{Attributes: attrs}, key.String(), rest
+}
+
+// add converts an event into a log record. The `message` field is used as
+// body of the record, and `log.level` as its severity.
+func (b *logsBatch) add(event *beat.Event) {
+	res, key, rest := b.enc.splitResource(event.Fields, "")
+	fields := rest.Flatten()
+
+	record := &logRecord{
+		TimeUnixNano:         unixNano(event.Timestamp),
+		ObservedTimeUnixNano: b.now,
+	}
+	if msg, ok := fields["message"].(string); ok {
+		record.Body = toAnyValue(msg)
+		delete(fields, "message")
+	}
+	if level, ok := fields["log.level"].(string); ok {
+		record.SeverityText = level
+		record.SeverityNumber = severities[strings.ToLower(level)]
+		delete(fields, "log.level")
+	}
+	record.Attributes = toAttributes(fields)
+
+	scope := b.scopes[key]
+	if scope == nil {
+		scope = &scopeLogs{Scope: b.enc.scope}
+		b.scopes[key] = scope
+		b.req.ResourceLogs = append(b.req.ResourceLogs, &resourceLogs{
+			Resource:  res,
+			ScopeLogs: []*scopeLogs{scope},
+		})
+	}
+	scope.LogRecords = append(scope.LogRecords, record)
+}
+
+// add converts the numeric fields reported by a metricset into gauges. All
+// other fields become attributes of the data points. If the event is not
+// reported by a metricset, or has no numeric fields, false is returned and
+// the event must be reported as log record instead.
+func (b *metricsBatch) add(event *beat.Event) bool {
+	if !b.enc.metrics {
+		return false
+	}
+	namespace := metricNamespace(event.Fields)
+	if namespace == "" {
+		return false
+	}
+
+	// The module namespace is never part of the resource, even if it is one
+	// of the resource fields, like for the kubernetes module.
+	values := common.MapStr{namespace: event.Fields[namespace]}.Flatten()
+	res, key, rest := b.enc.splitResource(event.Fields, namespace)
+	fields := rest.Flatten()
+
+	var names []string
+	points := map[string]*numberDataPoint{}
+	for name, v := range values {
+		if point, ok := toDataPoint(v); ok {
+			names = append(names, name)
+			points[name] = point
+		} else {
+			fields[name] = v
+		}
+	}
+	if len(points) == 0 {
+		return false
+	}
+
+	scope := b.scopes[key]
+	if scope == nil {
+		scope = &scopeMetrics{Scope: b.enc.scope}
+		b.scopes[key] = scope
+		b.req.ResourceMetrics = append(b.req.ResourceMetrics, &resourceMetrics{
+			Resource:     res,
+			ScopeMetrics: []*scopeMetrics{scope},
+		})
+	}
+
+	attrs := toAttributes(fields)
+	ts := unixNano(event.Timestamp)
+	sort.Strings(names)
+	for _, name := range names {
+		point := points[name]
+		point.TimeUnixNano = ts
+		point.Attributes = attrs
+
+		m := b.metrics[key+name]
+		if m == nil {
+			m = &metric{Name: name, Gauge: &gauge{}}
+			b.metrics[key+name] = m
+			scope.Metrics = append(scope.Metrics, m)
+		}
+		m.Gauge.DataPoints = append(m.Gauge.DataPoints, point)
+	}
+	return true
+}
+
+// metricNamespace returns the name of the field holding the metrics of an
+// event reported by a metricset, or an empty string for all other events.
+func metricNamespace(fields common.MapStr) string {
+	if ok, _ := fields.HasKey("metricset.name"); !ok {
+		return ""
+	}
+	module, _ := fields.GetValue("event.module")
+	name, _ := module.(string)
+	if _, exists := fields[name]; !exists {
+		return ""
+	}
+	return name
+}
+
+// toAttributes converts flattened fields into attributes sorted by key.
+func toAttributes(fields common.MapStr) []*keyValue {
+	if len(fields) == 0 {
+		return nil
+	}
+	return toKeyValues(fields)
+}
+
+// toDataPoint creates a data point from a numeric value. Unsigned integers
+// not fitting into an int64 are reported as double.
+func toDataPoint(v interface{}) (*numberDataPoint, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		return &numberDataPoint{AsInt: &i}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			f := float64(u)
+			return &numberDataPoint{AsDouble: &f}, true
+		}
+		i := int64(u)
+		return &numberDataPoint{AsInt: &i}, true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return &numberDataPoint{AsDouble: &f}, true
+	}
+	return nil, false
+}
+
+// toAnyValue converts a field value. Objects are converted into key-value
+// lists and slices into arrays. Values of other types are formatted as
+// string.
+func toAnyValue(v interface{}) *anyValue {
+	switch v := v.(type) {
+	case nil:
+		return &anyValue{}
+	case string:
+		return &anyValue{StringValue: &v}
+	case bool:
+		return &anyValue{BoolValue: &v}
+	case []byte:
+		return &anyValue{BytesValue: v}
+	case time.Time:
+		s := v.UTC().Format(time.RFC3339Nano)
+		return &anyValue{StringValue: &s}
+	case common.Time:
+		return toAnyValue(time.Time(v))
+	case common.MapStr:
+		return &anyValue{KvlistValue: &keyValueList{Values: toKeyValues(v)}}
+	case map[string]interface{}:
+		return &anyValue{KvlistValue: &keyValueList{Values: toKeyValues(v)}}
+	}
+
+	if point, ok := toDataPoint(v); ok {
+		if point.AsInt != nil {
+			return &anyValue{IntValue: point.AsInt}
+		}
+		return &anyValue{DoubleValue: point.AsDouble}
+	}
+
+	if s, ok := v.(fmt.Stringer); ok {
+		str := s.String()
+		return &anyValue{StringValue: &str}
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		values := make([]*anyValue, rv.Len())
+		for i := range values {
+			values[i] = toAnyValue(rv.Index(i).Interface())
+		}
+		return &anyValue{ArrayValue: &arrayValue{Values: values}}
+	}
+
+	s := fmt.Sprint(v)
+	return &anyValue{StringValue: &s}
+}
+
+func toKeyValues(m map[string]interface{}) []*keyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]*keyValue, len(keys))
+	for i, k := range keys {
+		values[i] = &keyValue{Key: k, Value: toAnyValue(m[k])}
+	}
+	return values
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
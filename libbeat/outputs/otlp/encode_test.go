@@ -0,0 +1,258 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestEncodeLogs(t *testing.T) {
+	enc := newEncoder(beat.Info{Beat: "filebeat", Version: "7.3.0"}, []string{"host"}, true)
+	ts := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	logs := enc.newLogs()
+	logs.add(&beat.Event{
+		Timestamp: ts,
+		Fields: common.MapStr{
+			"message": "hello",
+			"log":     common.MapStr{"level": "WARN", "offset": 10},
+			"host":    common.MapStr{"name": "a"},
+		},
+	})
+	logs.add(&beat.Event{
+		Timestamp: ts,
+		Fields: common.MapStr{
+			"message": "world",
+			"host":    common.MapStr{"name": "a"},
+		},
+	})
+	logs.add(&beat.Event{
+		Timestamp: ts,
+		Fields: common.MapStr{
+			"message": "other",
+			"host":    common.MapStr{"name": "b"},
+		},
+	})
+
+	require.Len(t, logs.req.ResourceLogs, 2)
+	first := logs.req.ResourceLogs[0]
+	assert.Equal(t, map[string]interface{}{"host.name": "a"}, attributeMap(first.Resource.Attributes))
+	require.Len(t, first.ScopeLogs, 1)
+	assert.Equal(t, "filebeat", first.ScopeLogs[0].Scope.Name)
+	assert.Equal(t, "7.3.0", first.ScopeLogs[0].Scope.Version)
+
+	records := first.ScopeLogs[0].LogRecords
+	require.Len(t, records, 2)
+	assert.Equal(t, uint64(ts.UnixNano()), records[0].TimeUnixNano)
+	assert.NotZero(t, records[0].ObservedTimeUnixNano)
+	assert.Equal(t, "hello", *records[0].Body.StringValue)
+	assert.Equal(t, "WARN", records[0].SeverityText)
+	assert.Equal(t, int32(13), records[0].SeverityNumber)
+	assert.Equal(t, map[string]interface{}{"log.offset": int64(10)}, attributeMap(records[0].Attributes))
+	assert.Equal(t, "world", *records[1].Body.StringValue)
+	assert.Empty(t, records[1].Attributes)
+
+	second := logs.req.ResourceLogs[1]
+	assert.Equal(t, map[string]interface{}{"host.name": "b"}, attributeMap(second.Resource.Attributes))
+}
+
+func TestEncodeMetrics(t *testing.T) {
+	enc := newEncoder(beat.Info{Beat: "metricbeat"}, []string{"host"}, true)
+	ts := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	event := func(iface string, in, out uint64) *beat.Event {
+		return &beat.Event{
+			Timestamp: ts,
+			Fields: common.MapStr{
+				"event":     common.MapStr{"module": "system", "duration": 1000},
+				"metricset": common.MapStr{"name": "network"},
+				"host":      common.MapStr{"name": "a"},
+				"system": common.MapStr{
+					"network": common.MapStr{
+						"name": iface,
+						"in":   common.MapStr{"bytes": in},
+						"out":  common.MapStr{"bytes": out},
+					},
+				},
+			},
+		}
+	}
+
+	metrics := enc.newMetrics()
+	require.True(t, metrics.add(event("eth0", 10, 20)))
+	require.True(t, metrics.add(event("eth1", 30, 40)))
+
+	require.Len(t, metrics.req.ResourceMetrics, 1)
+	rm := metrics.req.ResourceMetrics[0]
+	assert.Equal(t, map[string]interface{}{"host.name": "a"}, attributeMap(rm.Resource.Attributes))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	ms := rm.ScopeMetrics[0].Metrics
+	require.Len(t, ms, 2)
+	assert.Equal(t, "system.network.in.bytes", ms[0].Name)
+	assert.Equal(t, "system.network.out.bytes", ms[1].Name)
+
+	points := ms[0].Gauge.DataPoints
+	require.Len(t, points, 2)
+	assert.Equal(t, int64(10), *points[0].AsInt)
+	assert.Equal(t, int64(30), *points[1].AsInt)
+	assert.Equal(t, uint64(ts.UnixNano()), points[0].TimeUnixNano)
+	assert.Equal(t, map[string]interface{}{
+		"event.duration":      int64(1000),
+		"event.module":        "system",
+		"metricset.name":      "network",
+		"system.network.name": "eth0",
+	}, attributeMap(points[0].Attributes))
+}
+
+func TestEncodeMetricsModuleResourceField(t *testing.T) {
+	enc := newEncoder(beat.Info{Beat: "metricbeat"}, []string{"host", "kubernetes"}, true)
+
+	metrics := enc.newMetrics()
+	require.True(t, metrics.add(&beat.Event{
+		Fields: common.MapStr{
+			"event":     common.MapStr{"module": "kubernetes"},
+			"metricset": common.MapStr{"name": "pod"},
+			"host":      common.MapStr{"name": "a"},
+			"kubernetes": common.MapStr{
+				"namespace": "default",
+				"pod": common.MapStr{
+					"name":   "web",
+					"cpu":    common.MapStr{"usage": common.MapStr{"nanocores": 1200}},
+					"memory": common.MapStr{"usage": common.MapStr{"bytes": 4096}},
+				},
+			},
+		},
+	}))
+
+	require.Len(t, metrics.req.ResourceMetrics, 1)
+	rm := metrics.req.ResourceMetrics[0]
+	assert.Equal(t, map[string]interface{}{"host.name": "a"}, attributeMap(rm.Resource.Attributes))
+
+	ms := rm.ScopeMetrics[0].Metrics
+	require.Len(t, ms, 2)
+	assert.Equal(t, "kubernetes.pod.cpu.usage.nanocores", ms[0].Name)
+	assert.Equal(t, "kubernetes.pod.memory.usage.bytes", ms[1].Name)
+	assert.Equal(t, map[string]interface{}{
+		"event.module":         "kubernetes",
+		"metricset.name":       "pod",
+		"kubernetes.namespace": "default",
+		"kubernetes.pod.name":  "web",
+	}, attributeMap(ms[0].Gauge.DataPoints[0].Attributes))
+}
+
+func TestEncodeMetricsFallback(t *testing.T) {
+	cases := map[string]struct {
+		enabled bool
+		fields  common.MapStr
+	}{
+		"disabled": {
+			enabled: false,
+			fields: common.MapStr{
+				"event":     common.MapStr{"module": "system"},
+				"metricset": common.MapStr{"name": "cpu"},
+				"system":    common.MapStr{"cpu": common.MapStr{"cores": 4}},
+			},
+		},
+		"no metricset": {
+			enabled: true,
+			fields: common.MapStr{
+				"event":  common.MapStr{"module": "system"},
+				"system": common.MapStr{"cpu": common.MapStr{"cores": 4}},
+			},
+		},
+		"no numeric fields": {
+			enabled: true,
+			fields: common.MapStr{
+				"event":     common.MapStr{"module": "system"},
+				"metricset": common.MapStr{"name": "process"},
+				"system":    common.MapStr{"process": common.MapStr{"name": "beat"}},
+			},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			enc := newEncoder(beat.Info{}, nil, test.enabled)
+			metrics := enc.newMetrics()
+			assert.False(t, metrics.add(&beat.Event{Fields: test.fields}))
+			assert.Empty(t, metrics.req.ResourceMetrics)
+		})
+	}
+}
+
+func TestToAnyValue(t *testing.T) {
+	ts := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	str := func(s string) *anyValue { return &anyValue{StringValue: &s} }
+	i64 := func(i int64) *anyValue { return &anyValue{IntValue: &i} }
+	f64 := func(f float64) *anyValue { return &anyValue{DoubleValue: &f} }
+	yes := true
+
+	cases := []struct {
+		in       interface{}
+		expected *anyValue
+	}{
+		{nil, &anyValue{}},
+		{"a", str("a")},
+		{true, &anyValue{BoolValue: &yes}},
+		{int32(-3), i64(-3)},
+		{uint64(7), i64(7)},
+		{uint64(1 << 63), f64(1 << 63)},
+		{common.Float(1.5), f64(1.5)},
+		{[]byte("ab"), &anyValue{BytesValue: []byte("ab")}},
+		{ts, str("2019-06-01T10:00:00Z")},
+		{common.Time(ts), str("2019-06-01T10:00:00Z")},
+		{net.ParseIP("127.0.0.1"), str("127.0.0.1")},
+		{[]string{"a", "b"}, &anyValue{ArrayValue: &arrayValue{Values: []*anyValue{str("a"), str("b")}}}},
+		{common.MapStr{"b": 1, "a": "x"}, &anyValue{KvlistValue: &keyValueList{Values: []*keyValue{
+			{Key: "a", Value: str("x")},
+			{Key: "b", Value: i64(1)},
+		}}}},
+	}
+
+	for _, test := range cases {
+		assert.Equal(t, test.expected, toAnyValue(test.in), "%#v", test.in)
+	}
+}
+
+// attributeMap converts attributes with scalar values into a map.
+func attributeMap(attrs []*keyValue) map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, attr := range attrs {
+		v := attr.Value
+		switch {
+		case v.StringValue != nil:
+			m[attr.Key] = *v.StringValue
+		case v.IntValue != nil:
+			m[attr.Key] = *v.IntValue
+		case v.DoubleValue != nil:
+			m[attr.Key] = *v.DoubleValue
+		case v.BoolValue != nil:
+			m[attr.Key] = *v.BoolValue
+		}
+	}
+	return m
+}
@@ -0,0 +1,180 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// The types below mirror the messages of the OpenTelemetry protocol
+// (opentelemetry/proto/collector/{logs,metrics}/v1 and the common, resource,
+// logs and metrics protos they reference). Only the fields written by the
+// output are declared.
+//
+// Fields that are part of a oneof in the original protos are declared as
+// pointers without the proto3 option, so that zero values are still encoded
+// and the receiver can tell which member of the oneof is set.
+
+type exportLogsServiceRequest struct {
+	ResourceLogs []*resourceLogs `protobuf:"bytes,1,rep,name=resource_logs" json:"resource_logs,omitempty"`
+}
+
+type resourceLogs struct {
+	Resource  *resource    `protobuf:"bytes,1,opt,name=resource" json:"resource,omitempty"`
+	ScopeLogs []*scopeLogs `protobuf:"bytes,2,rep,name=scope_logs" json:"scope_logs,omitempty"`
+}
+
+type scopeLogs struct {
+	Scope      *instrumentationScope `protobuf:"bytes,1,opt,name=scope" json:"scope,omitempty"`
+	LogRecords []*logRecord          `protobuf:"bytes,2,rep,name=log_records" json:"log_records,omitempty"`
+}
+
+type logRecord struct {
+	TimeUnixNano         uint64      `protobuf:"fixed64,1,opt,name=time_unix_nano,proto3" json:"time_unix_nano,omitempty"`
+	SeverityNumber       int32       `protobuf:"varint,2,opt,name=severity_number,proto3" json:"severity_number,omitempty"`
+	SeverityText         string      `protobuf:"bytes,3,opt,name=severity_text,proto3" json:"severity_text,omitempty"`
+	Body                 *anyValue   `protobuf:"bytes,5,opt,name=body" json:"body,omitempty"`
+	Attributes           []*keyValue `protobuf:"bytes,6,rep,name=attributes" json:"attributes,omitempty"`
+	ObservedTimeUnixNano uint64      `protobuf:"fixed64,11,opt,name=observed_time_unix_nano,proto3" json:"observed_time_unix_nano,omitempty"`
+}
+
+type exportMetricsServiceRequest struct {
+	ResourceMetrics []*resourceMetrics `protobuf:"bytes,1,rep,name=resource_metrics" json:"resource_metrics,omitempty"`
+}
+
+type resourceMetrics struct {
+	Resource     *resource       `protobuf:"bytes,1,opt,name=resource" json:"resource,omitempty"`
+	ScopeMetrics []*scopeMetrics `protobuf:"bytes,2,rep,name=scope_metrics" json:"scope_metrics,omitempty"`
+}
+
+type scopeMetrics struct {
+	Scope   *instrumentationScope `protobuf:"bytes,1,opt,name=scope" json:"scope,omitempty"`
+	Metrics []*metric             `protobuf:"bytes,2,rep,name=metrics" json:"metrics,omitempty"`
+}
+
+type metric struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Gauge *gauge `protobuf:"bytes,5,opt,name=gauge" json:"gauge,omitempty"`
+}
+
+type gauge struct {
+	DataPoints []*numberDataPoint `protobuf:"bytes,1,rep,name=data_points" json:"data_points,omitempty"`
+}
+
+type numberDataPoint struct {
+	TimeUnixNano uint64      `protobuf:"fixed64,3,opt,name=time_unix_nano,proto3" json:"time_unix_nano,omitempty"`
+	AsDouble     *float64    `protobuf:"fixed64,4,opt,name=as_double" json:"as_double,omitempty"`
+	AsInt        *int64      `protobuf:"fixed64,6,opt,name=as_int" json:"as_int,omitempty"`
+	Attributes   []*keyValue `protobuf:"bytes,7,rep,name=attributes" json:"attributes,omitempty"`
+}
+
+type resource struct {
+	Attributes []*keyValue `protobuf:"bytes,1,rep,name=attributes" json:"attributes,omitempty"`
+}
+
+type instrumentationScope struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+type keyValue struct {
+	Key   string    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value *anyValue `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+type anyValue struct {
+	StringValue *string       `protobuf:"bytes,1,opt,name=string_value" json:"string_value,omitempty"`
+	BoolValue   *bool         `protobuf:"varint,2,opt,name=bool_value" json:"bool_value,omitempty"`
+	IntValue    *int64        `protobuf:"varint,3,opt,name=int_value" json:"int_value,omitempty"`
+	DoubleValue *float64      `protobuf:"fixed64,4,opt,name=double_value" json:"double_value,omitempty"`
+	ArrayValue  *arrayValue   `protobuf:"bytes,5,opt,name=array_value" json:"array_value,omitempty"`
+	KvlistValue *keyValueList `protobuf:"bytes,6,opt,name=kvlist_value" json:"kvlist_value,omitempty"`
+	BytesValue  []byte        `protobuf:"bytes,7,opt,name=bytes_value" json:"bytes_value,omitempty"`
+}
+
+type arrayValue struct {
+	Values []*anyValue `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+}
+
+type keyValueList struct {
+	Values []*keyValue `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *exportLogsServiceRequest) Reset()         { *m = exportLogsServiceRequest{} }
+func (m *exportLogsServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*exportLogsServiceRequest) ProtoMessage()    {}
+
+func (m *resourceLogs) Reset()         { *m = resourceLogs{} }
+func (m *resourceLogs) String() string { return proto.CompactTextString(m) }
+func (*resourceLogs) ProtoMessage()    {}
+
+func (m *scopeLogs) Reset()         { *m = scopeLogs{} }
+func (m *scopeLogs) String() string { return proto.CompactTextString(m) }
+func (*scopeLogs) ProtoMessage()    {}
+
+func (m *logRecord) Reset()         { *m = logRecord{} }
+func (m *logRecord) String() string { return proto.CompactTextString(m) }
+func (*logRecord) ProtoMessage()    {}
+
+func (m *exportMetricsServiceRequest) Reset()         { *m = exportMetricsServiceRequest{} }
+func (m *exportMetricsServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*exportMetricsServiceRequest) ProtoMessage()    {}
+
+func (m *resourceMetrics) Reset()         { *m = resourceMetrics{} }
+func (m *resourceMetrics) String() string { return proto.CompactTextString(m) }
+func (*resourceMetrics) ProtoMessage()    {}
+
+func (m *scopeMetrics) Reset()         { *m = scopeMetrics{} }
+func (m *scopeMetrics) String() string { return proto.CompactTextString(m) }
+func (*scopeMetrics) ProtoMessage()    {}
+
+func (m *metric) Reset()         { *m = metric{} }
+func (m *metric) String() string { return proto.CompactTextString(m) }
+func (*metric) ProtoMessage()    {}
+
+func (m *gauge) Reset()         { *m = gauge{} }
+func (m *gauge) String() string { return proto.CompactTextString(m) }
+func (*gauge) ProtoMessage()    {}
+
+func (m *numberDataPoint) Reset()         { *m = numberDataPoint{} }
+func (m *numberDataPoint) String() string { return proto.CompactTextString(m) }
+func (*numberDataPoint) ProtoMessage()    {}
+
+func (m *resource) Reset()         { *m = resource{} }
+func (m *resource) String() string { return proto.CompactTextString(m) }
+func (*resource) ProtoMessage()    {}
+
+func (m *instrumentationScope) Reset()         { *m = instrumentationScope{} }
+func (m *instrumentationScope) String() string { return proto.CompactTextString(m) }
+func (*instrumentationScope) ProtoMessage()    {}
+
+func (m *keyValue) Reset()         { *m = keyValue{} }
+func (m *keyValue) String() string { return proto.CompactTextString(m) }
+func (*keyValue) ProtoMessage()    {}
+
+func (m *anyValue) Reset()         { *m = anyValue{} }
+func (m *anyValue) String() string { return proto.CompactTextString(m) }
+func (*anyValue) ProtoMessage()    {}
+
+func (m *arrayValue) Reset()         { *m = arrayValue{} }
+func (m *arrayValue) String() string { return proto.CompactTextString(m) }
+func (*arrayValue) ProtoMessage()    {}
+
+func (m *keyValueList) Reset()         { *m = keyValueList{} }
+func (m *keyValueList) String() string { return proto.CompactTextString(m) }
+func (*keyValueList) ProtoMessage()    {}
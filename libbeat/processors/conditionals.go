@@ -126,6 +126,15 @@ type IfThenElseProcessor struct {
 
 // NewIfElseThenProcessor construct a new IfThenElseProcessor.
 func NewIfElseThenProcessor(cfg *common.Config) (*IfThenElseProcessor, error) {
+	// Reject unknown options, a misspelled else would otherwise be ignored.
+	for _, field := range cfg.GetFields() {
+		switch field {
+		case "if", "then", "else":
+		default:
+			return nil, errors.Errorf("unexpected %v option in if/then/else processor", field)
+		}
+	}
+
 	var config ifThenElseConfig
 	if err := cfg.Unpack(&config); err != nil {
 		return nil, err
@@ -197,6 +197,17 @@ func TestIfElseThenProcessor(t *testing.T) {
       add_fields: {target: "", fields: {uid_type: "gt_500"}}
 `
 
+	const ifThenNestedList = `
+- if:
+    range.uid.lt: 500
+  then:
+    - add_fields: {target: "", fields: {uid_type: reserved}}
+    - if:
+        equals.uid: 0
+      then:
+        - add_fields: {target: "", fields: {root: true}}
+`
+
 	testProcessors(t, map[string]testCase{
 		"if-then-true": {
 			event: common.MapStr{"uid": 411},
@@ -228,5 +239,34 @@ func TestIfElseThenProcessor(t *testing.T) {
 			want:  common.MapStr{"uid": 500, "uid_type": "eq_500"},
 			cfg:   ifThenElseIf,
 		},
+		"if-then-nested-list": {
+			event: common.MapStr{"uid": 0},
+			want:  common.MapStr{"uid": 0, "uid_type": "reserved", "root": true},
+			cfg:   ifThenNestedList,
+		},
 	})
 }
+
+func TestIfElseThenProcessorUnknownOption(t *testing.T) {
+	const cfg = `
+- if:
+    range.uid.lt: 500
+  then:
+    - add_fields: {target: "", fields: {uid_type: reserved}}
+  esle:
+    - add_fields: {target: "", fields: {uid_type: user}}
+`
+
+	c, err := common.NewConfigWithYAML([]byte(cfg), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pluginConfig PluginConfig
+	if err = c.Unpack(&pluginConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = New(pluginConfig)
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decode_kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+type decodeKV struct {
+	kvConfig
+	parser      kvParser
+	includeKeys map[string]struct{}
+	excludeKeys map[string]struct{}
+}
+
+type kvConfig struct {
+	Field         string   `config:"field"`
+	Target        string   `config:"target"`
+	FieldSplit    string   `config:"field_split"`
+	ValueSplit    string   `config:"value_split"`
+	QuoteChars    string   `config:"quote_chars"`
+	EscapeChar    string   `config:"escape_char"`
+	TrimKey       string   `config:"trim_key"`
+	TrimValue     string   `config:"trim_value"`
+	IncludeKeys   []string `config:"include_keys"`
+	ExcludeKeys   []string `config:"exclude_keys"`
+	Prefix        string   `config:"prefix"`
+	IgnoreMissing bool     `config:"ignore_missing"`
+	OverwriteKeys bool     `config:"overwrite_keys"`
+	FailOnError   bool     `config:"fail_on_error"`
+}
+
+var defaultKVConfig = kvConfig{
+	Field:       "message",
+	FieldSplit:  " ",
+	ValueSplit:  "=",
+	QuoteChars:  `"'`,
+	EscapeChar:  `\`,
+	FailOnError: true,
+}
+
+func init() {
+	processors.RegisterPlugin("decode_kv",
+		checks.ConfigChecked(NewDecodeKV,
+			checks.AllowedFields("field", "target", "field_split", "value_split", "quote_chars", "escape_char",
+				"trim_key", "trim_value", "include_keys", "exclude_keys", "prefix", "ignore_missing",
+				"overwrite_keys", "fail_on_error", "when")))
+}
+
+// NewDecodeKV constructs a new decode_kv processor.
+func NewDecodeKV(c *common.Config) (processors.Processor, error) {
+	config := defaultKVConfig
+
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the decode_kv configuration: %s", err)
+	}
+	if config.FieldSplit == "" || config.ValueSplit == "" {
+		return nil, errors.New("field_split and value_split must not be empty")
+	}
+
+	f := &decodeKV{
+		kvConfig: config,
+		parser: kvParser{
+			fieldSplit: config.FieldSplit,
+			valueSplit: config.ValueSplit,
+			quoteChars: config.QuoteChars,
+		},
+		includeKeys: stringSet(config.IncludeKeys),
+		excludeKeys: stringSet(config.ExcludeKeys),
+	}
+	switch n := utf8.RuneCountInString(config.EscapeChar); n {
+	case 0:
+		break
+	case 1:
+		f.parser.escapeChar, _ = utf8.DecodeRuneInString(config.EscapeChar)
+	default:
+		return nil, errors.Errorf("escape_char must be a single character, got %d in string '%s'", n, config.EscapeChar)
+	}
+	return f, nil
+}
+
+func stringSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// Run applies the decode_kv processor to an event.
+func (f *decodeKV) Run(event *beat.Event) (*beat.Event, error) {
+	saved := *event
+	if f.FailOnError {
+		saved.Fields = event.Fields.Clone()
+		saved.Meta = event.Meta.Clone()
+	}
+	if err := f.decodeKV(event); err != nil && f.FailOnError {
+		return &saved, err
+	}
+	return event, nil
+}
+
+func (f *decodeKV) decodeKV(event *beat.Event) error {
+	data, err := event.GetValue(f.Field)
+	if err != nil {
+		if f.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for field %s", f.Field)
+	}
+
+	text, ok := data.(string)
+	if !ok {
+		return errors.Errorf("field %s is not of string type", f.Field)
+	}
+
+	pairs, err := f.parser.parse(text)
+	if err != nil {
+		return errors.Wrapf(err, "error decoding key-value pairs from field %s", f.Field)
+	}
+
+	// Keys are written in the order they first appear. Repeated keys
+	// collect all their values in an array.
+	var keys []string
+	values := map[string]interface{}{}
+	for _, kv := range pairs {
+		key := strings.Trim(kv.key, f.TrimKey)
+		if !f.isIncluded(key) {
+			continue
+		}
+		value := strings.Trim(kv.value, f.TrimValue)
+
+		switch v := values[key].(type) {
+		case nil:
+			keys = append(keys, key)
+			values[key] = value
+		case string:
+			values[key] = []string{v, value}
+		case []string:
+			values[key] = append(v, value)
+		}
+	}
+
+	for _, key := range keys {
+		dest := f.Prefix + key
+		if f.Target != "" {
+			dest = f.Target + "." + dest
+		}
+		if !f.OverwriteKeys {
+			if _, err = event.GetValue(dest); err == nil {
+				return errors.Errorf("target field %s already has a value. Set the overwrite_keys flag or drop/rename the field first", dest)
+			}
+		}
+		if _, err = event.PutValue(dest, values[key]); err != nil {
+			return errors.Wrapf(err, "failed setting field %s", dest)
+		}
+	}
+	return nil
+}
+
+func (f *decodeKV) isIncluded(key string) bool {
+	if key == "" {
+		return false
+	}
+	if f.includeKeys != nil {
+		if _, found := f.includeKeys[key]; !found {
+			return false
+		}
+	}
+	_, excluded := f.excludeKeys[key]
+	return !excluded
+}
+
+// String returns a string representation of this processor.
+func (f decodeKV) String() string {
+	json, _ := json.Marshal(f.kvConfig)
+	return "decode_kv=" + string(json)
+}
@@ -0,0 +1,201 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decode_kv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestDecodeKV(t *testing.T) {
+	tests := map[string]struct {
+		config   common.MapStr
+		input    common.MapStr
+		expected common.MapStr
+		fail     bool
+	}{
+		"logfmt": {
+			config: common.MapStr{
+				"target": "kv",
+			},
+			input: common.MapStr{
+				"message": `at=info method=GET path="/some path" status=200 msg="say \"hi\"" empty= bare`,
+			},
+			expected: common.MapStr{
+				"message":   `at=info method=GET path="/some path" status=200 msg="say \"hi\"" empty= bare`,
+				"kv.at":     "info",
+				"kv.method": "GET",
+				"kv.path":   "/some path",
+				"kv.status": "200",
+				"kv.msg":    `say "hi"`,
+				"kv.empty":  "",
+			},
+		},
+		"iptables": {
+			config: common.MapStr{
+				"target":       "iptables",
+				"include_keys": []string{"SRC", "DST", "PROTO", "SPT", "DPT"},
+				"prefix":       "ip_",
+			},
+			input: common.MapStr{
+				"message": "IN=eth0 OUT= MAC=00:0c:29 SRC=10.0.0.1 DST=10.0.0.2 LEN=60 PROTO=TCP SPT=51234 DPT=22 WINDOW=29200 SYN URGP=0",
+			},
+			expected: common.MapStr{
+				"message":           "IN=eth0 OUT= MAC=00:0c:29 SRC=10.0.0.1 DST=10.0.0.2 LEN=60 PROTO=TCP SPT=51234 DPT=22 WINDOW=29200 SYN URGP=0",
+				"iptables.ip_SRC":   "10.0.0.1",
+				"iptables.ip_DST":   "10.0.0.2",
+				"iptables.ip_PROTO": "TCP",
+				"iptables.ip_SPT":   "51234",
+				"iptables.ip_DPT":   "22",
+			},
+		},
+		"custom separators and trimming": {
+			config: common.MapStr{
+				"field":        "log",
+				"field_split":  ", ",
+				"value_split":  ": ",
+				"quote_chars":  "",
+				"trim_key":     "<>",
+				"trim_value":   "[]",
+				"exclude_keys": []string{"secret"},
+			},
+			input: common.MapStr{
+				"log": "<user>: [alice], secret: x, action: login, path: C:\\Users",
+			},
+			expected: common.MapStr{
+				"log":    "<user>: [alice], secret: x, action: login, path: C:\\Users",
+				"user":   "alice",
+				"action": "login",
+				"path":   "C:\\Users",
+			},
+		},
+		"repeated keys": {
+			config: common.MapStr{
+				"target": "kv",
+			},
+			input: common.MapStr{
+				"message": "tag=a tag=b tag=c",
+			},
+			expected: common.MapStr{
+				"message": "tag=a tag=b tag=c",
+				"kv.tag":  []string{"a", "b", "c"},
+			},
+		},
+		"escaped backslash": {
+			config: common.MapStr{
+				"target": "kv",
+			},
+			input: common.MapStr{
+				"message": `path='C:\dir\\' user='o\'neil'`,
+			},
+			expected: common.MapStr{
+				"message": `path='C:\dir\\' user='o\'neil'`,
+				"kv.path": `C:\dir\`,
+				"kv.user": "o'neil",
+			},
+		},
+		"existing key": {
+			input: common.MapStr{
+				"message": "message=x a=1",
+			},
+			expected: common.MapStr{
+				"message": "message=x a=1",
+			},
+			fail: true,
+		},
+		"overwrite keys": {
+			config: common.MapStr{
+				"overwrite_keys": true,
+			},
+			input: common.MapStr{
+				"message": "message=x a=1",
+			},
+			expected: common.MapStr{
+				"message": "x",
+				"a":       "1",
+			},
+		},
+		"unterminated quote": {
+			input: common.MapStr{
+				"message": `a=1 b="2`,
+			},
+			expected: common.MapStr{
+				"message": `a=1 b="2`,
+			},
+			fail: true,
+		},
+		"unterminated quote without fail_on_error": {
+			config: common.MapStr{
+				"fail_on_error": false,
+			},
+			input: common.MapStr{
+				"message": `a=1 b="2`,
+			},
+			expected: common.MapStr{
+				"message": `a=1 b="2`,
+			},
+			fail: false,
+		},
+		"missing field": {
+			input:    common.MapStr{},
+			expected: common.MapStr{},
+			fail:     true,
+		},
+		"ignore missing field": {
+			config: common.MapStr{
+				"ignore_missing": true,
+			},
+			input:    common.MapStr{},
+			expected: common.MapStr{},
+		},
+	}
+
+	for title, tt := range tests {
+		t.Run(title, func(t *testing.T) {
+			processor, err := NewDecodeKV(common.MustNewConfigFrom(tt.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := processor.Run(&beat.Event{Fields: tt.input})
+			assert.Equal(t, tt.expected.Flatten(), result.Fields.Flatten())
+			if tt.fail {
+				assert.Error(t, err)
+				t.Log("got expected error", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDecodeKVInvalidConfig(t *testing.T) {
+	for title, config := range map[string]common.MapStr{
+		"empty field_split": {"field_split": ""},
+		"empty value_split": {"value_split": ""},
+		"long escape_char":  {"escape_char": "ab"},
+	} {
+		t.Run(title, func(t *testing.T) {
+			_, err := NewDecodeKV(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decode_kv
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// pair is a key and its value, as found in the text.
+type pair struct {
+	key, value string
+}
+
+// kvParser splits text into key-value pairs.
+type kvParser struct {
+	fieldSplit string // Separator between pairs.
+	valueSplit string // Separator between a key and its value.
+	quoteChars string // Characters that can enclose keys and values.
+	escapeChar rune   // Escapes quotes within quoted strings. Zero when disabled.
+}
+
+// parse returns the key-value pairs of s in the order they appear. Keys
+// without a value are skipped.
+func (p *kvParser) parse(s string) ([]pair, error) {
+	var pairs []pair
+	for len(s) > 0 {
+		if strings.HasPrefix(s, p.fieldSplit) {
+			s = s[len(p.fieldSplit):]
+			continue
+		}
+
+		key, rest, err := p.token(s, true)
+		if err != nil {
+			return nil, err
+		}
+		s = rest
+		if !strings.HasPrefix(s, p.valueSplit) {
+			continue
+		}
+		s = s[len(p.valueSplit):]
+
+		value, rest, err := p.token(s, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for key '%v'", key)
+		}
+		s = rest
+
+		if key != "" {
+			pairs = append(pairs, pair{key: key, value: value})
+		}
+	}
+	return pairs, nil
+}
+
+// token reads a key or a value from the start of s and returns it together
+// with the remaining text. Unquoted keys end at the value or field
+// separator, unquoted values end at the field separator.
+func (p *kvParser) token(s string, isKey bool) (string, string, error) {
+	if r, size := utf8.DecodeRuneInString(s); s != "" && strings.ContainsRune(p.quoteChars, r) {
+		return p.quoted(s[size:], r)
+	}
+
+	end := len(s)
+	if idx := strings.Index(s, p.fieldSplit); idx >= 0 {
+		end = idx
+	}
+	if isKey {
+		if idx := strings.Index(s[:end], p.valueSplit); idx >= 0 {
+			end = idx
+		}
+	}
+	return s[:end], s[end:], nil
+}
+
+// quoted reads a string terminated by quote. Within the string the escape
+// character escapes the quote and itself, and is kept as is otherwise.
+func (p *kvParser) quoted(s string, quote rune) (string, string, error) {
+	var buf strings.Builder
+	escaped := false
+	for i, r := range s {
+		switch {
+		case escaped:
+			if r != quote && r != p.escapeChar {
+				buf.WriteRune(p.escapeChar)
+			}
+			buf.WriteRune(r)
+			escaped = false
+		case p.escapeChar != 0 && r == p.escapeChar:
+			escaped = true
+		case r == quote:
+			return buf.String(), s[i+utf8.RuneLen(r):], nil
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return "", "", errors.Errorf("missing closing quote %c", quote)
+}
@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/OneOfOne/xxhash"
+	"github.com/pkg/errors"
+)
+
+type config struct {
+	Fields        []string     `config:"fields" validate:"required"`
+	Target        string       `config:"target_field"`
+	Method        hashMethod   `config:"method"`
+	Encoding      encodingType `config:"encoding"`
+	Key           string       `config:"key"`
+	IgnoreMissing bool         `config:"ignore_missing"`
+}
+
+func defaultConfig() config {
+	return config{
+		Target:   "fingerprint",
+		Method:   methodSHA1,
+		Encoding: encodingHex,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Key != "" && c.Method == methodXXHash {
+		return errors.New("a key can only be used with the sha1 and sha256 methods")
+	}
+	return nil
+}
+
+// newHash returns a new hash.Hash for the configured method. When a key is
+// configured the hash is a keyed HMAC.
+func (c *config) newHash() hash.Hash {
+	var h func() hash.Hash
+	switch c.Method {
+	case methodSHA256:
+		h = sha256.New
+	case methodXXHash:
+		return xxhash.New64()
+	default:
+		h = sha1.New
+	}
+	if c.Key != "" {
+		return hmac.New(h, []byte(c.Key))
+	}
+	return h()
+}
+
+type hashMethod uint8
+
+const (
+	methodSHA1 hashMethod = iota
+	methodSHA256
+	methodXXHash
+)
+
+var hashMethods = map[string]hashMethod{
+	"sha1":   methodSHA1,
+	"sha256": methodSHA256,
+	"xxhash": methodXXHash,
+}
+
+func (m *hashMethod) Unpack(s string) error {
+	method, found := hashMethods[strings.ToLower(s)]
+	if !found {
+		return fmt.Errorf("invalid fingerprint method '%s'", s)
+	}
+	*m = method
+	return nil
+}
+
+func (m hashMethod) String() string {
+	for name, method := range hashMethods {
+		if method == m {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+type encodingType uint8
+
+const (
+	encodingHex encodingType = iota
+	encodingBase64
+)
+
+var encodingTypes = map[string]encodingType{
+	"hex":    encodingHex,
+	"base64": encodingBase64,
+}
+
+func (e *encodingType) Unpack(s string) error {
+	encoding, found := encodingTypes[strings.ToLower(s)]
+	if !found {
+		return fmt.Errorf("invalid fingerprint encoding '%s'", s)
+	}
+	*e = encoding
+	return nil
+}
+
+func (e encodingType) encode(sum []byte) string {
+	if e == encodingBase64 {
+		return base64.StdEncoding.EncodeToString(sum)
+	}
+	return hex.EncodeToString(sum)
+}
@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+const processorName = "fingerprint"
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("fields"),
+			checks.AllowedFields("fields", "target_field", "method", "encoding", "key", "ignore_missing", "when")))
+}
+
+type fingerprint struct {
+	config
+	fields []string
+}
+
+// New constructs a new fingerprint processor. It hashes the values of the
+// configured fields and writes the result to the target field. Setting the
+// target field to `@metadata.id` makes the Elasticsearch output use the hash
+// as document ID, so that events sent more than once are only indexed once.
+func New(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the fingerprint configuration")
+	}
+
+	// Fields are always hashed in the same order, independently of the order
+	// they are configured in.
+	fields := make([]string, len(c.Fields))
+	copy(fields, c.Fields)
+	sort.Strings(fields)
+
+	return &fingerprint{config: c, fields: fields}, nil
+}
+
+func (p *fingerprint) String() string {
+	return fmt.Sprintf("%v=[method=%v, fields=%v, target_field=%v]",
+		processorName, p.Method, p.fields, p.Target)
+}
+
+func (p *fingerprint) Run(event *beat.Event) (*beat.Event, error) {
+	h := p.newHash()
+
+	for _, field := range p.fields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+				continue
+			}
+			return event, errors.Wrapf(err, "failed to compute fingerprint of field %s", field)
+		}
+
+		fmt.Fprintf(h, "|%s|", field)
+		writeValue(h, v)
+	}
+
+	if _, err := event.PutValue(p.Target, p.Encoding.encode(h.Sum(nil))); err != nil {
+		return event, errors.Wrapf(err, "failed to set fingerprint in field %s", p.Target)
+	}
+	return event, nil
+}
+
+// writeValue writes a deterministic representation of v to w. Keys of nested
+// objects are written in sorted order.
+func writeValue(w io.Writer, v interface{}) {
+	switch value := v.(type) {
+	case common.MapStr:
+		writeMap(w, value)
+	case map[string]interface{}:
+		writeMap(w, value)
+	case []interface{}:
+		io.WriteString(w, "[")
+		for _, elem := range value {
+			writeValue(w, elem)
+			io.WriteString(w, ",")
+		}
+		io.WriteString(w, "]")
+	case time.Time:
+		io.WriteString(w, value.UTC().Format(time.RFC3339Nano))
+	case common.Time:
+		io.WriteString(w, time.Time(value).UTC().Format(time.RFC3339Nano))
+	default:
+		fmt.Fprintf(w, "%v", value)
+	}
+}
+
+func writeMap(w io.Writer, m map[string]interface{}) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	io.WriteString(w, "{")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s:", key)
+		writeValue(w, m[key])
+		io.WriteString(w, ",")
+	}
+	io.WriteString(w, "}")
+}
@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestRun(t *testing.T) {
+	evt := func() *beat.Event {
+		return &beat.Event{
+			Fields: common.MapStr{
+				"field1": "foo",
+				"field2": 42,
+				"nested": common.MapStr{
+					"b": common.MapStr{"c": "x"},
+					"a": 1,
+				},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		config   common.MapStr
+		expected string
+	}{
+		"sha1": {
+			config:   common.MapStr{"fields": []string{"field1", "field2"}},
+			expected: "b9d44e0dfe6d983c5a2bee7623f14ea81d7e3b9a",
+		},
+		"stable field order": {
+			config:   common.MapStr{"fields": []string{"field2", "field1"}},
+			expected: "b9d44e0dfe6d983c5a2bee7623f14ea81d7e3b9a",
+		},
+		"sha256": {
+			config:   common.MapStr{"fields": []string{"field1", "field2"}, "method": "sha256"},
+			expected: "c4bbb6316759dea95355f4bfbc5c4a0c820a9f2dc759340d18398501ae1819b2",
+		},
+		"hmac": {
+			config:   common.MapStr{"fields": []string{"field1", "field2"}, "method": "sha256", "key": "secret"},
+			expected: "e594292024e1d690d6a2210be67176172f1cec9e1104408f3d15d18920c01c67",
+		},
+		"base64": {
+			config:   common.MapStr{"fields": []string{"field1", "field2"}, "encoding": "base64"},
+			expected: "udRODf5tmDxaK+52I/FOqB1+O5o=",
+		},
+		"nested object": {
+			config:   common.MapStr{"fields": []string{"nested"}},
+			expected: "b10b3307471f89ede6308260a99dadd7a0f91b22",
+		},
+		"ignore missing": {
+			config:   common.MapStr{"fields": []string{"field1", "field2", "missing"}, "ignore_missing": true},
+			expected: "b9d44e0dfe6d983c5a2bee7623f14ea81d7e3b9a",
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := New(common.MustNewConfigFrom(test.config))
+			require.NoError(t, err)
+
+			event, err := p.Run(evt())
+			require.NoError(t, err)
+
+			v, err := event.GetValue("fingerprint")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestXXHash(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{"fields": []string{"message"}, "method": "xxhash"}))
+	require.NoError(t, err)
+
+	run := func(message string) interface{} {
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"message": message}})
+		require.NoError(t, err)
+		v, err := event.GetValue("fingerprint")
+		require.NoError(t, err)
+		return v
+	}
+
+	first := run("hello")
+	assert.Len(t, first, 16)
+	assert.Equal(t, first, run("hello"))
+	assert.NotEqual(t, first, run("world"))
+}
+
+func TestMetadataTarget(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{"fields": []string{"message"}, "target_field": "@metadata.id"}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"message": "hello"}})
+	require.NoError(t, err)
+	assert.Equal(t, "007663ed2c762d689959535258fd887f10ab4b3f", event.Meta["id"])
+	assert.NotContains(t, event.Fields, "fingerprint")
+}
+
+func TestMissingField(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{"fields": []string{"missing"}}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"message": "hello"}})
+	assert.Error(t, err)
+	assert.NotContains(t, event.Fields, "fingerprint")
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"no fields":      {},
+		"unknown method": {"fields": []string{"message"}, "method": "md5"},
+		"xxhash key":     {"fields": []string{"message"}, "method": "xxhash", "key": "secret"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type config struct {
+	Database      string        `config:"database"`       // Path to a City or Country database.
+	ASNDatabase   string        `config:"asn_database"`   // Path to an ASN database.
+	Fields        []fieldConfig `config:"fields"`         // IP fields to enrich, defaults to the ECS ones.
+	ReloadPeriod  time.Duration `config:"reload_period"`  // How often the databases are checked for changes.
+	CacheSize     int           `config:"cache_size"`     // Maximum number of cached lookups.
+	IgnoreMissing bool          `config:"ignore_missing"` // Skip fields missing from the event.
+}
+
+type fieldConfig struct {
+	From string `config:"from" validate:"required"`
+	To   string `config:"to" validate:"required"`
+}
+
+func defaultConfig() config {
+	return config{
+		ReloadPeriod:  time.Minute,
+		CacheSize:     10000,
+		IgnoreMissing: true,
+	}
+}
+
+// defaultFields are the ECS IP fields that are enriched when no fields are
+// configured. The geo and as objects are added next to the IP field.
+var defaultFields = []fieldConfig{
+	{From: "source.ip", To: "source"},
+	{From: "destination.ip", To: "destination"},
+	{From: "client.ip", To: "client"},
+	{From: "server.ip", To: "server"},
+}
+
+func (c *config) Validate() error {
+	if c.Database == "" && c.ASNDatabase == "" {
+		return errors.New("at least one of database or asn_database must be set")
+	}
+	if c.ReloadPeriod < 0 {
+		return errors.New("reload_period must not be negative")
+	}
+	if c.CacheSize < 0 {
+		return errors.New("cache_size must not be negative")
+	}
+	return nil
+}
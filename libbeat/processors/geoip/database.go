@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"net"
+	"sync"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// cityRecord is the subset of a City or Country database record that is
+// mapped to the ECS geo fields.
+type cityRecord struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Continent struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"continent"`
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+	Subdivisions []struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"subdivisions"`
+}
+
+// asnRecord is an ASN database record.
+type asnRecord struct {
+	Number       uint   `maxminddb:"autonomous_system_number"`
+	Organization string `maxminddb:"autonomous_system_organization"`
+}
+
+// database is a MaxMind database file.
+type database struct {
+	mu     sync.RWMutex
+	reader *maxminddb.Reader
+}
+
+// load opens the database at path, and closes the previously opened version
+// if it succeeds.
+func (db *database) load(path string) error {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open database %s", path)
+	}
+
+	db.mu.Lock()
+	old := db.reader
+	db.reader = reader
+	db.mu.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
+// lookup decodes the record for ip into result. It returns false if the
+// database has no record for the address.
+func (db *database) lookup(ip net.IP, result interface{}) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	offset, err := db.reader.LookupOffset(ip)
+	if err != nil || offset == maxminddb.NotFound {
+		return false, err
+	}
+	return true, db.reader.Decode(offset, result)
+}
+
+func (db *database) close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.reader == nil {
+		return nil
+	}
+	err := db.reader.Close()
+	db.reader = nil
+	return err
+}
+
+// lookupGeo returns the ECS geo fields for ip.
+func (db *database) lookupGeo(ip net.IP) (common.MapStr, error) {
+	var record cityRecord
+	found, err := db.lookup(ip, &record)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	geo := common.MapStr{}
+	putString(geo, "city_name", record.City.Names["en"])
+	putString(geo, "continent_name", record.Continent.Names["en"])
+	putString(geo, "country_iso_code", record.Country.ISOCode)
+	putString(geo, "country_name", record.Country.Names["en"])
+	if len(record.Subdivisions) > 0 {
+		subdivision := record.Subdivisions[0]
+		putString(geo, "region_name", subdivision.Names["en"])
+		if record.Country.ISOCode != "" && subdivision.ISOCode != "" {
+			geo["region_iso_code"] = record.Country.ISOCode + "-" + subdivision.ISOCode
+		}
+	}
+	if record.Location.Latitude != nil && record.Location.Longitude != nil {
+		geo["location"] = common.MapStr{
+			"lat": *record.Location.Latitude,
+			"lon": *record.Location.Longitude,
+		}
+	}
+
+	if len(geo) == 0 {
+		return nil, nil
+	}
+	return geo, nil
+}
+
+// lookupAS returns the ECS as fields for ip.
+func (db *database) lookupAS(ip net.IP) (common.MapStr, error) {
+	var record asnRecord
+	found, err := db.lookup(ip, &record)
+	if err != nil || !found || record.Number == 0 {
+		return nil, err
+	}
+
+	as := common.MapStr{"number": record.Number}
+	if record.Organization != "" {
+		as["organization"] = common.MapStr{"name": record.Organization}
+	}
+	return as, nil
+}
+
+func putString(m common.MapStr, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
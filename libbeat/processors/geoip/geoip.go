@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/joeshaw/multierror"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+	"github.com/elastic/beats/libbeat/processors/util"
+)
+
+const logName = "processor.geoip"
+
+func init() {
+	processors.RegisterPlugin("geoip",
+		checks.ConfigChecked(New,
+			checks.AllowedFields("database", "asn_database", "fields", "reload_period",
+				"cache_size", "ignore_missing", "when")))
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+
+	geoDB     *database
+	asnDB     *database
+	reloaders []*util.FileReloader
+
+	mu    sync.Mutex
+	cache map[string]lookupResult
+}
+
+// lookupResult holds the enrichment found for a single IP address. Addresses
+// without any data are cached as well.
+type lookupResult struct {
+	geo common.MapStr
+	as  common.MapStr
+}
+
+// New constructs a new geoip processor. It adds the ECS geo and as fields
+// next to the configured IP fields, using MaxMind databases read from disk.
+func New(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the geoip configuration")
+	}
+	if len(c.Fields) == 0 {
+		c.Fields = defaultFields
+	}
+
+	p := &processor{
+		config: c,
+		log:    logp.NewLogger(logName),
+		cache:  make(map[string]lookupResult, c.CacheSize),
+	}
+
+	var err error
+	if c.Database != "" {
+		if p.geoDB, err = p.openDatabase(c.Database); err != nil {
+			return nil, err
+		}
+	}
+	if c.ASNDatabase != "" {
+		if p.asnDB, err = p.openDatabase(c.ASNDatabase); err != nil {
+			if p.geoDB != nil {
+				p.geoDB.close()
+			}
+			return nil, err
+		}
+	}
+
+	for _, r := range p.reloaders {
+		r.Start()
+	}
+	return p, nil
+}
+
+// openDatabase opens the database at path. Cached lookups are discarded when
+// the database is reloaded.
+func (p *processor) openDatabase(path string) (*database, error) {
+	db := &database{}
+	reloader, err := util.NewFileReloader(p.log, "database", path, p.ReloadPeriod, func(path string) error {
+		if err := db.load(path); err != nil {
+			return err
+		}
+		p.mu.Lock()
+		p.cache = make(map[string]lookupResult, p.CacheSize)
+		p.mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.reloaders = append(p.reloaders, reloader)
+	return db, nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("geoip=[database=%v, asn_database=%v, fields=%+v]",
+		p.Database, p.ASNDatabase, p.Fields)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	var errs multierror.Errors
+	for _, field := range p.Fields {
+		if err := p.enrich(event, field); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return event, errs.Err()
+}
+
+func (p *processor) enrich(event *beat.Event, field fieldConfig) error {
+	v, err := event.GetValue(field.From)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for field %s", field.From)
+	}
+
+	var ip net.IP
+	switch value := v.(type) {
+	case net.IP:
+		ip = value
+	case string:
+		ip = net.ParseIP(value)
+	}
+	if ip == nil {
+		return errors.Errorf("field %s does not contain a valid IP address: %v", field.From, v)
+	}
+
+	result, err := p.lookup(ip)
+	if err != nil {
+		return errors.Wrapf(err, "failed to look up %s", ip)
+	}
+
+	if result.geo != nil {
+		if _, err := event.PutValue(field.To+".geo", result.geo.Clone()); err != nil {
+			return err
+		}
+	}
+	if result.as != nil {
+		if _, err := event.PutValue(field.To+".as", result.as.Clone()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *processor) lookup(ip net.IP) (lookupResult, error) {
+	key := ip.String()
+
+	p.mu.Lock()
+	result, found := p.cache[key]
+	p.mu.Unlock()
+	if found {
+		return result, nil
+	}
+
+	var err error
+	if p.geoDB != nil {
+		if result.geo, err = p.geoDB.lookupGeo(ip); err != nil {
+			return result, err
+		}
+	}
+	if p.asnDB != nil {
+		if result.as, err = p.asnDB.lookupAS(ip); err != nil {
+			return result, err
+		}
+	}
+
+	if p.CacheSize > 0 {
+		p.mu.Lock()
+		if len(p.cache) >= p.CacheSize {
+			p.evict()
+		}
+		p.cache[key] = result
+		p.mu.Unlock()
+	}
+	return result, nil
+}
+
+// evict removes a single random entry from the cache.
+func (p *processor) evict() {
+	for key := range p.cache {
+		delete(p.cache, key)
+		return
+	}
+}
@@ -0,0 +1,205 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const (
+	cityDatabase = "testdata/GeoIP2-City-Test.mmdb"
+	asnDatabase  = "testdata/GeoLite2-ASN-Test.mmdb"
+)
+
+func TestRun(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"database":     cityDatabase,
+		"asn_database": asnDatabase,
+	}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{
+		Fields: common.MapStr{
+			"source":      common.MapStr{"ip": "81.2.69.160"},
+			"destination": common.MapStr{"ip": "216.160.83.58"},
+			"client":      common.MapStr{"ip": "2001:480::1"},
+			"server":      common.MapStr{"ip": "8.8.8.8"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, common.MapStr{
+		"ip": "81.2.69.160",
+		"geo": common.MapStr{
+			"city_name":        "London",
+			"continent_name":   "Europe",
+			"country_iso_code": "GB",
+			"country_name":     "United Kingdom",
+			"region_name":      "England",
+			"region_iso_code":  "GB-ENG",
+			"location":         common.MapStr{"lat": 51.5142, "lon": -0.0931},
+		},
+		"as": common.MapStr{
+			"number":       uint(20712),
+			"organization": common.MapStr{"name": "Andrews & Arnold Ltd"},
+		},
+	}, event.Fields["source"])
+
+	city, _ := event.GetValue("destination.geo.city_name")
+	assert.Equal(t, "Milton", city)
+	_, err = event.GetValue("destination.as")
+	assert.Error(t, err)
+
+	city, _ = event.GetValue("client.geo.city_name")
+	assert.Equal(t, "San Diego", city)
+
+	assert.Equal(t, common.MapStr{"ip": "8.8.8.8"}, event.Fields["server"])
+}
+
+func TestCustomFields(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"asn_database": asnDatabase,
+		"fields": []common.MapStr{
+			{"from": "remote_addr", "to": "remote"},
+		},
+	}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{
+		Fields: common.MapStr{
+			"remote_addr": "1.128.0.1",
+			"source":      common.MapStr{"ip": "81.2.69.160"},
+		},
+	})
+	require.NoError(t, err)
+
+	number, _ := event.GetValue("remote.as.number")
+	assert.Equal(t, uint(1221), number)
+	_, err = event.GetValue("source.as")
+	assert.Error(t, err, "default fields must not be enriched")
+}
+
+func TestErrors(t *testing.T) {
+	t.Run("missing field", func(t *testing.T) {
+		p, err := New(common.MustNewConfigFrom(common.MapStr{
+			"database":       cityDatabase,
+			"ignore_missing": false,
+			"fields":         []common.MapStr{{"from": "source.ip", "to": "source"}},
+		}))
+		require.NoError(t, err)
+		_, err = p.Run(&beat.Event{Fields: common.MapStr{}})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid IP", func(t *testing.T) {
+		p, err := New(common.MustNewConfigFrom(common.MapStr{"database": cityDatabase}))
+		require.NoError(t, err)
+		event, err := p.Run(&beat.Event{
+			Fields: common.MapStr{
+				"source":      common.MapStr{"ip": "not an ip"},
+				"destination": common.MapStr{"ip": "81.2.69.160"},
+			},
+		})
+		assert.Error(t, err)
+
+		city, _ := event.GetValue("destination.geo.city_name")
+		assert.Equal(t, "London", city, "valid fields must still be enriched")
+	})
+
+	t.Run("no database", func(t *testing.T) {
+		_, err := New(common.MustNewConfigFrom(common.MapStr{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("database not found", func(t *testing.T) {
+		_, err := New(common.MustNewConfigFrom(common.MapStr{"database": "testdata/missing.mmdb"}))
+		assert.Error(t, err)
+	})
+}
+
+func TestCache(t *testing.T) {
+	proc, err := New(common.MustNewConfigFrom(common.MapStr{"database": cityDatabase, "cache_size": 1}))
+	require.NoError(t, err)
+	p := proc.(*processor)
+
+	run := func(ip string) {
+		_, err := p.Run(&beat.Event{Fields: common.MapStr{"source": common.MapStr{"ip": ip}}})
+		require.NoError(t, err)
+	}
+
+	run("81.2.69.160")
+	assert.Len(t, p.cache, 1)
+	assert.Contains(t, p.cache, "81.2.69.160")
+
+	run("216.160.83.58")
+	assert.Len(t, p.cache, 1)
+	assert.Contains(t, p.cache, "216.160.83.58")
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geoip")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "GeoIP2-City.mmdb")
+	copyFile(t, cityDatabase, path)
+
+	proc, err := New(common.MustNewConfigFrom(common.MapStr{"database": path, "reload_period": "1h"}))
+	require.NoError(t, err)
+	p := proc.(*processor)
+	defer p.geoDB.close()
+	defer p.reloaders[0].Stop()
+
+	lookup := func() interface{} {
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"source": common.MapStr{"ip": "81.2.69.160"}}})
+		require.NoError(t, err)
+		city, _ := event.GetValue("source.geo.city_name")
+		return city
+	}
+	assert.Equal(t, "London", lookup())
+
+	// Replace the database with one that has no geo data for the address. The
+	// file is renamed over the old one, as the open database is memory mapped.
+	copyFile(t, asnDatabase, path+".tmp")
+	require.NoError(t, os.Rename(path+".tmp", path))
+	modTime := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	// Nothing is reloaded before the reload period has passed.
+	assert.False(t, p.reloaders[0].ReloadIfChanged(time.Now()))
+	assert.Equal(t, "London", lookup())
+
+	assert.True(t, p.reloaders[0].ReloadIfChanged(time.Now().Add(2*time.Hour)))
+	assert.Nil(t, lookup())
+}
+
+func copyFile(t *testing.T, from, to string) {
+	data, err := ioutil.ReadFile(from)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(to, data, 0644))
+}
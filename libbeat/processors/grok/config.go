@@ -0,0 +1,50 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config defines the configuration options for the grok processor.
+type Config struct {
+	Field              string            `config:"field"`                        // Field containing the text to parse.
+	Patterns           []string          `config:"patterns" validate:"required"` // Patterns tried in order until one matches.
+	PatternDefinitions map[string]string `config:"pattern_definitions"`          // Custom patterns, overriding the standard library.
+	Timeout            time.Duration     `config:"timeout" validate:"min=0"`     // Maximum time spent matching a single pattern.
+	IgnoreMissing      bool              `config:"ignore_missing"`               // Skip events without the source field.
+	TagOnFailure       []string          `config:"tag_on_failure"`               // Tags to append when no pattern matches.
+}
+
+func defaultConfig() Config {
+	return Config{
+		Field:        "message",
+		Timeout:      time.Second,
+		TagOnFailure: []string{"_grokparsefailure"},
+	}
+}
+
+// Validate validates the grok processor configuration.
+func (c *Config) Validate() error {
+	if len(c.Patterns) == 0 {
+		return errors.New("at least one pattern is required")
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+const (
+	processorName = "grok"
+	logName       = "processor." + processorName
+)
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("patterns"),
+			checks.AllowedFields("field", "patterns", "pattern_definitions", "timeout",
+				"ignore_missing", "tag_on_failure", "when")))
+}
+
+type processor struct {
+	Config
+	patterns []*pattern
+	metrics  processorMetrics
+	log      *logp.Logger
+}
+
+type processorMetrics struct {
+	matches  []*monitoring.Int // Number of events matched by each pattern.
+	failures *monitoring.Int   // Number of events not matched by any pattern.
+	errors   *monitoring.Int   // Number of match timeouts and conversion errors.
+}
+
+// New constructs a new grok processor.
+func New(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the grok configuration")
+	}
+
+	compiler := newCompiler(c.PatternDefinitions)
+	patterns := make([]*pattern, 0, len(c.Patterns))
+	for _, source := range c.Patterns {
+		p, err := compiler.Compile(source, c.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+
+	// Logging and metrics (each processor instance has a unique ID).
+	var (
+		id  = int(instanceID.Inc())
+		log = logp.NewLogger(logName).With("instance_id", id)
+		reg = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	metrics := processorMetrics{
+		failures: monitoring.NewInt(reg, "failures"),
+		errors:   monitoring.NewInt(reg, "errors"),
+	}
+	patternsReg := reg.NewRegistry("patterns")
+	for i := range patterns {
+		metrics.matches = append(metrics.matches,
+			monitoring.NewInt(patternsReg.NewRegistry(strconv.Itoa(i)), "matches"))
+	}
+
+	return &processor{
+		Config:   c,
+		patterns: patterns,
+		metrics:  metrics,
+		log:      log,
+	}, nil
+}
+
+func (p *processor) String() string {
+	sources := make([]string, 0, len(p.patterns))
+	for _, pat := range p.patterns {
+		sources = append(sources, pat.String())
+	}
+	return fmt.Sprintf("%v=[field=%v, patterns=[%v]]",
+		processorName, p.Field, strings.Join(sources, ", "))
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if err := p.match(event); err != nil {
+		p.log.Debugf("grok failed: %v", err)
+		common.AddTags(event.Fields, p.TagOnFailure)
+	}
+	return event, nil
+}
+
+func (p *processor) match(event *beat.Event) error {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for field %s", p.Field)
+	}
+
+	text, ok := v.(string)
+	if !ok {
+		return errors.Errorf("field %s is not a string but %T", p.Field, v)
+	}
+
+	for i, pat := range p.patterns {
+		values, err := pat.Match(text)
+		if err != nil {
+			p.metrics.errors.Inc()
+			return errors.Wrapf(err, "failed to match pattern '%v'", pat)
+		}
+		if values == nil {
+			continue
+		}
+
+		p.metrics.matches[i].Inc()
+		for field, value := range values {
+			if _, err := event.PutValue(field, value); err != nil {
+				return errors.Wrapf(err, "failed to set field %v", field)
+			}
+		}
+		return nil
+	}
+
+	p.metrics.failures.Inc()
+	return errors.Errorf("field %s does not match any pattern", p.Field)
+}
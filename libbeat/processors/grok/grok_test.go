@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestRun(t *testing.T) {
+	proc, err := New(common.MustNewConfigFrom(common.MapStr{
+		"patterns": []string{
+			`^%{IP:source.ip} %{WORD:http.request.method} %{URIPATHPARAM:url.original} %{NUMBER:http.response.bytes:long}$`,
+			`^%{IP:source.ip} %{GREEDYDATA:error.message}$`,
+		},
+	}))
+	require.NoError(t, err)
+	p := proc.(*processor)
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"message": "10.0.0.1 GET /index.html?a=b 1234"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"message": "10.0.0.1 GET /index.html?a=b 1234",
+		"source":  common.MapStr{"ip": "10.0.0.1"},
+		"http": common.MapStr{
+			"request":  common.MapStr{"method": "GET"},
+			"response": common.MapStr{"bytes": int64(1234)},
+		},
+		"url": common.MapStr{"original": "/index.html?a=b"},
+	}, event.Fields)
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"message": "10.0.0.2 connection reset"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"message": "10.0.0.2 connection reset",
+		"source":  common.MapStr{"ip": "10.0.0.2"},
+		"error":   common.MapStr{"message": "connection reset"},
+	}, event.Fields)
+
+	assert.EqualValues(t, 1, p.metrics.matches[0].Get())
+	assert.EqualValues(t, 1, p.metrics.matches[1].Get())
+	assert.EqualValues(t, 0, p.metrics.failures.Get())
+}
+
+func TestFailures(t *testing.T) {
+	proc, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":    "log.original",
+		"patterns": []string{`^%{INT:code:int}$`},
+	}))
+	require.NoError(t, err)
+	p := proc.(*processor)
+
+	// Unmatched values are counted as failures, values that can not be
+	// read or converted as errors. Both are tagged with _grokparsefailure.
+	for name, fields := range map[string]common.MapStr{
+		"no match":           {"log": common.MapStr{"original": "abc"}},
+		"not a string":       {"log": common.MapStr{"original": 42}},
+		"missing":            {},
+		"conversion failure": {"log": common.MapStr{"original": "99999999999"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			event, err := p.Run(&beat.Event{Fields: fields})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"_grokparsefailure"}, event.Fields["tags"])
+			assert.NotContains(t, event.Fields, "code")
+		})
+	}
+
+	assert.EqualValues(t, 1, p.metrics.failures.Get())
+	assert.EqualValues(t, 1, p.metrics.errors.Get())
+	assert.EqualValues(t, 0, p.metrics.matches[0].Get())
+}
+
+func TestIgnoreMissing(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"patterns":       []string{`%{WORD:word}`},
+		"ignore_missing": true,
+	}))
+	require.NoError(t, err)
+
+	// Only missing fields are ignored, fields that are not strings are still
+	// tagged.
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{}})
+	require.NoError(t, err)
+	assert.Empty(t, event.Fields)
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"message": 42}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"_grokparsefailure"}, event.Fields["tags"])
+}
+
+func TestPatternDefinitions(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"patterns": []string{`%{STATUS:status}`},
+		"pattern_definitions": map[string]string{
+			"STATUS": `(?:OK|FAILED)`,
+		},
+	}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"message": "job FAILED"}})
+	require.NoError(t, err)
+	assert.Equal(t, "FAILED", event.Fields["status"])
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"no patterns":       {"patterns": []string{}},
+		"undefined pattern": {"patterns": []string{"%{NOPE}"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dlclark/regexp2"
+	"github.com/pkg/errors"
+)
+
+// referenceRegexp matches pattern references of the form %{NAME},
+// %{NAME:field} and %{NAME:field:type}.
+var referenceRegexp = regexp.MustCompile(`%\{(\w+)(?::([\w@.\[\]-]+))?(?::(\w+))?\}`)
+
+// capture describes a named group of a compiled pattern.
+type capture struct {
+	group string   // Name of the group in the regular expression.
+	field string   // Event field the captured value is written to.
+	typ   dataType // Type the captured value is converted to.
+}
+
+// pattern is a grok expression compiled to a regular expression.
+type pattern struct {
+	source   string
+	re       *regexp2.Regexp
+	captures []capture
+}
+
+// compiler expands grok expressions using a pattern library.
+type compiler struct {
+	library map[string]string
+}
+
+func newCompiler(definitions map[string]string) *compiler {
+	library := make(map[string]string, len(standardPatterns)+len(definitions))
+	for name, p := range standardPatterns {
+		library[name] = p
+	}
+	for name, p := range definitions {
+		library[name] = p
+	}
+	return &compiler{library: library}
+}
+
+// Compile compiles a grok expression. Only named references capture values.
+func (c *compiler) Compile(source string, timeout time.Duration) (*pattern, error) {
+	p := &pattern{source: source}
+
+	expr, err := c.expand(source, p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexp2.Compile(expr, regexp2.ExplicitCapture)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile grok pattern '%v'", source)
+	}
+	if timeout > 0 {
+		re.MatchTimeout = timeout
+	}
+	p.re = re
+
+	// Named groups written directly as regular expressions, like
+	// (?<queue_id>[0-9A-F]+), are captured as strings.
+	for _, name := range re.GetGroupNames() {
+		if _, err := strconv.Atoi(name); err == nil || strings.HasPrefix(name, groupPrefix) {
+			continue
+		}
+		p.captures = append(p.captures, capture{group: name, field: name, typ: typeString})
+	}
+
+	return p, nil
+}
+
+const groupPrefix = "_grok"
+
+// expand recursively replaces all pattern references in expr. The stack of
+// patterns being expanded is used to detect cycles.
+func (c *compiler) expand(expr string, p *pattern, stack []string) (string, error) {
+	var err error
+	expanded := referenceRegexp.ReplaceAllStringFunc(expr, func(ref string) string {
+		if err != nil {
+			return ""
+		}
+
+		parts := referenceRegexp.FindStringSubmatch(ref)
+		name, field, typeName := parts[1], parts[2], parts[3]
+
+		for _, s := range stack {
+			if s == name {
+				err = errors.Errorf("circular reference in pattern %v: %v",
+					name, strings.Join(append(stack, name), " -> "))
+				return ""
+			}
+		}
+
+		definition, found := c.library[name]
+		if !found {
+			err = errors.Errorf("pattern %v is not defined", name)
+			return ""
+		}
+
+		var sub string
+		sub, err = c.expand(definition, p, append(stack, name))
+		if err != nil {
+			return ""
+		}
+
+		if field == "" {
+			return "(?:" + sub + ")"
+		}
+
+		typ := typeString
+		if typeName != "" {
+			if typ, err = parseDataType(typeName); err != nil {
+				return ""
+			}
+		}
+
+		group := groupPrefix + strconv.Itoa(len(p.captures))
+		p.captures = append(p.captures, capture{group: group, field: fieldName(field), typ: typ})
+		return "(?<" + group + ">" + sub + ")"
+	})
+	return expanded, err
+}
+
+// fieldName converts the Logstash [nested][field] syntax to a dotted field
+// name.
+func fieldName(s string) string {
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return s
+	}
+	return strings.Join(strings.Split(s[1:len(s)-1], "]["), ".")
+}
+
+// Match matches the pattern against text and returns the converted values of
+// all groups that participated in the match, keyed by field name. It returns
+// nil if the pattern does not match.
+func (p *pattern) Match(text string) (map[string]interface{}, error) {
+	m, err := p.re.FindStringMatch(text)
+	if err != nil || m == nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(p.captures))
+	for _, c := range p.captures {
+		g := m.GroupByName(c.group)
+		if g == nil || len(g.Captures) == 0 {
+			continue
+		}
+
+		v, err := c.typ.convert(g.String())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert field %v", c.field)
+		}
+		values[c.field] = v
+	}
+	return values, nil
+}
+
+func (p *pattern) String() string {
+	return p.source
+}
+
+// dataType is the type a captured value is converted to.
+type dataType uint8
+
+const (
+	typeString dataType = iota
+	typeInt
+	typeLong
+	typeFloat
+	typeDouble
+	typeBoolean
+)
+
+var dataTypeNames = map[dataType]string{
+	typeString:  "string",
+	typeInt:     "int",
+	typeLong:    "long",
+	typeFloat:   "float",
+	typeDouble:  "double",
+	typeBoolean: "boolean",
+}
+
+func (dt dataType) String() string {
+	return dataTypeNames[dt]
+}
+
+func parseDataType(s string) (dataType, error) {
+	for dt, name := range dataTypeNames {
+		if name == s {
+			return dt, nil
+		}
+	}
+	return typeString, errors.Errorf("invalid data type '%v'", s)
+}
+
+func (dt dataType) convert(s string) (interface{}, error) {
+	switch dt {
+	case typeInt:
+		v, err := strconv.ParseInt(s, 10, 32)
+		return int32(v), err
+	case typeLong:
+		return strconv.ParseInt(s, 10, 64)
+	case typeFloat:
+		v, err := strconv.ParseFloat(s, 32)
+		return float32(v), err
+	case typeDouble:
+		return strconv.ParseFloat(s, 64)
+	case typeBoolean:
+		return strconv.ParseBool(s)
+	case typeString:
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unexpected data type %v", dt)
+	}
+}
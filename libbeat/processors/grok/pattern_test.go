@@ -0,0 +1,163 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandardPatternsCompile(t *testing.T) {
+	c := newCompiler(nil)
+	for name := range standardPatterns {
+		_, err := c.Compile("%{"+name+"}", 0)
+		assert.NoError(t, err, "pattern %v", name)
+	}
+}
+
+func TestMatchStandardPatterns(t *testing.T) {
+	cases := map[string]struct {
+		pattern  string
+		text     string
+		expected map[string]interface{}
+	}{
+		"combined apache log": {
+			pattern: "%{COMBINEDAPACHELOG}",
+			text:    `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			expected: map[string]interface{}{
+				"clientip":    "127.0.0.1",
+				"ident":       "-",
+				"auth":        "frank",
+				"timestamp":   "10/Oct/2000:13:55:36 -0700",
+				"verb":        "GET",
+				"request":     "/apache_pb.gif",
+				"httpversion": "1.0",
+				"response":    "200",
+				"bytes":       "2326",
+				"referrer":    `"http://www.example.com/start.html"`,
+				"agent":       `"Mozilla/4.08"`,
+			},
+		},
+		"syslog line": {
+			pattern: "%{SYSLOGLINE}",
+			text:    "Jun  4 08:15:01 myhost CRON[1234]: (root) CMD (run-parts /etc/cron.hourly)",
+			expected: map[string]interface{}{
+				"timestamp": "Jun  4 08:15:01",
+				"logsource": "myhost",
+				"program":   "CRON",
+				"pid":       "1234",
+				"message":   "(root) CMD (run-parts /etc/cron.hourly)",
+			},
+		},
+		"ipv6": {
+			pattern:  "client=%{IP:client}",
+			text:     "client=2001:db8::ff00:42:8329",
+			expected: map[string]interface{}{"client": "2001:db8::ff00:42:8329"},
+		},
+		"iso8601": {
+			pattern:  "^%{TIMESTAMP_ISO8601:ts} %{LOGLEVEL:level}",
+			text:     "2019-05-30T01:51:12.123Z WARN something happened",
+			expected: map[string]interface{}{"ts": "2019-05-30T01:51:12.123Z", "level": "WARN"},
+		},
+	}
+
+	c := newCompiler(nil)
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := c.Compile(test.pattern, 0)
+			require.NoError(t, err)
+
+			values, err := p.Match(test.text)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	c := newCompiler(map[string]string{
+		"QUEUEID": `[0-9A-F]{10,11}`,
+		"LOOPA":   `%{LOOPB}`,
+		"LOOPB":   `x%{LOOPA}`,
+	})
+
+	t.Run("type coercion", func(t *testing.T) {
+		p, err := c.Compile(`%{INT:a:int} %{INT:b:long} %{NUMBER:c:float} %{NUMBER:d:double} %{WORD:e:boolean} %{WORD:f:string}`, 0)
+		require.NoError(t, err)
+
+		values, err := p.Match("1 2 1.5 2.5 true x")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"a": int32(1),
+			"b": int64(2),
+			"c": float32(1.5),
+			"d": 2.5,
+			"e": true,
+			"f": "x",
+		}, values)
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		p, err := c.Compile(`%{WORD:a:int}`, 0)
+		require.NoError(t, err)
+
+		_, err = p.Match("abc")
+		assert.Error(t, err)
+	})
+
+	t.Run("custom and inline named patterns", func(t *testing.T) {
+		p, err := c.Compile(`%{QUEUEID:[postfix][queue_id]}: (?<action>\w+)`, 0)
+		require.NoError(t, err)
+
+		values, err := p.Match("ABCDEF0123: reject")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"postfix.queue_id": "ABCDEF0123", "action": "reject"}, values)
+	})
+
+	t.Run("optional group not matched", func(t *testing.T) {
+		p, err := c.Compile(`%{WORD:a}(?: %{INT:b})?$`, 0)
+		require.NoError(t, err)
+
+		values, err := p.Match("hello")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"a": "hello"}, values)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		p, err := c.Compile(`^%{INT:a}$`, 0)
+		require.NoError(t, err)
+
+		values, err := p.Match("abc")
+		require.NoError(t, err)
+		assert.Nil(t, values)
+	})
+
+	for name, source := range map[string]string{
+		"undefined pattern":  "%{NOPE}",
+		"circular reference": "%{LOOPA}",
+		"invalid type":       "%{INT:a:number}",
+		"invalid regexp":     "%{INT:a}(",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.Compile(source, 0)
+			assert.Error(t, err)
+		})
+	}
+}
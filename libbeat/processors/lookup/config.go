@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lookup
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+type config struct {
+	Field         string        `config:"field" validate:"required"` // Field whose value is looked up.
+	TargetField   string        `config:"target_field"`              // Object the matched columns are written to.
+	Path          string        `config:"path" validate:"required"`  // Path to the CSV or JSON table.
+	Format        string        `config:"format"`                    // Format of the table, csv or json.
+	Key           string        `config:"key"`                       // Column containing the keys.
+	Columns       []string      `config:"columns"`                   // Columns to copy, defaults to all but the key.
+	Separator     string        `config:"separator"`                 // Separator of CSV columns.
+	Default       common.MapStr `config:"default"`                   // Columns written when no key matches.
+	ReloadPeriod  time.Duration `config:"reload_period"`             // How often the table is checked for changes.
+	IgnoreMissing bool          `config:"ignore_missing"`            // Skip events without the source field.
+	TagOnFailure  []string      `config:"tag_on_failure"`            // Tags to append when a failure occurs.
+}
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+func defaultConfig() config {
+	return config{
+		Separator:    ",",
+		ReloadPeriod: time.Minute,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Format == "" {
+		c.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(c.Path)), ".")
+	}
+	switch c.Format {
+	case formatCSV:
+		if utf8.RuneCountInString(c.Separator) != 1 {
+			return errors.Errorf("separator must be a single character, got '%v'", c.Separator)
+		}
+	case formatJSON:
+	default:
+		return errors.Errorf("unsupported format '%v', set format to csv or json", c.Format)
+	}
+	if c.ReloadPeriod < 0 {
+		return errors.New("reload_period must not be negative")
+	}
+	return nil
+}
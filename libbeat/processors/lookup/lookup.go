@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lookup
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+	"github.com/elastic/beats/libbeat/processors/util"
+)
+
+const (
+	processorName = "lookup"
+	logName       = "processor." + processorName
+)
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("field", "path"),
+			checks.AllowedFields("field", "target_field", "path", "format", "key", "columns",
+				"separator", "default", "reload_period", "ignore_missing", "tag_on_failure", "when")))
+}
+
+type processor struct {
+	config
+	log      *logp.Logger
+	table    *table
+	reloader *util.FileReloader
+}
+
+// New constructs a new lookup processor. It looks up the value of a field in
+// a table read from a CSV or JSON file, and copies the columns of the
+// matching entry to the event.
+func New(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the lookup configuration")
+	}
+
+	log := logp.NewLogger(logName)
+	t := newTable(c)
+	reloader, err := util.NewFileReloader(log, "lookup table",
+		paths.Resolve(paths.Config, c.Path), c.ReloadPeriod, t.load)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("Loaded %d entries from lookup table %s", t.len(), reloader.Path)
+	reloader.Start()
+
+	return &processor{
+		config:   c,
+		log:      log,
+		table:    t,
+		reloader: reloader,
+	}, nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[field=%v, target_field=%v, path=%v, key=%v, columns=%v]",
+		processorName, p.Field, p.TargetField, p.Path, p.Key, p.Columns)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if err := p.enrich(event); err != nil {
+		p.log.Debugf("lookup failed: %v", err)
+		common.AddTags(event.Fields, p.TagOnFailure)
+	}
+	return event, nil
+}
+
+func (p *processor) enrich(event *beat.Event) error {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for field %s", p.Field)
+	}
+
+	var key string
+	switch value := v.(type) {
+	case string:
+		key = value
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		key = fmt.Sprint(value)
+	default:
+		return errors.Errorf("field %s is not a string or a number but %T", p.Field, v)
+	}
+
+	columns, found := p.table.lookup(key)
+	if !found {
+		if p.Default == nil {
+			return nil
+		}
+		columns = p.Default
+	}
+
+	for column, value := range columns.Clone() {
+		if _, err := event.PutValue(p.targetKey(column), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *processor) targetKey(column string) string {
+	if p.TargetField == "" {
+		return column
+	}
+	return p.TargetField + "." + column
+}
@@ -0,0 +1,215 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lookup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func TestLookupCSV(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field": "host.name",
+		"path":  "testdata/hosts.csv",
+	}))
+	require.NoError(t, err)
+
+	fields := run(t, p, common.MapStr{"host": common.MapStr{"name": "db-01"}})
+	assert.Equal(t, common.MapStr{
+		"host":    common.MapStr{"name": "db-01"},
+		"owner":   "team-data",
+		"service": common.MapStr{"tier": "backend, critical"},
+	}, fields)
+
+	// Events without a match are not modified.
+	fields = run(t, p, common.MapStr{"host": common.MapStr{"name": "unknown"}})
+	assert.Equal(t, common.MapStr{"host": common.MapStr{"name": "unknown"}}, fields)
+}
+
+func TestLookupCSVColumns(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":        "owner",
+		"path":         "testdata/hosts.csv",
+		"key":          "owner",
+		"columns":      []string{"hostname"},
+		"target_field": "asset",
+	}))
+	require.NoError(t, err)
+
+	fields := run(t, p, common.MapStr{"owner": "team-web"})
+	assert.Equal(t, common.MapStr{
+		"owner": "team-web",
+		"asset": common.MapStr{"hostname": "web-01"},
+	}, fields)
+}
+
+func TestLookupJSONObject(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":        "host.name",
+		"path":         "testdata/hosts.json",
+		"target_field": "asset",
+	}))
+	require.NoError(t, err)
+
+	fields := run(t, p, common.MapStr{"host": common.MapStr{"name": "web-01"}})
+	assert.Equal(t, common.MapStr{
+		"host": common.MapStr{"name": "web-01"},
+		"asset": common.MapStr{
+			"owner":   "team-web",
+			"service": common.MapStr{"tier": "frontend"},
+		},
+	}, fields)
+}
+
+func TestLookupJSONArray(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":        "http.response.status_code",
+		"path":         "testdata/http_status.json",
+		"key":          "code",
+		"target_field": "http.response.status",
+	}))
+	require.NoError(t, err)
+
+	fields := run(t, p, common.MapStr{"http": common.MapStr{"response": common.MapStr{"status_code": 503}}})
+	assert.Equal(t, common.MapStr{
+		"http": common.MapStr{
+			"response": common.MapStr{
+				"status_code": 503,
+				"status": common.MapStr{
+					"description": "Service Unavailable",
+					"retryable":   true,
+				},
+			},
+		},
+	}, fields)
+}
+
+func TestLookupDefault(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":   "host.name",
+		"path":    "testdata/hosts.csv",
+		"default": common.MapStr{"owner": "unassigned"},
+	}))
+	require.NoError(t, err)
+
+	fields := run(t, p, common.MapStr{"host": common.MapStr{"name": "unknown"}})
+	assert.Equal(t, common.MapStr{
+		"host":  common.MapStr{"name": "unknown"},
+		"owner": "unassigned",
+	}, fields)
+
+	// Modifying an event does not modify the default of the next ones.
+	fields.Put("owner", "modified")
+	fields = run(t, p, common.MapStr{"host": common.MapStr{"name": "unknown"}})
+	assert.Equal(t, "unassigned", fields["owner"])
+}
+
+func TestLookupFailure(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":          "host.name",
+		"path":           "testdata/hosts.csv",
+		"tag_on_failure": []string{"_lookup_failure"},
+	}))
+	require.NoError(t, err)
+
+	fields := run(t, p, common.MapStr{"host": common.MapStr{"name": []string{"web-01"}}})
+	assert.Equal(t, []string{"_lookup_failure"}, fields["tags"])
+
+	fields = run(t, p, common.MapStr{})
+	assert.Equal(t, []string{"_lookup_failure"}, fields["tags"])
+
+	p, err = New(common.MustNewConfigFrom(common.MapStr{
+		"field":          "host.name",
+		"path":           "testdata/hosts.csv",
+		"ignore_missing": true,
+		"tag_on_failure": []string{"_lookup_failure"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{}, run(t, p, common.MapStr{}))
+}
+
+func TestLookupReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lookup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "owners.csv")
+	require.NoError(t, ioutil.WriteFile(path, []byte("hostname,owner\nweb-01,team-web\n"), 0600))
+
+	proc, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":         "host.name",
+		"path":          path,
+		"reload_period": "1h",
+	}))
+	require.NoError(t, err)
+	p := proc.(*processor)
+	defer p.reloader.Stop()
+
+	event := common.MapStr{"host": common.MapStr{"name": "web-01"}}
+	assert.Equal(t, "team-web", run(t, p, event.Clone())["owner"])
+
+	// The table is not reloaded before the reload period.
+	require.NoError(t, ioutil.WriteFile(path, []byte("hostname,owner\nweb-01,team-platform\n"), 0600))
+	assert.False(t, p.reloader.ReloadIfChanged(time.Now()))
+	assert.Equal(t, "team-web", run(t, p, event.Clone())["owner"])
+
+	assert.True(t, p.reloader.ReloadIfChanged(time.Now().Add(2*time.Hour)))
+	assert.Equal(t, "team-platform", run(t, p, event.Clone())["owner"])
+
+	// An invalid table is ignored and the previous version is kept.
+	require.NoError(t, ioutil.WriteFile(path, []byte("hostname,owner\nweb-01,team-web,extra\n"), 0600))
+	assert.False(t, p.reloader.ReloadIfChanged(time.Now().Add(4*time.Hour)))
+	assert.Equal(t, "team-platform", run(t, p, event.Clone())["owner"])
+}
+
+func TestLookupInvalidConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"missing file":        {"field": "a", "path": "testdata/missing.csv"},
+		"unknown format":      {"field": "a", "path": "testdata/hosts.txt"},
+		"invalid format":      {"field": "a", "path": "testdata/hosts.csv", "format": "xml"},
+		"unknown key":         {"field": "a", "path": "testdata/hosts.csv", "key": "ip"},
+		"unknown column":      {"field": "a", "path": "testdata/hosts.csv", "columns": []string{"ip"}},
+		"invalid separator":   {"field": "a", "path": "testdata/hosts.csv", "separator": "::"},
+		"JSON without key":    {"field": "a", "path": "testdata/http_status.json"},
+		"JSON key not found":  {"field": "a", "path": "testdata/http_status.json", "key": "status"},
+		"CSV read as JSON":    {"field": "a", "path": "testdata/hosts.csv", "format": "json"},
+		"negative reload":     {"field": "a", "path": "testdata/hosts.csv", "reload_period": "-1s"},
+		"missing path option": {"field": "a"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func run(t *testing.T, p processors.Processor, fields common.MapStr) common.MapStr {
+	event, err := p.Run(&beat.Event{Fields: fields})
+	require.NoError(t, err)
+	return event.Fields
+}
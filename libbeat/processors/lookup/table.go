@@ -0,0 +1,208 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package lookup
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/jsontransform"
+)
+
+// table is a lookup table read from a CSV or JSON file. Its entries are
+// swapped atomically when the file is loaded again.
+type table struct {
+	format    string
+	key       string
+	columns   []string
+	separator rune
+
+	entries atomic.Value // map[string]common.MapStr
+}
+
+func newTable(c config) *table {
+	separator, _ := utf8.DecodeRuneInString(c.Separator)
+	return &table{
+		format:    c.Format,
+		key:       c.Key,
+		columns:   c.Columns,
+		separator: separator,
+	}
+}
+
+// load reads the table from path. The entries in use are only replaced if
+// the whole file could be parsed.
+func (t *table) load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read lookup table %s", path)
+	}
+
+	var entries map[string]common.MapStr
+	switch t.format {
+	case formatCSV:
+		entries, err = t.parseCSV(data)
+	case formatJSON:
+		entries, err = t.parseJSON(data)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse lookup table %s", path)
+	}
+
+	t.entries.Store(entries)
+	return nil
+}
+
+// lookup returns the columns of the entry matching key.
+func (t *table) lookup(key string) (common.MapStr, bool) {
+	entry, found := t.current()[key]
+	return entry, found
+}
+
+func (t *table) len() int {
+	return len(t.current())
+}
+
+func (t *table) current() map[string]common.MapStr {
+	entries, _ := t.entries.Load().(map[string]common.MapStr)
+	return entries
+}
+
+// parseCSV parses a CSV file whose first row contains the column names.
+// The key column defaults to the first one.
+func (t *table) parseCSV(data []byte) (map[string]common.MapStr, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = t.separator
+
+	header, err := r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("missing header row")
+		}
+		return nil, err
+	}
+
+	keyIndex := 0
+	if t.key != "" {
+		keyIndex = indexOf(header, t.key)
+		if keyIndex < 0 {
+			return nil, errors.Errorf("key column %v not found", t.key)
+		}
+	}
+	for _, column := range t.columns {
+		if indexOf(header, column) < 0 {
+			return nil, errors.Errorf("column %v not found", column)
+		}
+	}
+
+	entries := map[string]common.MapStr{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(header))
+		for i, value := range record {
+			row[header[i]] = value
+		}
+		entries[record[keyIndex]] = t.selectColumns(row, header[keyIndex])
+	}
+}
+
+// parseJSON parses a JSON object mapping keys to objects, or, when a key
+// column is configured, a JSON array of objects.
+func (t *table) parseJSON(data []byte) (map[string]common.MapStr, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	entries := map[string]common.MapStr{}
+	if t.key == "" {
+		var objects map[string]interface{}
+		if err := dec.Decode(&objects); err != nil {
+			return nil, errors.Wrap(err, "expected an object of objects")
+		}
+		jsontransform.TransformNumbers(objects)
+
+		for key, v := range objects {
+			row, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("value of key %v is not an object", key)
+			}
+			entries[key] = t.selectColumns(row, "")
+		}
+		return entries, nil
+	}
+
+	var rows []map[string]interface{}
+	if err := dec.Decode(&rows); err != nil {
+		return nil, errors.Wrap(err, "expected an array of objects")
+	}
+	for i, row := range rows {
+		jsontransform.TransformNumbers(row)
+
+		key, found := row[t.key]
+		if !found {
+			return nil, errors.Errorf("object %d has no key column %v", i, t.key)
+		}
+		entries[fmt.Sprint(key)] = t.selectColumns(row, t.key)
+	}
+	return entries, nil
+}
+
+// selectColumns returns the configured columns of a row, or all the columns
+// but the key column when none are configured.
+func (t *table) selectColumns(row map[string]interface{}, keyColumn string) common.MapStr {
+	entry := common.MapStr{}
+	if len(t.columns) == 0 {
+		for column, value := range row {
+			if column != keyColumn {
+				entry[column] = value
+			}
+		}
+		return entry
+	}
+
+	for _, column := range t.columns {
+		if value, found := row[column]; found {
+			entry[column] = value
+		}
+	}
+	return entry
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// rateLimitConfig defines the configuration options for the rate_limit
+// processor.
+type rateLimitConfig struct {
+	Fields          []string      `config:"fields"`                            // Fields whose values select the token bucket.
+	Limit           rate          `config:"limit"`                             // Number of events allowed per period.
+	Burst           int           `config:"burst" validate:"min=0"`            // Bucket size. Defaults to the limit.
+	SummaryInterval time.Duration `config:"summary_interval" validate:"min=0"` // Minimum time between summary events of a bucket.
+}
+
+// sampleConfig defines the configuration options for the sample processor.
+type sampleConfig struct {
+	Field string `config:"field" validate:"required"` // Field whose value is hashed.
+	OneIn int    `config:"one_in" validate:"min=1"`   // Keep one in N events.
+}
+
+func defaultSampleConfig() sampleConfig {
+	return sampleConfig{
+		OneIn: 1,
+	}
+}
+
+// rate is a number of events per period, written as <events>/<unit>, for
+// example 100/s or 10000/m.
+type rate struct {
+	events float64
+	period time.Duration
+}
+
+var rateUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// Unpack parses a rate.
+func (r *rate) Unpack(s string) error {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return errors.Errorf("invalid rate '%v', expected <events>/<unit>", s)
+	}
+
+	events, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || events <= 0 {
+		return errors.Errorf("invalid number of events in rate '%v'", s)
+	}
+
+	period, found := rateUnits[strings.TrimSpace(parts[1])]
+	if !found {
+		return errors.Errorf("invalid unit in rate '%v', valid units are s, m and h", s)
+	}
+
+	*r = rate{events: events, period: period}
+	return nil
+}
+
+// perSecond returns the number of events allowed per second.
+func (r rate) perSecond() float64 {
+	return r.events / r.period.Seconds()
+}
+
+func (r rate) String() string {
+	for unit, period := range rateUnits {
+		if period == r.period {
+			return strconv.FormatFloat(r.events, 'f', -1, 64) + "/" + unit
+		}
+	}
+	return strconv.FormatFloat(r.perSecond(), 'f', -1, 64) + "/s"
+}
@@ -0,0 +1,223 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+const rateLimitName = "rate_limit"
+
+// gcInterval is the interval at which idle buckets are removed and pending
+// summaries are reported.
+const gcInterval = time.Minute
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin(rateLimitName,
+		checks.ConfigChecked(NewRateLimit,
+			checks.RequireFields("limit"),
+			checks.AllowedFields("fields", "limit", "burst", "summary_interval", "when")))
+}
+
+type rateLimit struct {
+	rateLimitConfig
+	log     *logp.Logger
+	dropped *monitoring.Int // Number of dropped events.
+	active  *monitoring.Int // Number of token buckets.
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	lastGC  time.Time
+	clock   func() time.Time
+}
+
+// bucket is a token bucket. It is refilled continuously at the configured
+// rate, and every event takes one token.
+type bucket struct {
+	tokens      float64
+	lastRefill  time.Time
+	dropped     int // Events dropped since the last summary, when summaries are enabled.
+	lastDrop    time.Time
+	lastSummary time.Time
+}
+
+// NewRateLimit constructs a new rate_limit processor. It drops the events of
+// a token bucket that exceed the configured rate.
+func NewRateLimit(cfg *common.Config) (processors.Processor, error) {
+	c := rateLimitConfig{}
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the rate_limit configuration")
+	}
+	if c.Limit.events == 0 {
+		return nil, errors.New("limit is required")
+	}
+	if c.Burst == 0 {
+		c.Burst = int(math.Ceil(c.Limit.events))
+	}
+
+	var (
+		id  = int(instanceID.Inc())
+		log = logp.NewLogger("processor."+rateLimitName).With("instance_id", id)
+		reg = monitoring.Default.NewRegistry("processor."+rateLimitName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	return &rateLimit{
+		rateLimitConfig: c,
+		log:             log,
+		dropped:         monitoring.NewInt(reg, "dropped"),
+		active:          monitoring.NewInt(reg, "buckets"),
+		buckets:         map[string]*bucket{},
+		lastGC:          time.Now(),
+		clock:           time.Now,
+	}, nil
+}
+
+func (p *rateLimit) String() string {
+	return fmt.Sprintf("%v=[fields=%v, limit=%v, burst=%v, summary_interval=%v]",
+		rateLimitName, p.Fields, p.Limit, p.Burst, p.SummaryInterval)
+}
+
+// Run returns the event if its bucket has a token left. Otherwise it drops
+// the event, or replaces it with a summary event if one is due.
+func (p *rateLimit) Run(event *beat.Event) (*beat.Event, error) {
+	key := p.key(event)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock()
+	if now.Sub(p.lastGC) >= gcInterval {
+		p.gc(now)
+	}
+
+	b, found := p.buckets[key]
+	if !found {
+		b = &bucket{tokens: float64(p.Burst), lastRefill: now, lastSummary: now}
+		p.buckets[key] = b
+		p.active.Set(int64(len(p.buckets)))
+	}
+	p.refill(b, now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return event, nil
+	}
+
+	p.dropped.Inc()
+	if p.SummaryInterval <= 0 {
+		return nil, nil
+	}
+
+	b.dropped++
+	b.lastDrop = now
+	if now.Sub(b.lastSummary) >= p.SummaryInterval {
+		summary := p.summary(event, b.dropped, now.Sub(b.lastSummary))
+		b.dropped = 0
+		b.lastSummary = now
+		return summary, nil
+	}
+	return nil, nil
+}
+
+// key returns the values of the configured fields as bucket key.
+func (p *rateLimit) key(event *beat.Event) string {
+	if len(p.Fields) == 0 {
+		return ""
+	}
+
+	values := make([]string, len(p.Fields))
+	for i, field := range p.Fields {
+		if v, err := event.GetValue(field); err == nil {
+			values[i] = fmt.Sprint(v)
+		}
+	}
+	return strings.Join(values, "\x00")
+}
+
+func (p *rateLimit) refill(b *bucket, now time.Time) {
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(float64(p.Burst), b.tokens+elapsed*p.Limit.perSecond())
+	b.lastRefill = now
+}
+
+// gc reports the pending summaries of the buckets that have not dropped
+// events for a summary interval, as no dropped event may replace them, and
+// removes the buckets that are full again.
+func (p *rateLimit) gc(now time.Time) {
+	for key, b := range p.buckets {
+		p.refill(b, now)
+		if b.dropped > 0 && now.Sub(b.lastDrop) >= p.SummaryInterval {
+			p.log.Infof("Dropped %d events exceeding the rate limit of %v in the last %v for %v=%v",
+				b.dropped, p.Limit, now.Sub(b.lastSummary), p.Fields, strings.Split(key, "\x00"))
+			b.dropped = 0
+			b.lastSummary = now
+		}
+		if b.tokens >= float64(p.Burst) && b.dropped == 0 {
+			delete(p.buckets, key)
+		}
+	}
+	p.lastGC = now
+	p.active.Set(int64(len(p.buckets)))
+}
+
+// summary creates the event reporting the number of events dropped from a
+// bucket. It contains the fields selecting the bucket, and the metadata of the
+// dropped event it replaces.
+func (p *rateLimit) summary(dropped *beat.Event, count int, period time.Duration) *beat.Event {
+	event := &beat.Event{
+		Timestamp: dropped.Timestamp,
+		Fields: common.MapStr{
+			"message": fmt.Sprintf("Dropped %d events exceeding the rate limit of %v in the last %v", count, p.Limit, period),
+			"rate_limit": common.MapStr{
+				"dropped": count,
+			},
+		},
+	}
+	if dropped.Meta != nil {
+		event.Meta = dropped.Meta.Clone()
+	}
+	for _, field := range p.Fields {
+		if v, err := dropped.GetValue(field); err == nil {
+			event.PutValue(field, v)
+		}
+	}
+
+	p.log.Debugf("Dropped %d events of bucket with fields %v", count, p.Fields)
+	return event
+}
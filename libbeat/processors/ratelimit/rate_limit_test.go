@@ -0,0 +1,244 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestRateUnpack(t *testing.T) {
+	for s, expected := range map[string]rate{
+		"100/s":   {events: 100, period: time.Second},
+		"10000/m": {events: 10000, period: time.Minute},
+		"0.5/h":   {events: 0.5, period: time.Hour},
+	} {
+		var r rate
+		if assert.NoError(t, r.Unpack(s), s) {
+			assert.Equal(t, expected, r)
+			assert.Equal(t, s, r.String())
+		}
+	}
+
+	for _, s := range []string{"100", "100/d", "x/s", "0/s", "-1/s", "1/s/s"} {
+		var r rate
+		assert.Error(t, r.Unpack(s), s)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	proc, err := NewRateLimit(common.MustNewConfigFrom(common.MapStr{
+		"fields": []string{"kubernetes.pod.name"},
+		"limit":  "2/s",
+	}))
+	require.NoError(t, err)
+	p := proc.(*rateLimit)
+	clock := &fakeClock{now: p.lastGC}
+	p.clock = clock.Now
+
+	noisy := podEvent("noisy")
+	quiet := podEvent("quiet")
+
+	// The bucket starts full.
+	assert.True(t, passes(t, p, noisy))
+	assert.True(t, passes(t, p, noisy))
+	assert.False(t, passes(t, p, noisy))
+	assert.False(t, passes(t, p, noisy))
+
+	// Other buckets are not affected.
+	assert.True(t, passes(t, p, quiet))
+
+	// Tokens are refilled at the configured rate.
+	clock.Add(500 * time.Millisecond)
+	assert.True(t, passes(t, p, noisy))
+	assert.False(t, passes(t, p, noisy))
+
+	// The bucket never holds more than burst tokens.
+	clock.Add(time.Hour)
+	assert.True(t, passes(t, p, noisy))
+	assert.True(t, passes(t, p, noisy))
+	assert.False(t, passes(t, p, noisy))
+
+	assert.EqualValues(t, 4, p.dropped.Get())
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	proc, err := NewRateLimit(common.MustNewConfigFrom(common.MapStr{
+		"limit": "1/m",
+		"burst": 3,
+	}))
+	require.NoError(t, err)
+	p := proc.(*rateLimit)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, passes(t, p, podEvent("a")))
+	}
+	assert.False(t, passes(t, p, podEvent("b")))
+}
+
+func TestRateLimitSummary(t *testing.T) {
+	proc, err := NewRateLimit(common.MustNewConfigFrom(common.MapStr{
+		"fields":           []string{"kubernetes.pod.name"},
+		"limit":            "1/s",
+		"summary_interval": "10s",
+	}))
+	require.NoError(t, err)
+	p := proc.(*rateLimit)
+	clock := &fakeClock{now: p.lastGC}
+	p.clock = clock.Now
+
+	assert.True(t, passes(t, p, podEvent("noisy")))
+	for i := 0; i < 5; i++ {
+		assert.False(t, passes(t, p, podEvent("noisy")))
+	}
+
+	// After the summary interval the next dropped event is replaced by a
+	// summary.
+	clock.Add(10 * time.Second)
+	assert.True(t, passes(t, p, podEvent("noisy")))
+
+	event, err := p.Run(podEvent("noisy"))
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, common.MapStr{
+		"message":    "Dropped 6 events exceeding the rate limit of 1/s in the last 10s",
+		"rate_limit": common.MapStr{"dropped": 6},
+		"kubernetes": common.MapStr{"pod": common.MapStr{"name": "noisy"}},
+	}, event.Fields)
+
+	assert.False(t, passes(t, p, podEvent("noisy")))
+}
+
+func TestRateLimitSummaryMeta(t *testing.T) {
+	proc, err := NewRateLimit(common.MustNewConfigFrom(common.MapStr{
+		"limit":            "1/s",
+		"summary_interval": "10s",
+	}))
+	require.NoError(t, err)
+	p := proc.(*rateLimit)
+	clock := &fakeClock{now: p.lastGC}
+	p.clock = clock.Now
+
+	assert.True(t, passes(t, p, podEvent("noisy")))
+	clock.Add(10 * time.Second)
+	assert.True(t, passes(t, p, podEvent("noisy")))
+
+	dropped := podEvent("noisy")
+	dropped.Meta = common.MapStr{"index": "logs-noisy"}
+	event, err := p.Run(dropped)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, common.MapStr{"index": "logs-noisy"}, event.Meta)
+
+	// The metadata of the summary is a copy.
+	event.Meta.Put("index", "modified")
+	assert.Equal(t, "logs-noisy", dropped.Meta["index"])
+}
+
+func TestRateLimitPendingSummary(t *testing.T) {
+	proc, err := NewRateLimit(common.MustNewConfigFrom(common.MapStr{
+		"fields":           []string{"kubernetes.pod.name"},
+		"limit":            "1/s",
+		"summary_interval": "10s",
+	}))
+	require.NoError(t, err)
+	p := proc.(*rateLimit)
+	clock := &fakeClock{now: p.lastGC}
+	p.clock = clock.Now
+
+	assert.True(t, passes(t, p, podEvent("noisy")))
+	for i := 0; i < 3; i++ {
+		assert.False(t, passes(t, p, podEvent("noisy")))
+	}
+	assert.Equal(t, 3, p.buckets["noisy"].dropped)
+
+	// No more events of the bucket are dropped, so the pending summary is
+	// reported when the buckets are collected, and the bucket is removed.
+	clock.Add(gcInterval)
+	assert.True(t, passes(t, p, podEvent("quiet")))
+	assert.NotContains(t, p.buckets, "noisy")
+	assert.EqualValues(t, 3, p.dropped.Get())
+}
+
+func TestRateLimitGC(t *testing.T) {
+	proc, err := NewRateLimit(common.MustNewConfigFrom(common.MapStr{
+		"fields": []string{"kubernetes.pod.name"},
+		"limit":  "1/s",
+	}))
+	require.NoError(t, err)
+	p := proc.(*rateLimit)
+	clock := &fakeClock{now: p.lastGC}
+	p.clock = clock.Now
+
+	passes(t, p, podEvent("a"))
+	passes(t, p, podEvent("b"))
+	assert.Len(t, p.buckets, 2)
+
+	clock.Add(gcInterval)
+	passes(t, p, podEvent("c"))
+	assert.Len(t, p.buckets, 1)
+	assert.EqualValues(t, 1, p.active.Get())
+
+	// Buckets that dropped events are removed too when no summaries are
+	// published.
+	passes(t, p, podEvent("c"))
+	clock.Add(gcInterval)
+	passes(t, p, podEvent("d"))
+	assert.Len(t, p.buckets, 1)
+}
+
+func TestRateLimitInvalidConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"missing limit":  {"fields": []string{"a"}},
+		"invalid limit":  {"limit": "fast"},
+		"negative burst": {"limit": "1/s", "burst": -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRateLimit(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time      { return c.now }
+func (c *fakeClock) Add(d time.Duration) { c.now = c.now.Add(d) }
+
+func podEvent(name string) *beat.Event {
+	return &beat.Event{
+		Fields: common.MapStr{
+			"message":    "log line",
+			"kubernetes": common.MapStr{"pod": common.MapStr{"name": name}},
+		},
+	}
+}
+
+func passes(t *testing.T, p *rateLimit, event *beat.Event) bool {
+	out, err := p.Run(event)
+	require.NoError(t, err)
+	return out == event
+}
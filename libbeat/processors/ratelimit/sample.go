@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+const sampleName = "sample"
+
+func init() {
+	processors.RegisterPlugin(sampleName,
+		checks.ConfigChecked(NewSample,
+			checks.RequireFields("field", "one_in"),
+			checks.AllowedFields("field", "one_in", "when")))
+}
+
+type sample struct {
+	sampleConfig
+	dropped *monitoring.Int // Number of dropped events.
+}
+
+// NewSample constructs a new sample processor. It keeps one in N events,
+// selected by hashing the value of a field, so that all events with the same
+// value are either kept or dropped.
+func NewSample(cfg *common.Config) (processors.Processor, error) {
+	c := defaultSampleConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the sample configuration")
+	}
+
+	id := int(instanceID.Inc())
+	reg := monitoring.Default.NewRegistry("processor."+sampleName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+
+	return &sample{
+		sampleConfig: c,
+		dropped:      monitoring.NewInt(reg, "dropped"),
+	}, nil
+}
+
+func (p *sample) String() string {
+	return fmt.Sprintf("%v=[field=%v, one_in=%v]", sampleName, p.Field, p.OneIn)
+}
+
+// Run keeps events without the field.
+func (p *sample) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		return event, nil
+	}
+
+	h := fnv.New32a()
+	fmt.Fprint(h, v)
+	if h.Sum32()%uint32(p.OneIn) == 0 {
+		return event, nil
+	}
+
+	p.dropped.Inc()
+	return nil, nil
+}
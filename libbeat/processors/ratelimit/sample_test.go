@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestSample(t *testing.T) {
+	p, err := NewSample(common.MustNewConfigFrom(common.MapStr{
+		"field":  "trace.id",
+		"one_in": 10,
+	}))
+	require.NoError(t, err)
+
+	kept := map[string]bool{}
+	for i := 0; i < 10000; i++ {
+		id := strconv.Itoa(i)
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"trace": common.MapStr{"id": id}}})
+		require.NoError(t, err)
+		kept[id] = event != nil
+	}
+
+	var n int
+	for _, k := range kept {
+		if k {
+			n++
+		}
+	}
+	assert.InDelta(t, 1000, n, 100)
+	assert.EqualValues(t, 10000-n, p.(*sample).dropped.Get())
+
+	// The decision only depends on the value of the field.
+	for id, k := range kept {
+		event, err := p.Run(&beat.Event{Fields: common.MapStr{"trace": common.MapStr{"id": id}, "other": 1}})
+		require.NoError(t, err)
+		require.Equal(t, k, event != nil)
+	}
+
+	// Events without the field are kept.
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{}})
+	require.NoError(t, err)
+	assert.NotNil(t, event)
+}
+
+func TestSampleInvalidConfig(t *testing.T) {
+	for name, config := range map[string]common.MapStr{
+		"missing field": {"one_in": 10},
+		"zero one_in":   {"field": "a", "one_in": 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewSample(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
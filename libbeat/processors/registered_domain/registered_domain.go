@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registered_domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+const (
+	processorName = "registered_domain"
+	logName       = "processor." + processorName
+)
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("field", "target_field"),
+			checks.AllowedFields("field", "target_field", "target_etld_field", "target_subdomain_field",
+				"ignore_missing", "tag_on_failure", "when")))
+}
+
+type config struct {
+	Field                string   `config:"field" validate:"required"`        // Field containing the domain name.
+	TargetField          string   `config:"target_field" validate:"required"` // Target field for the registered domain.
+	TargetETLDField      string   `config:"target_etld_field"`                // Target field for the effective top level domain.
+	TargetSubdomainField string   `config:"target_subdomain_field"`           // Target field for the subdomain.
+	IgnoreMissing        bool     `config:"ignore_missing"`                   // Skip events without the source field.
+	TagOnFailure         []string `config:"tag_on_failure"`                   // Tags to append when a failure occurs.
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+}
+
+// New constructs a new registered_domain processor. It uses the public suffix
+// list embedded in the Beat to split a domain name in its registered domain,
+// effective top level domain and subdomain.
+func New(cfg *common.Config) (processors.Processor, error) {
+	var c config
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the registered_domain configuration")
+	}
+
+	return &processor{
+		config: c,
+		log:    logp.NewLogger(logName),
+	}, nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[field=%v, target_field=%v, target_etld_field=%v, target_subdomain_field=%v]",
+		processorName, p.Field, p.TargetField, p.TargetETLDField, p.TargetSubdomainField)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if err := p.parse(event); err != nil {
+		p.log.Debugf("registered_domain failed: %v", err)
+		common.AddTags(event.Fields, p.TagOnFailure)
+	}
+	return event, nil
+}
+
+func (p *processor) parse(event *beat.Event) error {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for field %s", p.Field)
+	}
+
+	domain, ok := v.(string)
+	if !ok {
+		return errors.Errorf("field %s is not a string but %T", p.Field, v)
+	}
+	// Fully qualified names from DNS end with a dot.
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	registered, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get registered domain of %s", domain)
+	}
+
+	if _, err := event.PutValue(p.TargetField, registered); err != nil {
+		return err
+	}
+
+	if p.TargetETLDField != "" {
+		etld, _ := publicsuffix.PublicSuffix(domain)
+		if _, err := event.PutValue(p.TargetETLDField, etld); err != nil {
+			return err
+		}
+	}
+
+	if p.TargetSubdomainField != "" && len(domain) > len(registered) {
+		subdomain := strings.TrimSuffix(domain[:len(domain)-len(registered)], ".")
+		if _, err := event.PutValue(p.TargetSubdomainField, subdomain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
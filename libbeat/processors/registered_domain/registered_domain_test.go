@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package registered_domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestRun(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":                  "dns.question.name",
+		"target_field":           "dns.question.registered_domain",
+		"target_etld_field":      "dns.question.top_level_domain",
+		"target_subdomain_field": "dns.question.subdomain",
+		"tag_on_failure":         []string{"_registered_domain_failure"},
+	}))
+	require.NoError(t, err)
+
+	cases := []struct {
+		domain     string
+		registered string
+		etld       string
+		subdomain  string
+	}{
+		{"www.example.com", "example.com", "com", "www"},
+		{"www.example.com.", "example.com", "com", "www"},
+		{"a.b.example.co.uk", "example.co.uk", "co.uk", "a.b"},
+		{"WWW.Example.ORG", "example.org", "org", "www"},
+		{"example.com", "example.com", "com", ""},
+		{"foo.bar.appspot.com", "bar.appspot.com", "appspot.com", "foo"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.domain, func(t *testing.T) {
+			event, err := p.Run(&beat.Event{
+				Fields: common.MapStr{"dns": common.MapStr{"question": common.MapStr{"name": c.domain}}},
+			})
+			require.NoError(t, err)
+
+			question := event.Fields["dns"].(common.MapStr)["question"].(common.MapStr)
+			assert.Equal(t, c.registered, question["registered_domain"])
+			assert.Equal(t, c.etld, question["top_level_domain"])
+			if c.subdomain == "" {
+				assert.NotContains(t, question, "subdomain")
+			} else {
+				assert.Equal(t, c.subdomain, question["subdomain"])
+			}
+			assert.NotContains(t, event.Fields, "tags")
+		})
+	}
+}
+
+func TestPublicSuffixOnly(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"field":             "domain",
+		"target_field":      "registered_domain",
+		"target_etld_field": "top_level_domain",
+		"tag_on_failure":    []string{"_registered_domain_failure"},
+	}))
+	require.NoError(t, err)
+
+	// Public suffixes, including private ones like appspot.com, have no
+	// registered domain. No target field must be written for them.
+	for _, domain := range []string{"com", "co.uk", "appspot.com."} {
+		t.Run(domain, func(t *testing.T) {
+			event, err := p.Run(&beat.Event{Fields: common.MapStr{"domain": domain}})
+			require.NoError(t, err)
+			assert.Equal(t, common.MapStr{
+				"domain": domain,
+				"tags":   []string{"_registered_domain_failure"},
+			}, event.Fields)
+		})
+	}
+}
+
+func TestMissingDomain(t *testing.T) {
+	config := common.MapStr{
+		"field":          "dns.question.name",
+		"target_field":   "dns.question.registered_domain",
+		"tag_on_failure": []string{"_registered_domain_failure"},
+	}
+
+	p, err := New(common.MustNewConfigFrom(config))
+	require.NoError(t, err)
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"dns": common.MapStr{"type": "answer"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"_registered_domain_failure"}, event.Fields["tags"])
+
+	// DNS responses without question are skipped without tagging them.
+	config["ignore_missing"] = true
+	p, err = New(common.MustNewConfigFrom(config))
+	require.NoError(t, err)
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"dns": common.MapStr{"type": "answer"}}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{"dns": common.MapStr{"type": "answer"}}, event.Fields)
+}
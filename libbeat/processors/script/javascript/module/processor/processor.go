@@ -34,10 +34,18 @@ import (
 	"github.com/elastic/beats/libbeat/processors/communityid"
 	"github.com/elastic/beats/libbeat/processors/convert"
 	"github.com/elastic/beats/libbeat/processors/decode_csv_fields"
+	"github.com/elastic/beats/libbeat/processors/decode_kv"
 	"github.com/elastic/beats/libbeat/processors/dissect"
 	"github.com/elastic/beats/libbeat/processors/dns"
 	"github.com/elastic/beats/libbeat/processors/extract_array"
+	"github.com/elastic/beats/libbeat/processors/fingerprint"
+	"github.com/elastic/beats/libbeat/processors/geoip"
+	"github.com/elastic/beats/libbeat/processors/grok"
+	"github.com/elastic/beats/libbeat/processors/lookup"
+	"github.com/elastic/beats/libbeat/processors/ratelimit"
+	"github.com/elastic/beats/libbeat/processors/registered_domain"
 	"github.com/elastic/beats/libbeat/processors/script/javascript"
+	"github.com/elastic/beats/libbeat/processors/url"
 )
 
 // Create constructors for most of the Beat processors.
@@ -57,11 +65,19 @@ var constructors = map[string]processors.Constructor{
 	"DecodeBase64Field":     actions.NewDecodeBase64Field,
 	"DecodeCSVField":        decode_csv_fields.NewDecodeCSVField,
 	"DecodeJSONFields":      actions.NewDecodeJSONFields,
+	"DecodeKV":              decode_kv.NewDecodeKV,
 	"Dissect":               dissect.NewProcessor,
 	"DNS":                   dns.New,
 	"ExtractArray":          extract_array.New,
+	"Fingerprint":           fingerprint.New,
+	"GeoIP":                 geoip.New,
+	"Grok":                  grok.New,
+	"Lookup":                lookup.New,
+	"RegisteredDomain":      registered_domain.New,
 	"Rename":                actions.NewRenameFields,
+	"Sample":                ratelimit.NewSample,
 	"TruncateFields":        actions.NewTruncateFields,
+	"URL":                   url.New,
 }
 
 // beatProcessor wraps a processor for javascript.
@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package url
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/processors/checks"
+)
+
+const (
+	processorName = "url"
+	logName       = "processor." + processorName
+)
+
+func init() {
+	processors.RegisterPlugin(processorName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("field"),
+			checks.AllowedFields("field", "target_field", "decode", "ignore_missing", "tag_on_failure", "when")))
+}
+
+type config struct {
+	Field         string   `config:"field" validate:"required"` // Field containing the URL.
+	TargetField   string   `config:"target_field"`              // Object the ECS url fields are written to.
+	Decode        bool     `config:"decode"`                    // Percent-decode the path, query and fragment.
+	IgnoreMissing bool     `config:"ignore_missing"`            // Skip events without the source field.
+	TagOnFailure  []string `config:"tag_on_failure"`            // Tags to append when a failure occurs.
+}
+
+func defaultConfig() config {
+	return config{
+		TargetField: "url",
+	}
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+}
+
+// New constructs a new url processor. It parses a URL and writes its parts
+// to the ECS url fields.
+func New(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the url configuration")
+	}
+
+	return &processor{
+		config: c,
+		log:    logp.NewLogger(logName),
+	}, nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[field=%v, target_field=%v, decode=%v]",
+		processorName, p.Field, p.TargetField, p.Decode)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if err := p.parse(event); err != nil {
+		p.log.Debugf("url failed: %v", err)
+		common.AddTags(event.Fields, p.TagOnFailure)
+	}
+	return event, nil
+}
+
+func (p *processor) parse(event *beat.Event) error {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for field %s", p.Field)
+	}
+
+	original, ok := v.(string)
+	if !ok {
+		return errors.Errorf("field %s is not a string but %T", p.Field, v)
+	}
+
+	fields, err := p.urlFields(original)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		if _, err := event.PutValue(p.TargetField+"."+key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// urlFields returns the ECS url fields of a URL, without the url prefix.
+func (p *processor) urlFields(original string) (common.MapStr, error) {
+	u, err := url.Parse(original)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse URL %s", original)
+	}
+
+	fields := common.MapStr{}
+	putString(fields, "scheme", u.Scheme)
+	putString(fields, "domain", u.Hostname())
+	if port := u.Port(); port != "" {
+		n, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid port in URL %s", original)
+		}
+		fields["port"] = n
+	}
+	if u.User != nil {
+		putString(fields, "username", u.User.Username())
+		if password, ok := u.User.Password(); ok {
+			putString(fields, "password", password)
+		}
+	}
+
+	if p.Decode {
+		putString(fields, "path", u.Path)
+		query, err := url.QueryUnescape(u.RawQuery)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode query of URL %s", original)
+		}
+		putString(fields, "query", query)
+		putString(fields, "fragment", u.Fragment)
+	} else {
+		putString(fields, "path", u.EscapedPath())
+		putString(fields, "query", u.RawQuery)
+		// url.URL only keeps the decoded fragment.
+		if idx := strings.IndexByte(original, '#'); idx >= 0 {
+			putString(fields, "fragment", original[idx+1:])
+		}
+	}
+
+	return fields, nil
+}
+
+func putString(m common.MapStr, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
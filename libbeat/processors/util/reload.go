@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// FileReloader loads a file used by a processor, and loads it again when it
+// has been modified on disk. The file is checked at most once every period,
+// either by calling ReloadIfChanged or in the background after Start.
+type FileReloader struct {
+	Path string
+
+	kind   string
+	period time.Duration
+	load   func(path string) error
+	log    *logp.Logger
+
+	mu        sync.Mutex
+	lastCheck time.Time
+	modTime   time.Time
+	size      int64
+	done      chan struct{}
+}
+
+// NewFileReloader loads the file at path, describing it as kind in errors and
+// log messages. load must only replace the data in use if it succeeds. A zero
+// or negative period disables reloading.
+func NewFileReloader(log *logp.Logger, kind, path string, period time.Duration, load func(path string) error) (*FileReloader, error) {
+	r := &FileReloader{
+		Path:   path,
+		kind:   kind,
+		period: period,
+		load:   load,
+		log:    log,
+	}
+
+	info, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := load(path); err != nil {
+		return nil, err
+	}
+	r.lastCheck, r.modTime, r.size = time.Now(), info.ModTime(), info.Size()
+	return r, nil
+}
+
+// ReloadIfChanged loads the file again if the period has passed since the
+// last check and its modification time or size has changed. On failure the
+// previous version is kept. It reports whether a new version was loaded.
+func (r *FileReloader) ReloadIfChanged(now time.Time) bool {
+	if r.period <= 0 {
+		return false
+	}
+
+	r.mu.Lock()
+	if now.Sub(r.lastCheck) < r.period {
+		r.mu.Unlock()
+		return false
+	}
+	r.lastCheck = now
+	modTime, size := r.modTime, r.size
+	r.mu.Unlock()
+
+	info, err := r.stat()
+	if err == nil {
+		if info.ModTime().Equal(modTime) && info.Size() == size {
+			return false
+		}
+		err = r.load(r.Path)
+	}
+	if err != nil {
+		r.log.Warnf("Failed to reload %s, using the previous version: %v", r.kind, err)
+		return false
+	}
+
+	r.mu.Lock()
+	r.modTime, r.size = info.ModTime(), info.Size()
+	r.mu.Unlock()
+
+	r.log.Infof("Reloaded %s %s", r.kind, r.Path)
+	return true
+}
+
+// Start watches the file in a background goroutine, loading it again every
+// time it changes, until Stop is called. It does nothing if reloading is
+// disabled.
+func (r *FileReloader) Start() {
+	if r.period <= 0 {
+		return
+	}
+
+	r.done = make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-time.After(r.period):
+				r.ReloadIfChanged(time.Now())
+			}
+		}
+	}()
+}
+
+// Stop stops watching the file.
+func (r *FileReloader) Stop() {
+	if r.done != nil {
+		close(r.done)
+	}
+}
+
+func (r *FileReloader) stat() (os.FileInfo, error) {
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stat %s %s", r.kind, r.Path)
+	}
+	return info, nil
+}
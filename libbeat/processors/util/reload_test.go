@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+func TestFileReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "data")
+	require.NoError(t, ioutil.WriteFile(path, []byte("v1"), 0600))
+
+	var loaded string
+	r, err := NewFileReloader(logp.NewLogger("test"), "test file", path, time.Second, func(path string) error {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if string(data) == "invalid" {
+			return errors.New("invalid data")
+		}
+		loaded = string(data)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v1", loaded)
+
+	now := time.Now()
+	require.NoError(t, ioutil.WriteFile(path, []byte("v2 changed"), 0600))
+	assert.False(t, r.ReloadIfChanged(now), "reloaded before the period")
+
+	now = now.Add(2 * time.Second)
+	assert.True(t, r.ReloadIfChanged(now))
+	assert.Equal(t, "v2 changed", loaded)
+
+	now = now.Add(2 * time.Second)
+	assert.False(t, r.ReloadIfChanged(now), "reloaded an unchanged file")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("invalid"), 0600))
+	now = now.Add(2 * time.Second)
+	assert.False(t, r.ReloadIfChanged(now))
+	assert.Equal(t, "v2 changed", loaded, "previous version must be kept")
+
+	require.NoError(t, os.Remove(path))
+	now = now.Add(2 * time.Second)
+	assert.False(t, r.ReloadIfChanged(now))
+	assert.Equal(t, "v2 changed", loaded)
+}
+
+func TestFileReloaderErrors(t *testing.T) {
+	_, err := NewFileReloader(logp.NewLogger("test"), "test file", "testdata/missing", time.Second, func(string) error {
+		return nil
+	})
+	assert.Error(t, err)
+
+	_, err = NewFileReloader(logp.NewLogger("test"), "test file", "reload.go", time.Second, func(string) error {
+		return errors.New("invalid data")
+	})
+	assert.Error(t, err)
+}
+
+func TestFileReloaderStart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "data")
+	require.NoError(t, ioutil.WriteFile(path, []byte("v1"), 0600))
+
+	loaded := make(chan string, 2)
+	r, err := NewFileReloader(logp.NewLogger("test"), "test file", path, 10*time.Millisecond, func(path string) error {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		loaded <- string(data)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v1", <-loaded)
+
+	r.Start()
+	defer r.Stop()
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("v2 changed"), 0600))
+	select {
+	case data := <-loaded:
+		assert.Equal(t, "v2 changed", data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the file was not reloaded in the background")
+	}
+}
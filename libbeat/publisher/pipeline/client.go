@@ -38,6 +38,7 @@ type client struct {
 	producer   queue.Producer
 	mutex      sync.Mutex
 	acker      acker
+	metrics    *clientMetrics
 
 	eventFlags   publisher.EventFlags
 	canDrop      bool
@@ -177,6 +178,7 @@ func (c *client) onClosing() {
 }
 
 func (c *client) onClosed() {
+	c.pipeline.observer.identityClosed(c.metrics)
 	c.pipeline.observer.clientClosed()
 	if c.eventer != nil {
 		c.eventer.Closed()
@@ -185,10 +187,12 @@ func (c *client) onClosed() {
 
 func (c *client) onNewEvent() {
 	c.pipeline.observer.newEvent()
+	c.metrics.newEvent()
 }
 
 func (c *client) onPublished() {
 	c.pipeline.observer.publishedEvent()
+	c.metrics.publishedEvent()
 	if c.eventer != nil {
 		c.eventer.Published()
 	}
@@ -199,6 +203,7 @@ func (c *client) onFilteredOut(e beat.Event) {
 
 	log.Debug("Pipeline client receives callback 'onFilteredOut' for event: %+v", e)
 	c.pipeline.observer.filteredEvent()
+	c.metrics.filteredEvent()
 	if c.eventer != nil {
 		c.eventer.FilteredOut(e)
 	}
@@ -209,6 +214,7 @@ func (c *client) onDroppedOnPublish(e beat.Event) {
 
 	log.Debug("Pipeline client receives callback 'onDroppedOnPublish' for event: %+v", e)
 	c.pipeline.observer.failedPublishEvent()
+	c.metrics.failedPublishEvent()
 	if c.eventer != nil {
 		c.eventer.DroppedOnPublish(e)
 	}
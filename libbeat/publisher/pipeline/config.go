@@ -68,5 +68,9 @@ func validateClientConfig(c *beat.ClientConfig) error {
 		return errors.New("ACK handlers with DropIfFull mode not supported")
 	}
 
+	if c.Identity != nil && c.Identity.ID == "" {
+		return errors.New("client identity requires an ID")
+	}
+
 	return nil
 }
@@ -17,7 +17,13 @@
 
 package pipeline
 
-import "github.com/elastic/beats/libbeat/monitoring"
+import (
+	"strings"
+	"sync"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
 
 type observer interface {
 	pipelineObserver
@@ -32,6 +38,9 @@ type pipelineObserver interface {
 	clientConnected()
 	clientClosing()
 	clientClosed()
+
+	identityConnected(identity *beat.ClientIdentity, acks bool) *clientMetrics
+	identityClosed(*clientMetrics)
 }
 
 type clientObserver interface {
@@ -73,6 +82,22 @@ type metricsObserver struct {
 
 	// queue metrics
 	ackedQueue *monitoring.Uint
+
+	// per client metrics, by client identity
+	clientsMu       sync.Mutex
+	clientsRegistry *monitoring.Registry
+	clientsByID     map[string]*clientMetrics
+}
+
+// clientMetrics holds the metrics of the clients sharing an identity. All
+// methods are safe to call on a nil instance.
+type clientMetrics struct {
+	id   string
+	refs int
+	reg  *monitoring.Registry
+
+	events, published, filtered, dropped *monitoring.Uint
+	acked                                *monitoring.Uint // only set if a client waits for ACKs
 }
 
 func newMetricsObserver(metrics *monitoring.Registry) *metricsObserver {
@@ -95,6 +120,9 @@ func newMetricsObserver(metrics *monitoring.Registry) *metricsObserver {
 		ackedQueue: monitoring.NewUint(reg, "queue.acked"),
 
 		activeEvents: monitoring.NewUint(reg, "events.active"),
+
+		clientsRegistry: reg.NewRegistry("client"),
+		clientsByID:     map[string]*clientMetrics{},
 	}
 }
 
@@ -117,6 +145,93 @@ func (o *metricsObserver) clientClosing() {}
 // (client) client finished processing close
 func (o *metricsObserver) clientClosed() { o.clients.Dec() }
 
+// (pipeline) new client with an identity is connected. Clients sharing an
+// identity share their metrics. ACKed events are only reported if a client
+// waits for ACKs.
+func (o *metricsObserver) identityConnected(identity *beat.ClientIdentity, acks bool) *clientMetrics {
+	o.clientsMu.Lock()
+	defer o.clientsMu.Unlock()
+
+	// dots would create nested registries
+	id := strings.Replace(identity.ID, ".", "_", -1)
+	m := o.clientsByID[id]
+	if m != nil {
+		m.refs++
+	} else {
+		reg := o.clientsRegistry.NewRegistry(id)
+		for k, v := range identity.Fields {
+			monitoring.NewString(reg, strings.Replace(k, ".", "_", -1)).Set(v)
+		}
+		m = &clientMetrics{
+			id:        id,
+			refs:      1,
+			reg:       reg,
+			events:    monitoring.NewUint(reg, "events.total"),
+			published: monitoring.NewUint(reg, "events.published"),
+			filtered:  monitoring.NewUint(reg, "events.filtered"),
+			dropped:   monitoring.NewUint(reg, "events.dropped"),
+		}
+		o.clientsByID[id] = m
+	}
+
+	if acks && m.acked == nil {
+		m.acked = monitoring.NewUint(m.reg, "events.acked")
+	}
+	return m
+}
+
+// (client) client with an identity finished processing close. The metrics are
+// removed once all clients sharing the identity are closed.
+func (o *metricsObserver) identityClosed(m *clientMetrics) {
+	if m == nil {
+		return
+	}
+
+	o.clientsMu.Lock()
+	defer o.clientsMu.Unlock()
+
+	m.refs--
+	if m.refs == 0 {
+		delete(o.clientsByID, m.id)
+		o.clientsRegistry.Remove(m.id)
+	}
+}
+
+// (client) client is trying to publish a new event
+func (m *clientMetrics) newEvent() {
+	if m != nil {
+		m.events.Inc()
+	}
+}
+
+// (client) managed to push an event into the publisher pipeline
+func (m *clientMetrics) publishedEvent() {
+	if m != nil {
+		m.published.Inc()
+	}
+}
+
+// (client) event is filtered out (on purpose or failed)
+func (m *clientMetrics) filteredEvent() {
+	if m != nil {
+		m.filtered.Inc()
+	}
+}
+
+// (client) client closing down or DropIfFull is set
+func (m *clientMetrics) failedPublishEvent() {
+	if m != nil {
+		m.dropped.Inc()
+	}
+}
+
+// (queue) number of events of the client ACKed by the queue/broker in use
+func (m *clientMetrics) eventsACKed(n int) {
+	if m != nil && m.acked != nil {
+		m.acked.Add(uint64(n))
+	}
+}
+
 //
 // client publish events
 //
@@ -199,3 +314,6 @@ func (*emptyObserver) eventsDropped(int)   {}
 func (*emptyObserver) eventsRetry(int)     {}
 func (*emptyObserver) outBatchSend(int)    {}
 func (*emptyObserver) outBatchACKed(int)   {}
+
+func (*emptyObserver) identityConnected(*beat.ClientIdentity, bool) *clientMetrics { return nil }
+func (*emptyObserver) identityClosed(*clientMetrics)                               {}
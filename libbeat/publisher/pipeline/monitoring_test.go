@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func TestMetricsObserverIdentity(t *testing.T) {
+	registry := monitoring.NewRegistry()
+	observer := newMetricsObserver(registry)
+
+	identity := &beat.ClientIdentity{
+		ID:     "system.cpu",
+		Fields: map[string]string{"module": "system"},
+	}
+	m1 := observer.identityConnected(identity, false)
+	m2 := observer.identityConnected(identity, true)
+	assert.True(t, m1 == m2, "clients with the same identity must share their metrics")
+
+	m1.newEvent()
+	m1.publishedEvent()
+	m2.newEvent()
+	m2.filteredEvent()
+	m2.newEvent()
+	m2.failedPublishEvent()
+	m1.eventsACKed(1)
+
+	snapshot := monitoring.CollectFlatSnapshot(registry, monitoring.Full, false)
+	assert.Equal(t, map[string]int64{
+		"pipeline.clients":                            0,
+		"pipeline.events.total":                       0,
+		"pipeline.events.filtered":                    0,
+		"pipeline.events.published":                   0,
+		"pipeline.events.failed":                      0,
+		"pipeline.events.dropped":                     0,
+		"pipeline.events.retry":                       0,
+		"pipeline.events.active":                      0,
+		"pipeline.queue.acked":                        0,
+		"pipeline.client.system_cpu.events.total":     3,
+		"pipeline.client.system_cpu.events.published": 1,
+		"pipeline.client.system_cpu.events.filtered":  1,
+		"pipeline.client.system_cpu.events.dropped":   1,
+		"pipeline.client.system_cpu.events.acked":     1,
+	}, snapshot.Ints)
+	assert.Equal(t, map[string]string{"pipeline.client.system_cpu.module": "system"}, snapshot.Strings)
+
+	// metrics are kept until all clients sharing the identity are closed
+	observer.identityClosed(m1)
+	assert.NotNil(t, registry.Get("pipeline.client.system_cpu"))
+	observer.identityClosed(m2)
+	assert.Nil(t, registry.Get("pipeline.client.system_cpu"))
+
+	// a new client starts from zero
+	m3 := observer.identityConnected(identity, false)
+	assert.Equal(t, uint64(0), m3.events.Get())
+	assert.Nil(t, m3.acked, "acked events are only reported for clients waiting for ACKs")
+	m3.eventsACKed(1)
+	assert.Nil(t, registry.Get("pipeline.client.system_cpu.events.acked"))
+}
+
+func TestNilClientMetrics(t *testing.T) {
+	var m *clientMetrics
+	m.newEvent()
+	m.publishedEvent()
+	m.filteredEvent()
+	m.failedPublishEvent()
+	m.eventsACKed(1)
+	nilObserver.identityClosed(m)
+	assert.Nil(t, nilObserver.identityConnected(&beat.ClientIdentity{ID: "test"}, true))
+}
@@ -345,6 +345,20 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 		acker = nilACKer
 	}
 
+	// ACKs are only counted for clients waiting for them, so other clients
+	// keep using a producer without ACK tracking.
+	var metrics *clientMetrics
+	if cfg.Identity != nil {
+		metrics = p.observer.identityConnected(cfg.Identity, producerCfg.ACK != nil)
+	}
+	if metrics != nil && producerCfg.ACK != nil {
+		ackEvents := producerCfg.ACK
+		producerCfg.ACK = func(n int) {
+			ackEvents(n)
+			metrics.eventsACKed(n)
+		}
+	}
+
 	producer := p.queue.Producer(producerCfg)
 	client := &client{
 		pipeline:     p,
@@ -353,6 +367,7 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 		processors:   processors,
 		producer:     producer,
 		acker:        acker,
+		metrics:      metrics,
 		eventFlags:   eventFlags,
 		canDrop:      canDrop,
 		reportEvents: reportEvents,
@@ -0,0 +1,246 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/publisher/queue"
+)
+
+type consumer struct {
+	queue  *Queue
+	closed atomic.Bool
+}
+
+// batch of events read from a single segment. The batch keeps track of the
+// byte range of the frames it was read from.
+type batch struct {
+	queue      *Queue
+	events     []publisher.Event
+	segment    uint64
+	start, end uint64
+	acked      bool
+}
+
+const readBufferSize = 64 * 1024
+
+func newConsumer(q *Queue) *consumer {
+	return &consumer{queue: q}
+}
+
+func (c *consumer) Close() error {
+	if c.closed.Swap(true) {
+		return errors.New("already closed")
+	}
+
+	q := c.queue
+	q.mu.Lock()
+	q.cond.Broadcast()
+	q.mu.Unlock()
+	return nil
+}
+
+// Get reads up to sz events from the segment at the current read position.
+// Get blocks until events are available. If sz <= 0, all events available
+// in the current segment are returned.
+func (c *consumer) Get(sz int) (queue.Batch, error) {
+	q := c.queue
+
+	q.readMu.Lock()
+	defer q.readMu.Unlock()
+
+	for {
+		pos, limit, err := c.waitReadable()
+		if err != nil {
+			return nil, err
+		}
+
+		events, end := q.readFrames(pos, limit, sz)
+
+		q.mu.Lock()
+		q.readPos.offset = end
+		if len(events) == 0 {
+			q.mu.Unlock()
+			continue
+		}
+
+		b := &batch{
+			queue:   q,
+			events:  events,
+			segment: pos.segment,
+			start:   pos.offset,
+			end:     end,
+		}
+		q.batches = append(q.batches, b)
+		q.mu.Unlock()
+		return b, nil
+	}
+}
+
+// waitReadable blocks until committed frames are available for reading. The
+// read position and the committed size of the segment to read from are
+// returned.
+func (c *consumer) waitReadable() (position, uint64, error) {
+	q := c.queue
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		if q.closed || c.closed.Load() {
+			return position{}, 0, io.EOF
+		}
+
+		_, seg := q.findSegment(q.readPos.segment)
+		if seg != nil {
+			if q.readPos.offset < seg.size {
+				return q.readPos, seg.size, nil
+			}
+
+			if seg.sealed {
+				q.advanceReadSegment(seg)
+				continue
+			}
+		}
+
+		q.cond.Wait()
+	}
+}
+
+// advanceReadSegment moves the read position to the segment following seg.
+// Must be called with q.mu held.
+func (q *Queue) advanceReadSegment(seg *segment) {
+	next := q.nextID
+	if i, _ := q.findSegment(seg.id); i >= 0 && i+1 < len(q.segments) {
+		next = q.segments[i+1].id
+	}
+
+	q.readPos = position{segment: next, offset: segmentHeaderSize}
+	q.collectSegments()
+}
+
+// readFrames reads and decodes up to max events from the segment between
+// pos and limit. The end offset of the last frame read is returned.
+// Corrupted frames are skipped.
+func (q *Queue) readFrames(pos position, limit uint64, max int) ([]publisher.Event, uint64) {
+	log := q.logger
+
+	if err := q.openReader(pos, limit); err != nil {
+		log.Errorf("Failed to open segment %v, skipping segment: %v", pos.segment, err)
+		return nil, limit
+	}
+
+	var (
+		events []publisher.Event
+		offset = pos.offset
+		buf    []byte
+		err    error
+	)
+	for offset < limit && (max <= 0 || len(events) < max) {
+		buf, err = readFrame(q.readBuf, buf, limit-offset)
+		if err != nil {
+			log.Errorf("Failed to read from segment %v at offset %v, skipping %v bytes: %v",
+				pos.segment, offset, limit-offset, err)
+			offset = limit
+			q.closeReader()
+			break
+		}
+		offset += uint64(frameHeaderSize + len(buf))
+
+		event, err := q.decoder.decode(buf)
+		if err != nil {
+			log.Errorf("Dropping event, failed to decode event from segment %v: %v",
+				pos.segment, err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, offset
+}
+
+// openReader prepares the reader to read committed frames between pos and
+// limit.
+// The reader is limited to the committed contents of the segment, so to never
+// read data of partially written frames.
+func (q *Queue) openReader(pos position, limit uint64) error {
+	if q.reader == nil || q.readerID != pos.segment {
+		q.closeReader()
+
+		f, err := os.Open(segmentPath(q.path, pos.segment))
+		if err != nil {
+			return err
+		}
+		q.reader = f
+		q.readerID = pos.segment
+	}
+
+	section := io.NewSectionReader(q.reader, int64(pos.offset), int64(limit-pos.offset))
+	if q.readBuf == nil {
+		q.readBuf = bufio.NewReaderSize(section, readBufferSize)
+	} else {
+		q.readBuf.Reset(section)
+	}
+	return nil
+}
+
+func (q *Queue) closeReader() {
+	if q.reader != nil {
+		q.reader.Close()
+		q.reader = nil
+	}
+}
+
+func (b *batch) Events() []publisher.Event {
+	return b.events
+}
+
+// ACK marks the batch as ACKed. Once all preceding batches have been ACKed,
+// the queue state is updated and segments not required anymore are removed.
+func (b *batch) ACK() {
+	q := b.queue
+
+	q.mu.Lock()
+	if b.acked {
+		q.mu.Unlock()
+		panic("Can not acknowledge already acknowledged batch")
+	}
+	b.acked = true
+
+	advanced := false
+	for len(q.batches) > 0 && q.batches[0].acked {
+		q.batches[0] = nil
+		q.batches = q.batches[1:]
+		advanced = true
+	}
+	if advanced {
+		q.collectSegments()
+		q.cond.Broadcast()
+	}
+	q.mu.Unlock()
+
+	if advanced {
+		q.persistState()
+	}
+}
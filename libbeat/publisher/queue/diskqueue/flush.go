@@ -0,0 +1,231 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"io"
+	"time"
+)
+
+// flushLoop writes pending frames to disk. A flush is triggered by
+// producers, once enough frames are pending, or by the flush timer.
+// If a write fails, the flush is retried after RetryInterval.
+func (q *Queue) flushLoop() {
+	defer q.wg.Done()
+
+	var tick <-chan time.Time
+	if q.settings.FlushTimeout > 0 {
+		ticker := time.NewTicker(q.settings.FlushTimeout)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	defer q.closeWriter()
+
+	for {
+		select {
+		case <-q.done:
+			if err := q.flush(); err != nil {
+				q.logger.Errorf("Failed to flush events on shutdown: %v", err)
+			}
+			return
+		case <-q.flushSig:
+		case <-tick:
+		}
+
+		for err := q.flush(); err != nil; err = q.flush() {
+			q.logger.Errorf("Failed to write events to disk queue (retry in %v): %v",
+				q.settings.RetryInterval, err)
+
+			select {
+			case <-q.done:
+				return
+			case <-time.After(q.settings.RetryInterval):
+			}
+		}
+	}
+}
+
+// signalFlush asks the flush loop to write all pending frames.
+func (q *Queue) signalFlush() {
+	select {
+	case q.flushSig <- struct{}{}:
+	default:
+	}
+}
+
+// flush writes all frames pending at the time of the call to disk. Frames are
+// committed and ACKed to producers segment by segment, after the segment file
+// has been synced.
+func (q *Queue) flush() error {
+	q.mu.Lock()
+	frames := q.pending
+	q.flushing = len(frames)
+	q.mu.Unlock()
+
+	for len(frames) > 0 {
+		seg, err := q.activeSegment()
+		if err != nil {
+			q.abortFlush()
+			return err
+		}
+
+		size := seg.size
+		n := 0
+		for _, frame := range frames {
+			fits := size+uint64(len(frame.buf)) <= q.settings.SegmentSize
+			if !fits && (n > 0 || seg.frames > 0) {
+				break
+			}
+			size += uint64(len(frame.buf))
+			n++
+		}
+
+		if n == 0 {
+			q.sealSegment(seg)
+			continue
+		}
+
+		if err := q.write(seg, frames[:n]); err != nil {
+			q.abortFlush()
+			return err
+		}
+
+		q.commit(seg, size, frames[:n])
+		frames = frames[n:]
+	}
+
+	return nil
+}
+
+// activeSegment returns the segment to append frames to, creating a new
+// segment file if required.
+func (q *Queue) activeSegment() (*segment, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if n := len(q.segments); n > 0 && !q.segments[n-1].sealed {
+		return q.segments[n-1], nil
+	}
+
+	id := q.nextID
+	f, err := createSegment(segmentPath(q.path, id), q.settings.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	seg := &segment{id: id, size: segmentHeaderSize}
+	q.writer = f
+	q.nextID++
+	q.segments = append(q.segments, seg)
+	q.usedSize += seg.size
+	return seg, nil
+}
+
+// sealSegment marks the segment as complete and closes the writer.
+func (q *Queue) sealSegment(seg *segment) {
+	q.closeWriter()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	seg.sealed = true
+	q.collectSegments()
+	q.cond.Broadcast()
+}
+
+func (q *Queue) closeWriter() {
+	if q.writer != nil {
+		q.writer.Close()
+		q.writer = nil
+	}
+}
+
+// write appends the frames to the active segment and syncs the segment file.
+// On failure the segment file is truncated to its last committed size.
+func (q *Queue) write(seg *segment, frames []pendingFrame) error {
+	var sz int
+	for _, frame := range frames {
+		sz += len(frame.buf)
+	}
+
+	buf := make([]byte, 0, sz)
+	for _, frame := range frames {
+		buf = append(buf, frame.buf...)
+	}
+
+	_, err := q.writer.Write(buf)
+	if err == nil {
+		err = q.writer.Sync()
+	}
+	if err != nil {
+		q.rollback(seg)
+	}
+	return err
+}
+
+// rollback truncates the active segment to the last committed frame.
+// If the file can not be restored, the segment is sealed, such that a new
+// segment is started on the next flush.
+func (q *Queue) rollback(seg *segment) {
+	err := q.writer.Truncate(int64(seg.size))
+	if err == nil {
+		_, err = q.writer.Seek(int64(seg.size), io.SeekStart)
+	}
+	if err != nil {
+		q.logger.Errorf("Failed to restore segment %v: %v", seg.id, err)
+		q.sealSegment(seg)
+	}
+}
+
+// commit makes the written frames available to consumers and ACKs the
+// events to their producers.
+func (q *Queue) commit(seg *segment, size uint64, frames []pendingFrame) {
+	counts := map[*producer]int{}
+
+	q.mu.Lock()
+	seg.size = size
+	seg.frames += len(frames)
+	if seg.size >= q.settings.SegmentSize {
+		seg.sealed = true
+		q.closeWriter()
+	}
+
+	for i, frame := range frames {
+		if p := frame.producer; p != nil && p.ackCB != nil && !p.cancelled {
+			counts[p]++
+		}
+		q.pending[i] = pendingFrame{}
+	}
+	q.pending = q.pending[len(frames):]
+	q.flushing -= len(frames)
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	for p, count := range counts {
+		p.ackCB(count)
+	}
+	if q.settings.Eventer != nil {
+		q.settings.Eventer.OnACK(len(frames))
+	}
+}
+
+func (q *Queue) abortFlush() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.flushing = 0
+}
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/feature"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/publisher/queue"
+)
+
+// Feature exposes a segment based persistent queue.
+var Feature = queue.Feature("disk", create,
+	feature.NewDetails(
+		"Disk queue",
+		"Buffer events in segment files on disk before sending to the output.",
+		feature.Experimental),
+)
+
+func init() {
+	queue.RegisterType("disk", create)
+}
+
+func create(eventer queue.Eventer, logger *logp.Logger, cfg *common.Config) (queue.Queue, error) {
+	cfgwarn.Experimental("The disk queue is experimental")
+
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	path := config.Path
+	if path == "" {
+		path = paths.Resolve(paths.Data, "diskqueue")
+	}
+
+	if logger == nil {
+		logger = logp.NewLogger("diskqueue")
+	}
+
+	return NewQueue(logger, path, Settings{
+		Eventer:       eventer,
+		Mode:          config.Permissions,
+		MaxSize:       uint64(config.MaxSize),
+		SegmentSize:   uint64(config.SegmentSize),
+		FlushEvents:   config.FlushEvents,
+		FlushTimeout:  config.FlushTimeout,
+		RetryInterval: config.RetryInterval,
+	})
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/publisher/queue"
+)
+
+// producer serializes events and adds them to the queue its pending frames.
+// The producer state is protected by the queue mutex.
+type producer struct {
+	queue   *Queue
+	encoder *encoder
+
+	ackCB        func(count int)
+	dropCB       func(beat.Event)
+	dropOnCancel bool
+	cancelled    bool
+}
+
+func newProducer(
+	q *Queue,
+	ackCB func(int),
+	dropCB func(beat.Event),
+	dropOnCancel bool,
+) queue.Producer {
+	return &producer{
+		queue:        q,
+		encoder:      newEncoder(),
+		ackCB:        ackCB,
+		dropCB:       dropCB,
+		dropOnCancel: dropOnCancel,
+	}
+}
+
+func (p *producer) Publish(event publisher.Event) bool {
+	return p.publish(event, true)
+}
+
+func (p *producer) TryPublish(event publisher.Event) bool {
+	return p.publish(event, false)
+}
+
+func (p *producer) publish(event publisher.Event, block bool) bool {
+	q := p.queue
+	log := q.logger
+
+	payload, err := p.encoder.encode(&event)
+	if err != nil {
+		log.Errorf("Dropping event, failed to serialize event: %v", err)
+		return false
+	}
+
+	frame := pendingFrame{
+		buf:      appendFrame(make([]byte, 0, frameHeaderSize+len(payload)), payload),
+		producer: p,
+	}
+	if p.dropCB != nil {
+		frame.event = &event
+	}
+	sz := uint64(len(frame.buf))
+
+	q.mu.Lock()
+	for {
+		if q.closed || p.cancelled {
+			q.mu.Unlock()
+			return false
+		}
+
+		// Always accept an event if the queue is empty, so to guarantee progress
+		// if a single event exceeds the queue size.
+		buffered := q.bufferedSize()
+		if buffered == 0 || buffered+sz <= q.settings.MaxSize {
+			break
+		}
+
+		if !block {
+			q.mu.Unlock()
+			log.Debug("Dropping event, disk queue is full")
+			return false
+		}
+		q.cond.Wait()
+	}
+
+	q.pending = append(q.pending, frame)
+	q.usedSize += sz
+	flush := q.settings.FlushTimeout <= 0 ||
+		(q.settings.FlushEvents > 0 && len(q.pending) >= q.settings.FlushEvents)
+	q.mu.Unlock()
+
+	if flush {
+		q.signalFlush()
+	}
+	return true
+}
+
+// Cancel closes the producer. If the producer has been configured to drop
+// events on cancel, events not yet written to disk are removed from the
+// queue.
+func (p *producer) Cancel() int {
+	q := p.queue
+
+	var dropped []*publisher.Event
+
+	q.mu.Lock()
+	p.cancelled = true
+	removed := 0
+	if p.dropOnCancel {
+		kept := q.pending[:q.flushing:q.flushing]
+		for _, frame := range q.pending[q.flushing:] {
+			if frame.producer != p {
+				kept = append(kept, frame)
+				continue
+			}
+
+			removed++
+			q.usedSize -= uint64(len(frame.buf))
+			if frame.event != nil {
+				dropped = append(dropped, frame.event)
+			}
+		}
+		q.pending = kept
+	}
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	for _, event := range dropped {
+		p.dropCB(event.Content)
+	}
+	return removed
+}
@@ -0,0 +1,353 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/publisher/queue"
+)
+
+// Queue implements a persistent queue.Queue, storing events in append-only
+// segment files.
+//
+// Producers serialize events into frames, that are buffered in memory until
+// the flush loop writes and syncs them to the active segment. Producers get
+// the ACK for their events once the events have been synced to disk. Only
+// synced frames are visible to consumers.
+// Batches read by the consumers are tracked in read order. The position of
+// the oldest not yet ACKed batch is persisted in the state file, and segments
+// that have been read and ACKed completely are removed.
+type Queue struct {
+	logger   *logp.Logger
+	path     string
+	settings Settings
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	closed bool
+
+	// segments holds all segments ordered by ID. Only the last segment can be
+	// active (not sealed).
+	segments []*segment
+	nextID   uint64
+	usedSize uint64 // total size of segments and pending frames
+
+	// write state. The writer file is owned by the flush loop.
+	pending  []pendingFrame
+	flushing int // number of pending frames currently written by the flush loop
+	writer   *os.File
+	flushSig chan struct{}
+
+	// read state
+	readMu   sync.Mutex
+	readPos  position // position of next frame to be read
+	reader   *os.File
+	readerID uint64
+	readBuf  *bufio.Reader
+	decoder  *decoder
+	batches  []*batch // batches not yet ACKed, in read order
+
+	stateMu   sync.Mutex
+	persisted position
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Settings configure the disk queue.
+type Settings struct {
+	Eventer queue.Eventer
+
+	// Mode sets the file permissions of segment and state files.
+	Mode os.FileMode
+
+	// MaxSize limits the total size of events not yet ACKed. Producers block
+	// if the limit is reached, until enough events have been ACKed.
+	// Segments are deleted only after all events in a segment have been
+	// ACKed, so the disk usage can exceed MaxSize by up to SegmentSize.
+	MaxSize uint64
+
+	// SegmentSize configures the size after which a new segment file is
+	// started.
+	SegmentSize uint64
+
+	// FlushEvents triggers a flush once the given number of events is
+	// waiting to be written. If set to 0, flushes are only triggered by
+	// FlushTimeout.
+	FlushEvents int
+
+	// FlushTimeout configures the maximum duration events are buffered in
+	// memory before being written. If FlushTimeout is 0, every event is
+	// written immediately.
+	FlushTimeout time.Duration
+
+	// RetryInterval configures the wait time before retrying to write events
+	// after a write error (e.g. disk full).
+	RetryInterval time.Duration
+}
+
+type pendingFrame struct {
+	buf      []byte
+	producer *producer
+	event    *publisher.Event // only set if the producer requires drop callbacks
+}
+
+// NewQueue creates a new disk queue storing segments in path. If segments
+// from a previous run are found, the segments are validated and events not
+// yet ACKed will be published again.
+func NewQueue(logger *logp.Logger, path string, settings Settings) (*Queue, error) {
+	if logger == nil {
+		logger = logp.NewLogger("diskqueue")
+	}
+
+	defaults := defaultConfig()
+	if settings.Mode == 0 {
+		settings.Mode = defaults.Permissions
+	}
+	if settings.SegmentSize == 0 {
+		settings.SegmentSize = uint64(defaults.SegmentSize)
+	}
+	if settings.MaxSize == 0 {
+		settings.MaxSize = uint64(defaults.MaxSize)
+	}
+	if settings.RetryInterval <= 0 {
+		settings.RetryInterval = defaults.RetryInterval
+	}
+
+	if err := os.MkdirAll(path, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create queue directory %v: %v", path, err)
+	}
+
+	q := &Queue{
+		logger:   logger,
+		path:     path,
+		settings: settings,
+		flushSig: make(chan struct{}, 1),
+		decoder:  newDecoder(),
+		done:     make(chan struct{}),
+	}
+	q.cond = sync.NewCond(&q.mu)
+
+	if err := q.recover(); err != nil {
+		return nil, err
+	}
+
+	q.wg.Add(1)
+	go q.flushLoop()
+
+	return q, nil
+}
+
+// recover loads the queue state and validates all existing segment files.
+// Segments older than the persisted read position are deleted.
+func (q *Queue) recover() error {
+	log := q.logger
+
+	pos, err := readState(q.path)
+	if err != nil {
+		if err != errInvalidState {
+			return err
+		}
+		log.Warnf("Ignoring invalid queue state in %v. Events might be published again.", q.path)
+		pos = position{}
+	}
+
+	ids, err := listSegmentIDs(q.path)
+	if err != nil {
+		return err
+	}
+
+	frames := 0
+	for _, id := range ids {
+		path := segmentPath(q.path, id)
+		if id < pos.segment {
+			log.Debugf("Removing already ACKed segment %v", path)
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		seg, truncated, err := recoverSegment(path, id)
+		if err != nil {
+			return fmt.Errorf("failed to recover segment %v: %v", path, err)
+		}
+		if truncated {
+			log.Warnf("Segment %v has been truncated to %v bytes, due to incomplete or corrupted data",
+				path, seg.size)
+		}
+
+		q.segments = append(q.segments, seg)
+		q.usedSize += seg.size
+		q.nextID = id + 1
+		frames += seg.frames
+	}
+
+	if q.nextID < pos.segment {
+		q.nextID = pos.segment
+	}
+
+	q.readPos = position{segment: q.nextID, offset: segmentHeaderSize}
+	if len(q.segments) > 0 {
+		first := q.segments[0]
+		q.readPos.segment = first.id
+		if first.id == pos.segment && pos.offset > segmentHeaderSize {
+			if pos.offset <= first.size {
+				q.readPos.offset = pos.offset
+			} else {
+				log.Warnf("Read position %v exceeds size of segment %v. Events will be published again.",
+					pos.offset, first.id)
+			}
+		}
+	}
+	q.persisted = q.readPos
+
+	if len(q.segments) > 0 {
+		log.Infof("Disk queue recovered %v segments (%v) with up to %v events from %v",
+			len(q.segments), humanize.Bytes(q.usedSize), frames, q.path)
+	}
+	return nil
+}
+
+// Close stops the queue. Events not yet written are flushed to disk before
+// Close returns.
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	close(q.done)
+	q.wg.Wait()
+
+	q.readMu.Lock()
+	defer q.readMu.Unlock()
+	q.closeReader()
+	return nil
+}
+
+// BufferConfig returns the queue initial buffer settings.
+func (q *Queue) BufferConfig() queue.BufferConfig {
+	return queue.BufferConfig{Events: -1}
+}
+
+// Producer creates a new queue producer for publishing events.
+func (q *Queue) Producer(cfg queue.ProducerConfig) queue.Producer {
+	return newProducer(q, cfg.ACK, cfg.OnDrop, cfg.DropOnCancel)
+}
+
+// Consumer creates a new queue consumer for consuming and acking events.
+func (q *Queue) Consumer() queue.Consumer {
+	return newConsumer(q)
+}
+
+func (q *Queue) findSegment(id uint64) (int, *segment) {
+	for i, seg := range q.segments {
+		if seg.id == id {
+			return i, seg
+		}
+	}
+	return -1, nil
+}
+
+// collectSegments removes sealed segments that have been read and ACKed
+// completely. Must be called with q.mu held.
+func (q *Queue) collectSegments() {
+	for len(q.segments) > 0 {
+		seg := q.segments[0]
+		if !seg.sealed || seg.id >= q.readPos.segment {
+			return
+		}
+		if len(q.batches) > 0 && q.batches[0].segment <= seg.id {
+			return
+		}
+
+		path := segmentPath(q.path, seg.id)
+		if err := os.Remove(path); err != nil {
+			q.logger.Errorf("Failed to remove segment %v: %v", path, err)
+		}
+		q.logger.Debugf("Removed ACKed segment %v", path)
+
+		q.usedSize -= seg.size
+		q.segments[0] = nil
+		q.segments = q.segments[1:]
+		q.cond.Broadcast()
+	}
+}
+
+// ackPosition returns the position of the oldest frame not yet ACKed.
+// Must be called with q.mu held.
+func (q *Queue) ackPosition() position {
+	if len(q.batches) > 0 {
+		b := q.batches[0]
+		return position{segment: b.segment, offset: b.start}
+	}
+	return q.readPos
+}
+
+// bufferedSize returns the number of bytes not yet ACKed, including frames
+// waiting to be written. Must be called with q.mu held.
+func (q *Queue) bufferedSize() uint64 {
+	pos := q.ackPosition()
+
+	acked := uint64(0)
+	for _, seg := range q.segments {
+		if seg.id > pos.segment {
+			break
+		}
+		if seg.id < pos.segment {
+			acked += seg.size
+		} else {
+			acked += pos.offset
+		}
+	}
+	return q.usedSize - acked
+}
+
+// persistState writes the current ACK position to the state file.
+func (q *Queue) persistState() {
+	q.stateMu.Lock()
+	defer q.stateMu.Unlock()
+
+	q.mu.Lock()
+	pos := q.ackPosition()
+	q.mu.Unlock()
+
+	if pos == q.persisted {
+		return
+	}
+
+	if err := writeState(q.path, q.settings.Mode, pos); err != nil {
+		q.logger.Errorf("Failed to update disk queue state: %v", err)
+		return
+	}
+	q.persisted = pos
+}
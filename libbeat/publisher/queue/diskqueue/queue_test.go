@@ -0,0 +1,252 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"flag"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/publisher/queue"
+	"github.com/elastic/beats/libbeat/publisher/queue/queuetest"
+)
+
+var seed int64
+
+type testQueue struct {
+	*Queue
+	teardown func()
+}
+
+func init() {
+	flag.Int64Var(&seed, "seed", time.Now().UnixNano(), "test random seed")
+}
+
+func TestProduceConsumer(t *testing.T) {
+	maxEvents := 4096
+	minEvents := 32
+
+	rand.Seed(seed)
+	events := rand.Intn(maxEvents-minEvents) + minEvents
+	batchSize := rand.Intn(events-8) + 4
+
+	t.Log("seed: ", seed)
+	t.Log("events: ", events)
+	t.Log("batchSize: ", batchSize)
+
+	testWith := func(factory queuetest.QueueFactory) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Run("single", func(t *testing.T) {
+				queuetest.TestSingleProducerConsumer(t, events, batchSize, factory)
+			})
+			t.Run("multi", func(t *testing.T) {
+				queuetest.TestMultiProducerConsumer(t, events, batchSize, factory)
+			})
+		}
+	}
+
+	t.Run("direct", testWith(makeTestQueue(Settings{
+		SegmentSize: 16 * 1024,
+	})))
+	t.Run("flush", testWith(makeTestQueue(Settings{
+		SegmentSize:  16 * 1024,
+		FlushEvents:  batchSize / 2,
+		FlushTimeout: 10 * time.Millisecond,
+	})))
+}
+
+func TestRecoverUnACKedEvents(t *testing.T) {
+	path, teardown := setupPath(t)
+	defer teardown()
+
+	settings := Settings{SegmentSize: 1024}
+
+	q := openQueue(t, path, settings)
+	publishN(t, q, 0, 100)
+	consumer := q.Consumer()
+	readN(t, consumer, 40, true)
+	readN(t, consumer, 20, false) // read, but not ACKed
+	require.NoError(t, q.Close())
+
+	q = openQueue(t, path, settings)
+	defer q.Close()
+
+	events := readN(t, q.Consumer(), 60, true)
+	assertValues(t, events, 40)
+}
+
+func TestRemoveACKedSegments(t *testing.T) {
+	path, teardown := setupPath(t)
+	defer teardown()
+
+	q := openQueue(t, path, Settings{SegmentSize: 1024})
+	defer q.Close()
+
+	publishN(t, q, 0, 200)
+	ids, err := listSegmentIDs(path)
+	require.NoError(t, err)
+	require.True(t, len(ids) > 1, "expected multiple segments")
+
+	readN(t, q.Consumer(), 200, true)
+
+	ids, err = listSegmentIDs(path)
+	require.NoError(t, err)
+	assert.Len(t, ids, 1, "only the active segment is expected to be left")
+}
+
+func TestRecoverTruncatedSegment(t *testing.T) {
+	path, teardown := setupPath(t)
+	defer teardown()
+
+	settings := Settings{SegmentSize: 64 * 1024}
+
+	q := openQueue(t, path, settings)
+	publishN(t, q, 0, 10)
+	require.NoError(t, q.Close())
+
+	// simulate partial write of an event
+	ids, err := listSegmentIDs(path)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	f, err := os.OpenFile(segmentPath(path, ids[0]), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{100, 0, 0, 0, 1, 2, 3, 4, 5})
+	require.NoError(t, err)
+	f.Close()
+
+	q = openQueue(t, path, settings)
+	defer q.Close()
+
+	publishN(t, q, 10, 5)
+	events := readN(t, q.Consumer(), 15, true)
+	assertValues(t, events, 0)
+}
+
+func TestTryPublishOnFullQueue(t *testing.T) {
+	path, teardown := setupPath(t)
+	defer teardown()
+
+	q := openQueue(t, path, Settings{SegmentSize: 1024, MaxSize: 1024})
+	defer q.Close()
+
+	producer := q.Producer(queue.ProducerConfig{})
+	published := 0
+	for producer.TryPublish(makeEvent(published)) {
+		published++
+		require.True(t, published < 1024, "queue did not block")
+	}
+	assert.True(t, published > 0)
+
+	// free space by consuming events
+	readN(t, q.Consumer(), published, true)
+	assert.True(t, producer.TryPublish(makeEvent(published)))
+}
+
+func makeTestQueue(settings Settings) queuetest.QueueFactory {
+	return func(t *testing.T) queue.Queue {
+		path, teardown := setupPath(t)
+		q, err := NewQueue(nil, path, settings)
+		if err != nil {
+			teardown()
+			t.Fatal(err)
+		}
+		return &testQueue{Queue: q, teardown: teardown}
+	}
+}
+
+func (t *testQueue) Close() error {
+	err := t.Queue.Close()
+	t.teardown()
+	return err
+}
+
+func setupPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "diskqueue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "queue"), func() { os.RemoveAll(dir) }
+}
+
+func openQueue(t *testing.T, path string, settings Settings) *Queue {
+	q, err := NewQueue(nil, path, settings)
+	require.NoError(t, err)
+	return q
+}
+
+// publishN publishes n events and waits for the events to be written to disk.
+func publishN(t *testing.T, q *Queue, start, n int) {
+	acked := make(chan int, n)
+	producer := q.Producer(queue.ProducerConfig{
+		ACK: func(count int) { acked <- count },
+	})
+	for i := start; i < start+n; i++ {
+		require.True(t, producer.Publish(makeEvent(i)))
+	}
+
+	for total := 0; total < n; {
+		select {
+		case count := <-acked:
+			total += count
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for events to be written")
+		}
+	}
+}
+
+// readN reads n events. Batches are ACKed if ack is set.
+func readN(t *testing.T, consumer queue.Consumer, n int, ack bool) []publisher.Event {
+	var events []publisher.Event
+	for len(events) < n {
+		batch, err := consumer.Get(n - len(events))
+		require.NoError(t, err)
+		events = append(events, batch.Events()...)
+		if ack {
+			batch.ACK()
+		}
+	}
+	require.Len(t, events, n)
+	return events
+}
+
+func assertValues(t *testing.T, events []publisher.Event, start int) {
+	for i, event := range events {
+		value, err := event.Content.Fields.GetValue("value")
+		require.NoError(t, err)
+		assert.EqualValues(t, start+i, value)
+	}
+}
+
+func makeEvent(value int) publisher.Event {
+	return publisher.Event{
+		Content: beat.Event{
+			Timestamp: time.Now(),
+			Fields:    common.MapStr{"value": value},
+		},
+	}
+}
@@ -0,0 +1,213 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// segment describes a single append-only segment file.
+// A segment file starts with a small header holding the segment format
+// version, followed by a sequence of frames. Each frame is prefixed with the
+// payload size and a CRC32 checksum of the payload.
+type segment struct {
+	id     uint64
+	size   uint64 // committed (synced) segment size in bytes, including header
+	frames int    // number of committed frames
+	sealed bool   // no more frames will be appended if set
+}
+
+const (
+	segmentVersion    uint32 = 1
+	segmentHeaderSize        = 4
+	frameHeaderSize          = 8
+
+	segmentExt = ".seg"
+)
+
+var (
+	errInvalidFrame   = errors.New("invalid frame")
+	errInvalidVersion = errors.New("unsupported segment version")
+)
+
+func segmentPath(dir string, id uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%v%v", id, segmentExt))
+}
+
+// listSegmentIDs returns the sorted list of segment IDs found in dir.
+func listSegmentIDs(dir string) ([]uint64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint64
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// createSegment creates a new segment file and writes the segment header.
+// The header is synced to disk before the file is returned.
+func createSegment(path string, mode os.FileMode) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var hdr [segmentHeaderSize]byte
+	binary.LittleEndian.PutUint32(hdr[:], segmentVersion)
+	if _, err := f.Write(hdr[:]); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// recoverSegment validates all frames in an existing segment file. If a
+// partial or corrupted frame is found, the segment is truncated to the last
+// valid frame. The returned segment is sealed.
+func recoverSegment(path string, id uint64) (*segment, bool, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+	fileSize := uint64(info.Size())
+
+	seg := &segment{id: id, sealed: true}
+	if fileSize < segmentHeaderSize {
+		// crashed before the header has been written
+		return seg, true, f.Truncate(0)
+	}
+
+	r := bufio.NewReader(f)
+	if err := readSegmentHeader(r); err != nil {
+		return nil, false, err
+	}
+
+	seg.size = segmentHeaderSize
+	var buf []byte
+	for seg.size < fileSize {
+		buf, err = readFrame(r, buf, fileSize-seg.size)
+		if err != nil {
+			break
+		}
+
+		seg.size += uint64(frameHeaderSize + len(buf))
+		seg.frames++
+	}
+
+	truncated := seg.size < fileSize
+	if truncated {
+		if err := f.Truncate(int64(seg.size)); err != nil {
+			return nil, false, err
+		}
+		if err := f.Sync(); err != nil {
+			return nil, false, err
+		}
+	}
+	return seg, truncated, nil
+}
+
+func readSegmentHeader(r io.Reader) error {
+	var hdr [segmentHeaderSize]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return err
+	}
+	if version := binary.LittleEndian.Uint32(hdr[:]); version != segmentVersion {
+		return errInvalidVersion
+	}
+	return nil
+}
+
+// appendFrame appends the frame header and the payload to buf.
+func appendFrame(buf, payload []byte) []byte {
+	var hdr [frameHeaderSize]byte
+	binary.LittleEndian.PutUint32(hdr[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(hdr[4:8], crc32.ChecksumIEEE(payload))
+	buf = append(buf, hdr[:]...)
+	return append(buf, payload...)
+}
+
+// readFrame reads the next frame of at most limit bytes (including the frame
+// header) into buf. The payload is returned if the frame is complete and the
+// checksum matches.
+func readFrame(r io.Reader, buf []byte, limit uint64) ([]byte, error) {
+	var hdr [frameHeaderSize]byte
+	if limit < frameHeaderSize {
+		return nil, errInvalidFrame
+	}
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+
+	sz := uint64(binary.LittleEndian.Uint32(hdr[0:4]))
+	checksum := binary.LittleEndian.Uint32(hdr[4:8])
+	if sz == 0 || sz > limit-frameHeaderSize {
+		return nil, errInvalidFrame
+	}
+
+	if uint64(cap(buf)) >= sz {
+		buf = buf[:sz]
+	} else {
+		buf = make([]byte, sz)
+	}
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(buf) != checksum {
+		return nil, errInvalidFrame
+	}
+	return buf, nil
+}
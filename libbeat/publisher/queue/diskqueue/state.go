@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// position points to a frame within a segment file.
+type position struct {
+	segment uint64
+	offset  uint64
+}
+
+const (
+	stateFileName = "state.dat"
+	stateSize     = 20
+)
+
+var errInvalidState = errors.New("invalid queue state file")
+
+// readState reads the position of the oldest not yet ACKed frame from the
+// queue its state file. If no state file exists, the zero position is
+// returned.
+func readState(dir string) (position, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(dir, stateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return position{}, nil
+		}
+		return position{}, err
+	}
+
+	if len(contents) != stateSize {
+		return position{}, errInvalidState
+	}
+	if crc32.ChecksumIEEE(contents[:16]) != binary.LittleEndian.Uint32(contents[16:]) {
+		return position{}, errInvalidState
+	}
+
+	return position{
+		segment: binary.LittleEndian.Uint64(contents[0:8]),
+		offset:  binary.LittleEndian.Uint64(contents[8:16]),
+	}, nil
+}
+
+// writeState atomically replaces the queue state file, by writing the new
+// state to a temporary file first.
+// The state is not synced to disk. In case of a crash, an older position
+// might be restored, which results in events being published again.
+func writeState(dir string, mode os.FileMode, pos position) error {
+	var buf [stateSize]byte
+	binary.LittleEndian.PutUint64(buf[0:8], pos.segment)
+	binary.LittleEndian.PutUint64(buf[8:16], pos.offset)
+	binary.LittleEndian.PutUint32(buf[16:20], crc32.ChecksumIEEE(buf[:16]))
+
+	path := filepath.Join(dir, stateFileName)
+	tmp := path + ".new"
+	if err := ioutil.WriteFile(tmp, buf[:], mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -34,6 +34,7 @@ import (
 	_ "github.com/elastic/beats/libbeat/outputs/logstash"
 	"github.com/elastic/beats/libbeat/paths"
 	"github.com/elastic/beats/libbeat/publisher/pipeline/stress"
+	_ "github.com/elastic/beats/libbeat/publisher/queue/diskqueue"
 	_ "github.com/elastic/beats/libbeat/publisher/queue/memqueue"
 	_ "github.com/elastic/beats/libbeat/publisher/queue/spool"
 	"github.com/elastic/beats/libbeat/service"
@@ -52,7 +52,29 @@ func (h *HttpEvent) GetMeta() server.Meta {
 	return h.meta
 }
 
+// NewHttpServer creates a server that pushes the body of every POST request as event.
 func NewHttpServer(mb mb.BaseMetricSet) (server.Server, error) {
+	h, err := newHttpServer(mb)
+	if err != nil {
+		return nil, err
+	}
+	h.server.Handler = http.HandlerFunc(h.handleFunc)
+	return h, nil
+}
+
+// NewHttpServerWithHandler creates a server serving all requests with the
+// given handler. No events are pushed to the events channel, the handler
+// must forward the data itself.
+func NewHttpServerWithHandler(mb mb.BaseMetricSet, handlerFunc http.HandlerFunc) (server.Server, error) {
+	h, err := newHttpServer(mb)
+	if err != nil {
+		return nil, err
+	}
+	h.server.Handler = handlerFunc
+	return h, nil
+}
+
+func newHttpServer(mb mb.BaseMetricSet) (*HttpServer, error) {
 	config := defaultHttpConfig()
 	err := mb.Module().UnpackConfig(&config)
 	if err != nil {
@@ -73,8 +95,7 @@ func NewHttpServer(mb mb.BaseMetricSet) (server.Server, error) {
 	}
 
 	httpServer := &http.Server{
-		Addr:    net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port))),
-		Handler: http.HandlerFunc(h.handleFunc),
+		Addr: net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port))),
 	}
 	if tlsConfig != nil {
 		httpServer.TLSConfig = tlsConfig.BuildModuleConfig(config.Host)
@@ -20,6 +20,7 @@ package udp
 import (
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -35,6 +36,7 @@ type UdpServer struct {
 	receiveBufferSize int
 	done              chan struct{}
 	eventQueue        chan server.Event
+	wg                sync.WaitGroup
 }
 
 type UdpEvent struct {
@@ -51,7 +53,12 @@ func (u *UdpEvent) GetMeta() server.Meta {
 }
 
 func NewUdpServer(base mb.BaseMetricSet) (server.Server, error) {
-	config := defaultUdpConfig()
+	return NewUdpServerWithDefaults(base, defaultUdpConfig())
+}
+
+// NewUdpServerWithDefaults creates a UDP server using the given settings as
+// defaults for the ones not present in the module configuration.
+func NewUdpServerWithDefaults(base mb.BaseMetricSet, config UdpConfig) (server.Server, error) {
 	err := base.Module().UnpackConfig(&config)
 	if err != nil {
 		return nil, err
@@ -80,11 +87,14 @@ func (g *UdpServer) Start() error {
 	logp.Info("Started listening for UDP on: %s", g.udpaddr.String())
 	g.listener = listener
 
+	g.wg.Add(1)
 	go g.watchMetrics()
 	return nil
 }
 
 func (g *UdpServer) watchMetrics() {
+	defer g.wg.Done()
+
 	buffer := make([]byte, g.receiveBufferSize)
 	for {
 		select {
@@ -95,18 +105,33 @@ func (g *UdpServer) watchMetrics() {
 
 		length, addr, err := g.listener.ReadFromUDP(buffer)
 		if err != nil {
+			select {
+			case <-g.done:
+				return
+			default:
+			}
 			logp.Err("Error reading from buffer: %v", err.Error())
 			continue
 		}
 
-		g.eventQueue <- &UdpEvent{
+		// The buffer is reused for the next read, so the event gets its own copy.
+		data := make([]byte, length)
+		copy(data, buffer[:length])
+
+		event := &UdpEvent{
 			event: common.MapStr{
-				server.EventDataKey: buffer[:length],
+				server.EventDataKey: data,
 			},
 			meta: server.Meta{
 				"client_ip": addr.IP.String(),
 			},
 		}
+
+		select {
+		case <-g.done:
+			return
+		case g.eventQueue <- event:
+		}
 	}
 }
 
@@ -117,5 +142,6 @@ func (g *UdpServer) GetEvents() chan server.Event {
 func (g *UdpServer) Stop() {
 	close(g.done)
 	g.listener.Close()
+	g.wg.Wait()
 	close(g.eventQueue)
 }
@@ -151,6 +151,7 @@ import (
 	_ "github.com/elastic/beats/metricbeat/module/postgresql/statement"
 	_ "github.com/elastic/beats/metricbeat/module/prometheus"
 	_ "github.com/elastic/beats/metricbeat/module/prometheus/collector"
+	_ "github.com/elastic/beats/metricbeat/module/prometheus/remote_write"
 	_ "github.com/elastic/beats/metricbeat/module/rabbitmq"
 	_ "github.com/elastic/beats/metricbeat/module/rabbitmq/connection"
 	_ "github.com/elastic/beats/metricbeat/module/rabbitmq/exchange"
@@ -160,6 +161,8 @@ import (
 	_ "github.com/elastic/beats/metricbeat/module/redis/info"
 	_ "github.com/elastic/beats/metricbeat/module/redis/key"
 	_ "github.com/elastic/beats/metricbeat/module/redis/keyspace"
+	_ "github.com/elastic/beats/metricbeat/module/statsd"
+	_ "github.com/elastic/beats/metricbeat/module/statsd/server"
 	_ "github.com/elastic/beats/metricbeat/module/system"
 	_ "github.com/elastic/beats/metricbeat/module/system/core"
 	_ "github.com/elastic/beats/metricbeat/module/system/cpu"
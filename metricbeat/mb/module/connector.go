@@ -18,6 +18,8 @@
 package module
 
 import (
+	"strings"
+
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/processors"
@@ -31,11 +33,17 @@ type Connector struct {
 	eventMeta     common.EventMetadata
 	dynamicFields *common.MapStrPointer
 	timeSeries    bool
+	identity      *beat.ClientIdentity
 }
 
 type connectorConfig struct {
 	Processors           processors.PluginConfig `config:"processors"`
 	common.EventMetadata `config:",inline"`      // Fields and tags to add to events.
+
+	// used to identify the module in the monitoring metrics
+	ID         string   `config:"id"`
+	Module     string   `config:"module"`
+	MetricSets []string `config:"metricsets"`
 }
 
 func NewConnector(pipeline beat.Pipeline, c *common.Config, dynFields *common.MapStrPointer) (*Connector, error) {
@@ -49,14 +57,30 @@ func NewConnector(pipeline beat.Pipeline, c *common.Config, dynFields *common.Ma
 		return nil, err
 	}
 
+	identity, err := moduleIdentity(c, config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Connector{
 		pipeline:      pipeline,
 		processors:    processors,
 		eventMeta:     config.EventMetadata,
 		dynamicFields: dynFields,
+		identity:      identity,
 	}, nil
 }
 
+// moduleIdentity identifies the pipeline client of a module in the monitoring
+// metrics. Modules without an ID are identified by their name and the hash of
+// their configuration.
+func moduleIdentity(c *common.Config, config connectorConfig) (*beat.ClientIdentity, error) {
+	return beat.NewClientIdentity(config.ID, config.Module, c, map[string]string{
+		"module":     config.Module,
+		"metricsets": strings.Join(config.MetricSets, ","),
+	})
+}
+
 func (c *Connector) Connect() (beat.Client, error) {
 	return c.pipeline.ConnectWith(beat.ClientConfig{
 		Processing: beat.ProcessingConfig{
@@ -64,5 +88,6 @@ func (c *Connector) Connect() (beat.Client, error) {
 			Processor:     c.processors,
 			DynamicFields: c.dynamicFields,
 		},
+		Identity: c.identity,
 	})
 }
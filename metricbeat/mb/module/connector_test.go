@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package module
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestConnectorIdentity(t *testing.T) {
+	identity := func(t *testing.T, settings common.MapStr) (string, map[string]string) {
+		c, err := NewConnector(nil, common.MustNewConfigFrom(settings), nil)
+		require.NoError(t, err)
+		return c.identity.ID, c.identity.Fields
+	}
+
+	id, fields := identity(t, common.MapStr{
+		"module":     "system",
+		"metricsets": []string{"cpu", "memory"},
+	})
+	assert.True(t, strings.HasPrefix(id, "system-"), id)
+	assert.Equal(t, map[string]string{"module": "system", "metricsets": "cpu,memory"}, fields)
+
+	id, _ = identity(t, common.MapStr{"module": "system", "id": "system-host"})
+	assert.Equal(t, "system-host", id)
+}
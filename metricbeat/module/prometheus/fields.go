@@ -32,5 +32,5 @@ func init() {
 // AssetPrometheus returns asset data.
 // This is the base64 encoded gzipped contents of ../metricbeat/module/prometheus.
 func AssetPrometheus() string {
-	return "eJyUkc1qwzAQhO9+ikG9hSQPoENfoYUeSwmKtba30R+7G0LeviQ2aQIttLppv9FoNNrgQGePJjWTTXTUDjC2RB7u9TZ0HRBJe+FmXIvHcwcAbxZMob2ERhGD1IyA71OgElvlYtsO0KmK7fpaBh49hpCUOkAoUVDyGMNFQ2ZcRvV4d6rJreEms+Y+OmBgSlH99d4nvEgkASs4tyoWimEioTVS2FNSnDgl5GD9hIFFbQ2bCEJqCEKI9bhPdPXaoIRM9w1sZ4/t6soBOzfyqPtP6m0ZzZvdTA50PlWJC/qhpsu6ayWTCfdL0t8yzKK/h7h70QPZ5dAal3GRuZX7Z84b2Tx81lcAAAD//3hXtDU="
+	return "eJyUkU1qKzEQhPdzikJvZ2wfQIt3hfcgyxCMPOqZ6Vh/dLdxfPtgz+A4f5CANqqvpC6VNjjQ2aNJzWQTHbUDjC2Rh/t/E10HRNJeuBnX4vG3A4AHC6bQXkKjiEFqRsDbKVCJrXKxbQfoVMV2fS0Djx5DSEodIJQoKHmM4eIhMy6jejw61eTWcJNZc08dMDClqP469w/+SSQBKzi3KhaKYSKhNVLYU1KcOCXkYP2EgUVtDZsIQmoIQoj1uE+X+cAGJWS6b2A737FdXTlg50Yedf9MvS3SvNnN5EDnU5W4oC9quqy7VjKZcL8k/S7DbPp5iLsXvSO7HFrjMi42t3K/zHkjmw+f9Umll0bCmYqF1L0OAIX4vdU="
 }
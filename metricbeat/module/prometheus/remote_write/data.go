@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote_write
+
+import (
+	"math"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// samplesToEvents maps the samples of a WriteRequest to events. Samples
+// sharing the same label set and timestamp are grouped into a single event.
+func samplesToEvents(req *writeRequest) []mb.Event {
+	var keys []string
+	events := map[string]*mb.Event{}
+
+	for _, ts := range req.Timeseries {
+		if ts == nil {
+			continue
+		}
+
+		var name string
+		labels := common.MapStr{}
+		for _, l := range ts.Labels {
+			if l == nil {
+				continue
+			}
+			if l.Name == "__name__" {
+				name = l.Value
+				continue
+			}
+			if l.Name != "" && l.Value != "" {
+				labels[l.Name] = l.Value
+			}
+		}
+		if name == "" {
+			continue
+		}
+
+		labelsHash := labels.String()
+		for _, s := range ts.Samples {
+			if s == nil || math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+				continue
+			}
+
+			timestamp := time.Unix(0, s.Timestamp*int64(time.Millisecond)).UTC()
+			key := labelsHash + timestamp.String()
+			event, exists := events[key]
+			if !exists {
+				fields := common.MapStr{
+					"metrics": common.MapStr{},
+				}
+				if len(labels) > 0 {
+					fields["labels"] = labels
+				}
+
+				event = &mb.Event{
+					Timestamp:  timestamp,
+					RootFields: common.MapStr{"prometheus": fields},
+				}
+				events[key] = event
+				keys = append(keys, key)
+			}
+
+			// Not checking anything here because we create these maps some lines before
+			metrics := event.RootFields["prometheus"].(common.MapStr)["metrics"].(common.MapStr)
+			metrics[name] = s.Value
+		}
+	}
+
+	list := make([]mb.Event, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, *events[key])
+	}
+	return list
+}
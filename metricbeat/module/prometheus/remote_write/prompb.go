@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote_write
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// The types below mirror the WriteRequest message of the Prometheus remote
+// storage protocol (prompb/remote.proto and prompb/types.proto). Only the
+// fields required for decoding samples are declared.
+
+type writeRequest struct {
+	Timeseries []*timeSeries `protobuf:"bytes,1,rep,name=timeseries" json:"timeseries,omitempty"`
+}
+
+type timeSeries struct {
+	Labels  []*label  `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+	Samples []*sample `protobuf:"bytes,2,rep,name=samples" json:"samples,omitempty"`
+}
+
+type label struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+type sample struct {
+	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Timestamp int64   `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *writeRequest) Reset()         { *m = writeRequest{} }
+func (m *writeRequest) String() string { return proto.CompactTextString(m) }
+func (*writeRequest) ProtoMessage()    {}
+
+func (m *timeSeries) Reset()         { *m = timeSeries{} }
+func (m *timeSeries) String() string { return proto.CompactTextString(m) }
+func (*timeSeries) ProtoMessage()    {}
+
+func (m *label) Reset()         { *m = label{} }
+func (m *label) String() string { return proto.CompactTextString(m) }
+func (*label) ProtoMessage()    {}
+
+func (m *sample) Reset()         { *m = sample{} }
+func (m *sample) String() string { return proto.CompactTextString(m) }
+func (*sample) ProtoMessage()    {}
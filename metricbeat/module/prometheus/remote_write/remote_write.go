@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package remote_write
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/logp"
+	serverhelper "github.com/elastic/beats/metricbeat/helper/server"
+	httpserver "github.com/elastic/beats/metricbeat/helper/server/http"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("prometheus", "remote_write", New)
+}
+
+// MetricSet receives samples pushed by Prometheus servers using the remote
+// write protocol.
+type MetricSet struct {
+	mb.BaseMetricSet
+	server serverhelper.Server
+	events chan mb.Event
+	log    *logp.Logger
+}
+
+// New creates a new remote_write metricset.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Experimental("The prometheus remote_write metricset is experimental")
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		events:        make(chan mb.Event),
+		log:           logp.NewLogger("prometheus.remote_write"),
+	}
+
+	svc, err := httpserver.NewHttpServerWithHandler(base, m.handleFunc)
+	if err != nil {
+		return nil, err
+	}
+	m.server = svc
+	return m, nil
+}
+
+// Run starts the HTTP server and reports received samples until the
+// metricset is stopped.
+func (m *MetricSet) Run(reporter mb.PushReporterV2) {
+	if err := m.server.Start(); err != nil {
+		reporter.Error(errors.Wrap(err, "failed to start HTTP server"))
+		return
+	}
+
+	for {
+		select {
+		case <-reporter.Done():
+			m.server.Stop()
+			return
+		case e := <-m.events:
+			reporter.Event(e)
+		}
+	}
+}
+
+func (m *MetricSet) handleFunc(writer http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		http.Error(writer, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	compressed, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		m.log.Errorw("Failed to read request body", "error", err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reqBuf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		m.log.Errorw("Failed to decompress request body", "error", err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var wr writeRequest
+	if err := proto.Unmarshal(reqBuf, &wr); err != nil {
+		m.log.Errorw("Failed to decode write request", "error", err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, e := range samplesToEvents(&wr) {
+		select {
+		case m.events <- e:
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	writer.WriteHeader(http.StatusAccepted)
+}
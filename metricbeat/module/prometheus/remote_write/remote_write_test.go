@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package remote_write
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+func testWriteRequest() *writeRequest {
+	return &writeRequest{
+		Timeseries: []*timeSeries{
+			{
+				Labels: []*label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []*sample{{Value: 10, Timestamp: 1561982400000}},
+			},
+			{
+				Labels: []*label{
+					{Name: "__name__", Value: "http_errors_total"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []*sample{{Value: 2, Timestamp: 1561982400000}},
+			},
+			{
+				Labels: []*label{
+					{Name: "__name__", Value: "up"},
+				},
+				Samples: []*sample{
+					{Value: 1, Timestamp: 1561982400000},
+					{Value: 1, Timestamp: 1561982415000},
+				},
+			},
+		},
+	}
+}
+
+func TestSamplesToEvents(t *testing.T) {
+	events := samplesToEvents(testWriteRequest())
+	require.Len(t, events, 3)
+
+	ts := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, ts, events[0].Timestamp)
+	assert.Equal(t, common.MapStr{
+		"prometheus": common.MapStr{
+			"labels": common.MapStr{"job": "api"},
+			"metrics": common.MapStr{
+				"http_requests_total": float64(10),
+				"http_errors_total":   float64(2),
+			},
+		},
+	}, events[0].RootFields)
+
+	assert.Equal(t, ts, events[1].Timestamp)
+	assert.Equal(t, common.MapStr{
+		"prometheus": common.MapStr{
+			"metrics": common.MapStr{"up": float64(1)},
+		},
+	}, events[1].RootFields)
+	assert.Equal(t, ts.Add(15*time.Second), events[2].Timestamp)
+}
+
+func TestHandleWriteRequest(t *testing.T) {
+	m := &MetricSet{
+		events: make(chan mb.Event, 10),
+		log:    logp.NewLogger("test"),
+	}
+
+	raw, err := proto.Marshal(testWriteRequest())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/write", bytes.NewReader(snappy.Encode(nil, raw)))
+	w := httptest.NewRecorder()
+	m.handleFunc(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Len(t, m.events, 3)
+}
+
+func TestHandleInvalidRequest(t *testing.T) {
+	m := &MetricSet{
+		events: make(chan mb.Event, 10),
+		log:    logp.NewLogger("test"),
+	}
+
+	req := httptest.NewRequest("POST", "/write", bytes.NewReader([]byte("not snappy")))
+	w := httptest.NewRecorder()
+	m.handleFunc(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest("GET", "/write", nil)
+	w = httptest.NewRecorder()
+	m.handleFunc(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Len(t, m.events, 0)
+}
@@ -0,0 +1,41 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"github.com/pkg/errors"
+)
+
+type statsdConfig struct {
+	Percentiles []float64 `config:"percentiles"`
+}
+
+func defaultConfig() statsdConfig {
+	return statsdConfig{
+		Percentiles: []float64{75, 95, 99},
+	}
+}
+
+func (c *statsdConfig) Validate() error {
+	for _, p := range c.Percentiles {
+		if p <= 0 || p > 100 {
+			return errors.Errorf("percentile %v out of range, must be greater than 0 and lower or equal than 100", p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// StatsD metric types.
+const (
+	typeCounter   = "c"
+	typeGauge     = "g"
+	typeTimer     = "ms"
+	typeHistogram = "h"
+	typeSet       = "s"
+)
+
+// statsdMetric is a single metric line received from a StatsD client, in
+// the form `<name>:<value>|<type>[|@<sample rate>][|#<tag>[:<value>],...]`.
+type statsdMetric struct {
+	name       string
+	metricType string
+	value      string
+	sampleRate float64
+	tags       common.MapStr
+}
+
+// parse splits a packet into its metric lines and parses each of them. Lines
+// that cannot be parsed are skipped and reported in the returned error.
+func parse(packet []byte) ([]statsdMetric, error) {
+	var metrics []statsdMetric
+	var errs []string
+	for _, line := range strings.Split(string(packet), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		m, err := parseLine(line)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+
+	if len(errs) > 0 {
+		return metrics, errors.New(strings.Join(errs, "; "))
+	}
+	return metrics, nil
+}
+
+func parseLine(line string) (statsdMetric, error) {
+	m := statsdMetric{sampleRate: 1}
+
+	sep := strings.IndexByte(line, ':')
+	if sep <= 0 {
+		return m, errors.Errorf("invalid metric '%s': missing name or value", line)
+	}
+	m.name = line[:sep]
+
+	parts := strings.Split(line[sep+1:], "|")
+	if len(parts) < 2 {
+		return m, errors.Errorf("invalid metric '%s': missing type", line)
+	}
+	m.value = parts[0]
+	m.metricType = parts[1]
+
+	switch m.metricType {
+	case typeCounter, typeGauge, typeTimer, typeHistogram:
+		if _, err := strconv.ParseFloat(m.value, 64); err != nil {
+			return m, errors.Errorf("invalid metric '%s': value is not a number", line)
+		}
+	case typeSet:
+	default:
+		return m, errors.Errorf("invalid metric '%s': unknown type '%s'", line, m.metricType)
+	}
+
+	for _, part := range parts[2:] {
+		switch {
+		case strings.HasPrefix(part, "@"):
+			rate, err := strconv.ParseFloat(part[1:], 64)
+			if err != nil || rate <= 0 || rate > 1 {
+				return m, errors.Errorf("invalid metric '%s': invalid sample rate", line)
+			}
+			m.sampleRate = rate
+		case strings.HasPrefix(part, "#"):
+			m.tags = parseTags(part[1:])
+		}
+	}
+
+	return m, nil
+}
+
+// parseTags parses DogStatsD tags. Tags without value are kept with an empty
+// value.
+func parseTags(s string) common.MapStr {
+	tags := common.MapStr{}
+	for _, tag := range strings.Split(s, ",") {
+		if tag == "" {
+			continue
+		}
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) == 2 {
+			tags[kv[0]] = kv[1]
+		} else {
+			tags[kv[0]] = ""
+		}
+	}
+	return tags
+}
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected statsdMetric
+	}{
+		{
+			input: "requests:1|c",
+			expected: statsdMetric{
+				name: "requests", value: "1", metricType: typeCounter, sampleRate: 1,
+			},
+		},
+		{
+			input: "requests:2|c|@0.1",
+			expected: statsdMetric{
+				name: "requests", value: "2", metricType: typeCounter, sampleRate: 0.1,
+			},
+		},
+		{
+			input: "queue.size:-3|g",
+			expected: statsdMetric{
+				name: "queue.size", value: "-3", metricType: typeGauge, sampleRate: 1,
+			},
+		},
+		{
+			input: "response_time:320|ms|@0.5|#env:prod,region:eu",
+			expected: statsdMetric{
+				name: "response_time", value: "320", metricType: typeTimer, sampleRate: 0.5,
+				tags: common.MapStr{"env": "prod", "region": "eu"},
+			},
+		},
+		{
+			input: "size:12.5|h|#canary",
+			expected: statsdMetric{
+				name: "size", value: "12.5", metricType: typeHistogram, sampleRate: 1,
+				tags: common.MapStr{"canary": ""},
+			},
+		},
+		{
+			input: "users:alice|s",
+			expected: statsdMetric{
+				name: "users", value: "alice", metricType: typeSet, sampleRate: 1,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			metrics, err := parse([]byte(c.input))
+			require.NoError(t, err)
+			require.Len(t, metrics, 1)
+			assert.Equal(t, c.expected, metrics[0])
+		})
+	}
+}
+
+func TestParseMultipleLines(t *testing.T) {
+	metrics, err := parse([]byte("a:1|c\nb:2|g\n\n"))
+	require.NoError(t, err)
+	assert.Len(t, metrics, 2)
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{
+		"requests",
+		"requests:1",
+		":1|c",
+		"requests:one|c",
+		"requests:1|x",
+		"requests:1|c|@2",
+	} {
+		t.Run(input, func(t *testing.T) {
+			metrics, err := parse([]byte(input + "\nvalid:1|c"))
+			assert.Error(t, err)
+			require.Len(t, metrics, 1)
+			assert.Equal(t, "valid", metrics[0].name)
+		})
+	}
+}
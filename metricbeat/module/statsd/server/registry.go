@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// registry aggregates the received metrics in memory until they are flushed.
+// Metrics are grouped by their set of tags, each group is reported as a
+// single event. It is not safe for concurrent use.
+type registry struct {
+	groups map[string]*metricGroup
+}
+
+type metricGroup struct {
+	tags     common.MapStr
+	counters map[string]float64
+	gauges   map[string]*gauge
+	timers   map[string]*timer
+	sets     map[string]map[string]struct{}
+}
+
+// gauge keeps its value between flushes so relative updates can be applied,
+// but it is only reported when it has been updated since the last flush.
+type gauge struct {
+	value   float64
+	updated bool
+}
+
+type timer struct {
+	count   float64
+	samples []float64
+}
+
+func newRegistry() *registry {
+	return &registry{groups: map[string]*metricGroup{}}
+}
+
+func (r *registry) add(m statsdMetric) {
+	g := r.group(m.tags)
+
+	switch m.metricType {
+	case typeCounter:
+		v, _ := strconv.ParseFloat(m.value, 64)
+		g.counters[m.name] += v / m.sampleRate
+	case typeGauge:
+		v, _ := strconv.ParseFloat(m.value, 64)
+		gg, found := g.gauges[m.name]
+		if !found {
+			gg = &gauge{}
+			g.gauges[m.name] = gg
+		}
+		if strings.HasPrefix(m.value, "+") || strings.HasPrefix(m.value, "-") {
+			gg.value += v
+		} else {
+			gg.value = v
+		}
+		gg.updated = true
+	case typeTimer, typeHistogram:
+		v, _ := strconv.ParseFloat(m.value, 64)
+		t, found := g.timers[m.name]
+		if !found {
+			t = &timer{}
+			g.timers[m.name] = t
+		}
+		t.count += 1 / m.sampleRate
+		t.samples = append(t.samples, v)
+	case typeSet:
+		s, found := g.sets[m.name]
+		if !found {
+			s = map[string]struct{}{}
+			g.sets[m.name] = s
+		}
+		s[m.value] = struct{}{}
+	}
+}
+
+func (r *registry) group(tags common.MapStr) *metricGroup {
+	key := tagsKey(tags)
+	g, found := r.groups[key]
+	if !found {
+		g = &metricGroup{
+			tags:     tags,
+			counters: map[string]float64{},
+			gauges:   map[string]*gauge{},
+			timers:   map[string]*timer{},
+			sets:     map[string]map[string]struct{}{},
+		}
+		r.groups[key] = g
+	}
+	return g
+}
+
+// flush returns an event for each group with metrics updated since the last
+// flush and resets counters, timers and sets.
+func (r *registry) flush(percentiles []float64) []mb.Event {
+	var events []mb.Event
+	for key, g := range r.groups {
+		metrics := common.MapStr{}
+
+		for name, v := range g.counters {
+			metrics[name] = common.MapStr{"count": v}
+		}
+		for name, gg := range g.gauges {
+			if gg.updated {
+				metrics[name] = common.MapStr{"value": gg.value}
+				gg.updated = false
+			}
+		}
+		for name, t := range g.timers {
+			metrics[name] = t.stats(percentiles)
+		}
+		for name, s := range g.sets {
+			metrics[name] = common.MapStr{"count": len(s)}
+		}
+
+		g.counters = map[string]float64{}
+		g.timers = map[string]*timer{}
+		g.sets = map[string]map[string]struct{}{}
+		if len(g.gauges) == 0 {
+			delete(r.groups, key)
+		}
+
+		if len(metrics) == 0 {
+			continue
+		}
+
+		fields := common.MapStr{"metrics": metrics}
+		if len(g.tags) > 0 {
+			fields["labels"] = g.tags
+		}
+		events = append(events, mb.Event{ModuleFields: fields})
+	}
+	return events
+}
+
+func (t *timer) stats(percentiles []float64) common.MapStr {
+	samples := t.samples
+	sort.Float64s(samples)
+
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+
+	stats := common.MapStr{
+		"count": t.count,
+		"sum":   sum,
+		"min":   samples[0],
+		"max":   samples[len(samples)-1],
+		"mean":  sum / float64(len(samples)),
+	}
+	for _, p := range percentiles {
+		stats[percentileName(p)] = percentile(samples, p)
+	}
+	return stats
+}
+
+// percentile returns the p-th percentile of the sorted samples using the
+// nearest-rank method.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// percentileName returns the field name used for a percentile, p99 for the
+// 99th percentile or p99_9 for the 99.9th.
+func percentileName(p float64) string {
+	return "p" + strings.Replace(strconv.FormatFloat(p, 'f', -1, 64), ".", "_", 1)
+}
+
+// tagsKey builds a key that is the same for equal sets of tags, independently
+// of their order.
+func tagsKey(tags common.MapStr) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(tags[k].(string))
+		b.WriteByte(0)
+	}
+	return b.String()
+}
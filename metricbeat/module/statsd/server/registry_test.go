@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package server
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func addLines(t *testing.T, r *registry, packet string) {
+	metrics, err := parse([]byte(packet))
+	require.NoError(t, err)
+	for _, m := range metrics {
+		r.add(m)
+	}
+}
+
+func TestRegistryFlush(t *testing.T) {
+	r := newRegistry()
+	addLines(t, r, "requests:1|c\nrequests:2|c|@0.5\n"+
+		"queue:10|g\nqueue:+5|g\nqueue:-2|g\n"+
+		"users:alice|s\nusers:bob|s\nusers:alice|s")
+
+	events := r.flush(nil)
+	require.Len(t, events, 1)
+	assert.Equal(t, common.MapStr{
+		"metrics": common.MapStr{
+			"requests": common.MapStr{"count": float64(5)},
+			"queue":    common.MapStr{"value": float64(13)},
+			"users":    common.MapStr{"count": 2},
+		},
+	}, events[0].ModuleFields)
+
+	// Counters and sets are reset, and gauges are only reported again when
+	// they are updated.
+	assert.Empty(t, r.flush(nil))
+
+	addLines(t, r, "queue:+1|g")
+	events = r.flush(nil)
+	require.Len(t, events, 1)
+	assert.Equal(t, common.MapStr{"value": float64(14)}, events[0].ModuleFields["metrics"].(common.MapStr)["queue"])
+}
+
+func TestRegistryTimers(t *testing.T) {
+	r := newRegistry()
+	for i := 1; i <= 100; i++ {
+		r.add(statsdMetric{name: "latency", metricType: typeTimer, value: strconv.Itoa(i), sampleRate: 1})
+	}
+
+	events := r.flush([]float64{50, 99, 99.9})
+	require.Len(t, events, 1)
+	assert.Equal(t, common.MapStr{
+		"count": float64(100),
+		"sum":   float64(5050),
+		"min":   float64(1),
+		"max":   float64(100),
+		"mean":  float64(50.5),
+		"p50":   float64(50),
+		"p99":   float64(99),
+		"p99_9": float64(100),
+	}, events[0].ModuleFields["metrics"].(common.MapStr)["latency"])
+}
+
+func TestRegistryGroupsByTags(t *testing.T) {
+	r := newRegistry()
+	addLines(t, r, "requests:1|c|#env:prod,region:eu\n"+
+		"requests:1|c|#region:eu,env:prod\n"+
+		"requests:1|c|#env:dev\n"+
+		"requests:1|c")
+
+	events := r.flush(nil)
+	require.Len(t, events, 3)
+
+	counts := map[string]interface{}{}
+	for _, e := range events {
+		count, err := e.ModuleFields.GetValue("metrics.requests.count")
+		require.NoError(t, err)
+		labels, _ := e.ModuleFields["labels"].(common.MapStr)
+		counts[tagsKey(labels)] = count
+	}
+	assert.Equal(t, map[string]interface{}{
+		tagsKey(common.MapStr{"env": "prod", "region": "eu"}): float64(2),
+		tagsKey(common.MapStr{"env": "dev"}):                  float64(1),
+		tagsKey(nil):                                          float64(1),
+	}, counts)
+}
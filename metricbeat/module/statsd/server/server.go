@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/libbeat/logp"
+	serverhelper "github.com/elastic/beats/metricbeat/helper/server"
+	"github.com/elastic/beats/metricbeat/helper/server/udp"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("statsd", "server", New,
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet receives metrics from StatsD clients and reports them aggregated
+// on every period.
+type MetricSet struct {
+	mb.BaseMetricSet
+	server      serverhelper.Server
+	registry    *registry
+	percentiles []float64
+	log         *logp.Logger
+}
+
+// New creates a new statsd server metricset.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The statsd server metricset is beta")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	svc, err := udp.NewUdpServerWithDefaults(base, udp.UdpConfig{
+		Host:              "localhost",
+		Port:              8125,
+		ReceiveBufferSize: 8192,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		server:        svc,
+		registry:      newRegistry(),
+		percentiles:   config.Percentiles,
+		log:           logp.NewLogger("statsd"),
+	}, nil
+}
+
+// Run starts the UDP server and reports the aggregated metrics once per
+// period until the metricset is stopped.
+func (m *MetricSet) Run(reporter mb.PushReporterV2) {
+	if err := m.server.Start(); err != nil {
+		reporter.Error(errors.Wrap(err, "failed to start statsd server"))
+		return
+	}
+
+	ticker := time.NewTicker(m.Module().Config().Period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-reporter.Done():
+			m.server.Stop()
+			return
+		case <-ticker.C:
+			for _, e := range m.registry.flush(m.percentiles) {
+				reporter.Event(e)
+			}
+		case msg := <-m.server.GetEvents():
+			m.processPacket(msg)
+		}
+	}
+}
+
+func (m *MetricSet) processPacket(msg serverhelper.Event) {
+	data, ok := msg.GetEvent()[serverhelper.EventDataKey].([]byte)
+	if !ok || len(data) == 0 {
+		return
+	}
+
+	metrics, err := parse(data)
+	if err != nil {
+		m.log.Debugw("Failed to parse metrics", "error", err, "client_ip", msg.GetMeta()["client_ip"])
+	}
+	for _, metric := range metrics {
+		m.registry.add(metric)
+	}
+}
@@ -23,4 +23,6 @@ import (
 	_ "github.com/elastic/beats/x-pack/metricbeat/module/mssql/transaction_log"
 	_ "github.com/elastic/beats/x-pack/metricbeat/module/oracle"
 	_ "github.com/elastic/beats/x-pack/metricbeat/module/oracle/tablespace"
+	_ "github.com/elastic/beats/x-pack/metricbeat/module/sql"
+	_ "github.com/elastic/beats/x-pack/metricbeat/module/sql/query"
 )
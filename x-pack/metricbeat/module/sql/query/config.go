@@ -0,0 +1,53 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	tableResponseFormat     = "table"
+	variablesResponseFormat = "variables"
+)
+
+type config struct {
+	Driver  string        `config:"driver" validate:"required"`
+	Queries []queryConfig `config:"sql_queries" validate:"required"`
+}
+
+type queryConfig struct {
+	Query          string `config:"query" validate:"required"`
+	ResponseFormat string `config:"response_format"`
+}
+
+func defaultConfig() config {
+	return config{}
+}
+
+func (c *config) Validate() error {
+	drivers := sql.Drivers()
+	i := sort.SearchStrings(drivers, c.Driver)
+	if i == len(drivers) || drivers[i] != c.Driver {
+		return errors.Errorf("unknown driver '%s', supported drivers are: %s", c.Driver, strings.Join(drivers, ", "))
+	}
+	return nil
+}
+
+func (q *queryConfig) Validate() error {
+	switch q.ResponseFormat {
+	case "":
+		q.ResponseFormat = tableResponseFormat
+	case tableResponseFormat, variablesResponseFormat:
+	default:
+		return errors.Errorf("invalid response_format '%s', expected '%s' or '%s'",
+			q.ResponseFormat, tableResponseFormat, variablesResponseFormat)
+	}
+	return nil
+}
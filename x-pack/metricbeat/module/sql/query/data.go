@@ -0,0 +1,109 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// tableMetrics returns the metrics of a row, keyed by column name.
+func tableMetrics(columns []string, row []interface{}) common.MapStr {
+	metrics := newMetrics()
+	for i, column := range columns {
+		metrics.add(column, row[i])
+	}
+	return metrics.mapStr()
+}
+
+// variablesMetrics returns the metrics of a result with two columns, using the
+// values of the first one as names and the values of the second one as
+// values.
+func variablesMetrics(columns []string, rows [][]interface{}) (common.MapStr, error) {
+	if len(columns) != 2 {
+		return nil, errors.Errorf("variables response format requires 2 columns, query returned %d", len(columns))
+	}
+
+	metrics := newMetrics()
+	for _, row := range rows {
+		name, ok := toString(row[0])
+		if !ok {
+			continue
+		}
+		metrics.add(name, row[1])
+	}
+	return metrics.mapStr(), nil
+}
+
+// metrics classifies values by their type, so numbers are kept apart from
+// strings and booleans and can be indexed as such.
+type metrics struct {
+	numeric common.MapStr
+	str     common.MapStr
+	boolean common.MapStr
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		numeric: common.MapStr{},
+		str:     common.MapStr{},
+		boolean: common.MapStr{},
+	}
+}
+
+func (m *metrics) add(name string, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+	case bool:
+		m.boolean[name] = v
+	case int64:
+		m.numeric[name] = v
+	case float64:
+		m.numeric[name] = v
+	case time.Time:
+		m.str[name] = v.Format(time.RFC3339Nano)
+	default:
+		s, _ := toString(v)
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			m.numeric[name] = n
+		} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+			m.numeric[name] = f
+		} else {
+			m.str[name] = s
+		}
+	}
+}
+
+func (m *metrics) mapStr() common.MapStr {
+	result := common.MapStr{}
+	if len(m.numeric) > 0 {
+		result["numeric"] = m.numeric
+	}
+	if len(m.str) > 0 {
+		result["string"] = m.str
+	}
+	if len(m.boolean) > 0 {
+		result["boolean"] = m.boolean
+	}
+	return result
+}
+
+func toString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case []byte:
+		return string(v), true
+	case string:
+		return v, true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
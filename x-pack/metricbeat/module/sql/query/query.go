@@ -0,0 +1,193 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	// Register the database drivers supported by the metricset. The MySQL and
+	// PostgreSQL drivers are vendored within their modules, which register
+	// them when imported.
+	_ "github.com/denisenkom/go-mssqldb"
+	_ "github.com/elastic/beats/metricbeat/module/mysql"
+	_ "github.com/elastic/beats/metricbeat/module/postgresql"
+	_ "gopkg.in/goracle.v2"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("sql", "query", New,
+		mb.WithHostParser(ParseDSN),
+		mb.DefaultMetricSet(),
+	)
+}
+
+var (
+	// keyValueDSN matches data source names given as list of key=value pairs,
+	// like `host=db user=u` or `server=db;user id=u`.
+	keyValueDSN = regexp.MustCompile(`^\s*[\w ]+=`)
+
+	// passwordPair matches key=value pairs whose key contains `pass`,
+	// together with the separator in front of them and an optional
+	// separator following them.
+	passwordPair = regexp.MustCompile(`(?i)(^|[\s;&?])[\w.-]*pass\w*\s*=\s*('[^']*'|"[^"]*"|[^\s;&]*)[;&]?`)
+)
+
+// ParseDSN returns the host data of a data source name, removing the
+// credentials it may contain from the sanitized version. Credentials given
+// as `user:password@` prefix and key=value pairs with a key containing
+// `pass`, like `password=secret`, are removed.
+func ParseDSN(mod mb.Module, host string) (mb.HostData, error) {
+	sanitized := host
+	if i := strings.LastIndex(host, "@"); i >= 0 && !keyValueDSN.MatchString(host) {
+		sanitized = host[i+1:]
+		if j := strings.Index(host, "://"); j >= 0 && j < i {
+			sanitized = host[:j+3] + sanitized
+		}
+	}
+	sanitized = strings.TrimSpace(passwordPair.ReplaceAllStringFunc(sanitized, removePair))
+
+	return mb.HostData{
+		URI:          host,
+		SanitizedURI: sanitized,
+		Host:         sanitized,
+	}, nil
+}
+
+// removePair removes a key=value pair matched by passwordPair, keeping the
+// separators required by the remaining pairs.
+func removePair(pair string) string {
+	first, last := pair[:1], pair[len(pair)-1:]
+	switch {
+	case first == "?":
+		return "?"
+	case strings.ContainsAny(first, " \t\n;&") && strings.ContainsAny(last, ";&"):
+		return last
+	}
+	return ""
+}
+
+// MetricSet runs the configured queries against a database and reports their
+// results.
+type MetricSet struct {
+	mb.BaseMetricSet
+	config config
+	db     *sql.DB
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The sql 'query' metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, errors.Wrap(err, "error parsing config file")
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		config:        config,
+	}, nil
+}
+
+// Fetch runs the configured queries and reports an event for each row in the
+// table response format, or a single event per query in the variables
+// response format.
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	if m.db == nil {
+		db, err := sql.Open(m.config.Driver, m.HostData().URI)
+		if err != nil {
+			return errors.Wrap(err, "could not open database")
+		}
+		m.db = db
+	}
+
+	for _, q := range m.config.Queries {
+		columns, rows, err := m.query(ctx, q.Query)
+		if err != nil {
+			reporter.Error(errors.Wrapf(err, "error running query '%s'", q.Query))
+			continue
+		}
+
+		var events []mb.Event
+		switch q.ResponseFormat {
+		case variablesResponseFormat:
+			metrics, err := variablesMetrics(columns, rows)
+			if err != nil {
+				reporter.Error(errors.Wrapf(err, "error reading results of query '%s'", q.Query))
+				continue
+			}
+			events = append(events, m.event(q.Query, metrics))
+		default:
+			for _, row := range rows {
+				events = append(events, m.event(q.Query, tableMetrics(columns, row)))
+			}
+		}
+
+		for _, e := range events {
+			if !reporter.Event(e) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes the connections to the database.
+func (m *MetricSet) Close() error {
+	if m.db == nil {
+		return nil
+	}
+	return m.db.Close()
+}
+
+func (m *MetricSet) query(ctx context.Context, query string) ([]string, [][]interface{}, error) {
+	rows, err := m.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values [][]interface{}
+	for rows.Next() {
+		row := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, nil, err
+		}
+		values = append(values, row)
+	}
+	return columns, values, rows.Err()
+}
+
+func (m *MetricSet) event(query string, metrics common.MapStr) mb.Event {
+	return mb.Event{
+		ModuleFields: common.MapStr{
+			"driver":  m.config.Driver,
+			"query":   query,
+			"metrics": metrics,
+		},
+	}
+}